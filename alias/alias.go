@@ -0,0 +1,93 @@
+// Package alias serves services under the names they had before, such as
+// greet.GreetService before greet.v1, and maps every name of a method to
+// the current one, so that authorization policies and rate limits written
+// for the current names apply to the aliases too.
+package alias
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+)
+
+var (
+	mu sync.RWMutex
+	// services maps the aliases of services to the name they stand for.
+	services = make(map[string]string)
+)
+
+// Register makes service old an alias of service current. current may be an
+// alias itself, e.g. of a newer version. Packages register their aliases
+// when they are initialized.
+func Register(old, current string) {
+	mu.Lock()
+	defer mu.Unlock()
+	services[old] = current
+}
+
+// Method returns fullMethod, such as /greet.GreetService/Greet, under the
+// current name of its service: /greet.v1.GreetService/Greet. Service
+// wildcards like /greet.GreetService/* are mapped too, and other names are
+// returned unchanged.
+func Method(fullMethod string) string {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok || !strings.HasPrefix(fullMethod, "/") {
+		return fullMethod
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	current, found := service, false
+	// Following at most as many aliases as there are stops at cycles.
+	for range len(services) {
+		next, ok := services[current]
+		if !ok {
+			break
+		}
+		current, found = next, true
+	}
+	if !found {
+		return fullMethod
+	}
+	return "/" + current + "/" + method
+}
+
+// Desc returns desc served under the service name, with only the methods
+// and streams named in rpcs, those the service had under that name, and
+// registers name as an alias of desc.ServiceName. Interceptors see the
+// full method names of unary calls under name, as they do for streams.
+func Desc(desc grpc.ServiceDesc, name string, rpcs ...string) grpc.ServiceDesc {
+	Register(name, desc.ServiceName)
+	desc.ServiceName = name
+
+	var methods []grpc.MethodDesc
+	for _, m := range desc.Methods {
+		if !slices.Contains(rpcs, m.MethodName) {
+			continue
+		}
+		handler, fullMethod := m.Handler, "/"+name+"/"+m.MethodName
+		m.Handler = func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			if interceptor == nil {
+				return handler(srv, ctx, dec, nil)
+			}
+			return handler(srv, ctx, dec, func(ctx context.Context, req any, info *grpc.UnaryServerInfo, h grpc.UnaryHandler) (any, error) {
+				aliased := *info
+				aliased.FullMethod = fullMethod
+				return interceptor(ctx, req, &aliased, h)
+			})
+		}
+		methods = append(methods, m)
+	}
+	desc.Methods = methods
+
+	var streams []grpc.StreamDesc
+	for _, s := range desc.Streams {
+		if slices.Contains(rpcs, s.StreamName) {
+			streams = append(streams, s)
+		}
+	}
+	desc.Streams = streams
+	return desc
+}
@@ -0,0 +1,68 @@
+package alias
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestMethod(t *testing.T) {
+	Register("test.Service", "test.v1.Service")
+	Register("test.v1.Service", "test.v2.Service")
+	Register("test.A", "test.B")
+	Register("test.B", "test.A")
+	tests := []struct {
+		method, want string
+	}{
+		{"/test.Service/Call", "/test.v2.Service/Call"},
+		{"/test.v1.Service/Call", "/test.v2.Service/Call"},
+		{"/test.v2.Service/Call", "/test.v2.Service/Call"},
+		{"/test.Service/*", "/test.v2.Service/*"},
+		{"/other.Service/Call", "/other.Service/Call"},
+		{"*", "*"},
+		{"test.Service/Call", "test.Service/Call"},
+		{"/test.A/Call", "/test.A/Call"},
+	}
+	for _, tt := range tests {
+		if got := Method(tt.method); got != tt.want {
+			t.Errorf("Method(%q) = %q, want %q", tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestDesc(t *testing.T) {
+	var seen string
+	handler := func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/desc.v1.Service/Call"}
+		return interceptor(ctx, nil, info, func(context.Context, any) (any, error) { return nil, nil })
+	}
+	desc := grpc.ServiceDesc{
+		ServiceName: "desc.v1.Service",
+		Methods:     []grpc.MethodDesc{{MethodName: "Call", Handler: handler}, {MethodName: "New", Handler: handler}},
+		Streams:     []grpc.StreamDesc{{StreamName: "Watch"}, {StreamName: "NewWatch"}},
+	}
+
+	legacy := Desc(desc, "desc.Service", "Call", "Watch")
+	if legacy.ServiceName != "desc.Service" || len(legacy.Methods) != 1 || legacy.Methods[0].MethodName != "Call" ||
+		len(legacy.Streams) != 1 || legacy.Streams[0].StreamName != "Watch" {
+		t.Fatalf("Desc = %+v, want desc.Service with Call and Watch only", legacy)
+	}
+	if len(desc.Methods) != 2 || len(desc.Streams) != 2 {
+		t.Errorf("Desc changed the methods of the original descriptor")
+	}
+
+	record := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, h grpc.UnaryHandler) (any, error) {
+		seen = info.FullMethod
+		return h(ctx, req)
+	}
+	if _, err := legacy.Methods[0].Handler(nil, context.Background(), nil, record); err != nil {
+		t.Fatal(err)
+	}
+	if seen != "/desc.Service/Call" {
+		t.Errorf("interceptor saw %q, want /desc.Service/Call", seen)
+	}
+	if got := Method("/desc.Service/Call"); got != "/desc.v1.Service/Call" {
+		t.Errorf("Method of the legacy name = %q, want /desc.v1.Service/Call", got)
+	}
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// apiKeyFile is the layout of the file read by LoadAPIKeys:
+//
+//	{
+//	  "keys": [
+//	    {"key": "s3cr3t", "principal": "billing-service", "roles": ["billing"]},
+//	    {"key": "0ld", "principal": "legacy-job", "disabled": true}
+//	  ]
+//	}
+type apiKeyFile struct {
+	Keys []struct {
+		Key       string   `json:"key"`
+		Principal string   `json:"principal"`
+		Roles     []string `json:"roles"`
+		Disabled  bool     `json:"disabled"`
+	} `json:"keys"`
+}
+
+type apiKey struct {
+	principal Principal
+	disabled  bool
+}
+
+// APIKeys verifies static API keys. Keys are indexed by their SHA-256 digest
+// so the plain values are not kept in memory.
+type APIKeys struct {
+	keys map[[sha256.Size]byte]apiKey
+}
+
+// LoadAPIKeys reads the API keys stored in the JSON file at path.
+func LoadAPIKeys(path string) (*APIKeys, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file apiKeyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing API keys %s: %v", path, err)
+	}
+
+	keys := &APIKeys{keys: make(map[[sha256.Size]byte]apiKey)}
+	for i, k := range file.Keys {
+		if k.Key == "" || k.Principal == "" {
+			return nil, fmt.Errorf("parsing API keys %s: entry %d needs a key and a principal", path, i)
+		}
+		keys.keys[sha256.Sum256([]byte(k.Key))] = apiKey{
+			principal: Principal{Name: k.Principal, Roles: k.Roles, Source: "apikey"},
+			disabled:  k.Disabled,
+		}
+	}
+	return keys, nil
+}
+
+// Verify looks the token up among the loaded keys.
+func (a *APIKeys) Verify(token string) (*Principal, error) {
+	// JWTs are handled by JWTVerifier.
+	if strings.Count(token, ".") == 2 {
+		return nil, errUnsupported
+	}
+
+	k, ok := a.keys[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	if k.disabled {
+		return nil, ErrForbidden
+	}
+	// Callers get their own copy, roles included.
+	p := k.principal
+	p.Roles = append([]string(nil), p.Roles...)
+	return &p, nil
+}
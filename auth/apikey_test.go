@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeAPIKeys writes the API key file text and returns its path.
+func writeAPIKeys(t *testing.T, text string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "keys.json")
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAPIKeys(t *testing.T) {
+	keys, err := LoadAPIKeys(writeAPIKeys(t, `{"keys": [
+		{"key": "s3cr3t", "principal": "billing-service", "roles": ["billing"]},
+		{"key": "0ld", "principal": "legacy-job", "disabled": true}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := keys.Verify("s3cr3t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "billing-service" || p.Source != "apikey" || !p.HasRole("billing") {
+		t.Errorf("Verify = %+v, want billing-service with the billing role", p)
+	}
+	// Principals are copies, so handlers cannot change the keys.
+	p.Roles[0] = "admin"
+	if p, _ := keys.Verify("s3cr3t"); !p.HasRole("billing") {
+		t.Error("changing a principal changed its key")
+	}
+
+	tests := []struct {
+		token string
+		want  error
+	}{
+		{"0ld", ErrForbidden},
+		{"wrong", ErrInvalidToken},
+		{"a.b.c", errUnsupported},
+	}
+	for _, tt := range tests {
+		if _, err := keys.Verify(tt.token); !errors.Is(err, tt.want) {
+			t.Errorf("Verify(%q) = %v, want %v", tt.token, err, tt.want)
+		}
+	}
+}
+
+func TestLoadAPIKeysErrors(t *testing.T) {
+	for _, text := range []string{
+		"{",
+		`{"keys": [{"principal": "nobody"}]}`,
+		`{"keys": [{"key": "s3cr3t"}]}`,
+	} {
+		if _, err := LoadAPIKeys(writeAPIKeys(t, text)); err == nil {
+			t.Errorf("LoadAPIKeys(%s) succeeded", text)
+		}
+	}
+	if _, err := LoadAPIKeys(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadAPIKeys of a missing file succeeded")
+	}
+}
@@ -0,0 +1,93 @@
+// Package auth authenticates callers of the gRPC services using bearer tokens
+// sent in the request metadata. Tokens can be static API keys loaded from a
+// file or JWTs verified against a local JWKS file.
+package auth
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Principal identifies the caller of an RPC once its token has been verified.
+type Principal struct {
+	// Name is the API key owner or the JWT subject.
+	Name string
+	// Roles are the roles granted to the caller.
+	Roles []string
+	// Source tells which kind of credential identified the caller.
+	Source string
+}
+
+// HasRole reports whether the principal was granted the given role.
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// ErrInvalidToken is returned by a Verifier when the token is malformed,
+	// expired or signed with an unknown key.
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrForbidden is returned by a Verifier when the token is valid but
+	// must not be used against this server (revoked key, wrong audience).
+	ErrForbidden = errors.New("token not allowed")
+	// errUnsupported is returned by a Verifier that does not understand the
+	// token format, so the next one can be tried.
+	errUnsupported = errors.New("unsupported token")
+)
+
+// Verifier turns a bearer token into a Principal.
+type Verifier interface {
+	Verify(token string) (*Principal, error)
+}
+
+type principalKey struct{}
+
+// NewContext returns a copy of ctx carrying the principal p.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal stored in ctx by the server interceptors.
+// The second value is false for unauthenticated calls.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// forwardedForKey carries the address of a client whose call was bridged in
+// process, like ratelimit.ForwardedForKey.
+const forwardedForKey = "x-forwarded-for"
+
+// Owner identifies the caller in ctx as the owner of a resource it creates,
+// such as a session: the principal when the call was authenticated, and the
+// host of the peer otherwise, so that reconnections from another port still
+// own it. Calls bridged in process, for instance from gRPC-Web, are owned by
+// the host they were forwarded for. It is empty when neither is known.
+func Owner(ctx context.Context) string {
+	if p, ok := FromContext(ctx); ok {
+		return p.Source + ":" + p.Name
+	}
+	pr, ok := peer.FromContext(ctx)
+	if !ok || pr.Addr == nil {
+		return ""
+	}
+	if pr.Addr.Network() == "bufconn" {
+		if forwarded := metadata.ValueFromIncomingContext(ctx, forwardedForKey); len(forwarded) > 0 {
+			return "peer:" + forwarded[0]
+		}
+	}
+	addr := pr.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return "peer:" + addr
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestOwner(t *testing.T) {
+	fromTCP := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("198.51.100.1"), Port: 4000}})
+	fromOtherPort := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("198.51.100.1"), Port: 4001}})
+	lis := bufconn.Listen(1)
+	defer lis.Close()
+	bridged := metadata.NewIncomingContext(
+		peer.NewContext(context.Background(), &peer.Peer{Addr: lis.Addr()}),
+		metadata.Pairs(forwardedForKey, "203.0.113.7"))
+	spoofed := metadata.NewIncomingContext(fromTCP, metadata.Pairs(forwardedForKey, "203.0.113.7"))
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"principal", NewContext(fromTCP, &Principal{Name: "alice", Source: "jwt"}), "jwt:alice"},
+		{"peer", fromTCP, "peer:198.51.100.1"},
+		{"other port", fromOtherPort, "peer:198.51.100.1"},
+		{"bridged call", bridged, "peer:203.0.113.7"},
+		{"forwarded over TCP", spoofed, "peer:198.51.100.1"},
+		{"unknown", context.Background(), ""},
+	}
+	for _, tt := range tests {
+		if got := Owner(tt.ctx); got != tt.want {
+			t.Errorf("%s: Owner = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
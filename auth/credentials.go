@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// TokenCredentials attaches a bearer token to every call made on a client
+// connection. It implements credentials.PerRPCCredentials.
+type TokenCredentials struct {
+	Token string
+	// Insecure allows sending the token over plaintext connections, which
+	// is what the example clients use.
+	Insecure bool
+}
+
+// GetRequestMetadata returns the authorization header for the call.
+func (c TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.Token}, nil
+}
+
+// RequireTransportSecurity tells gRPC whether the token needs TLS.
+func (c TokenCredentials) RequireTransportSecurity() bool {
+	return !c.Insecure
+}
+
+// ReadTokenFile reads a token from path, ignoring surrounding whitespace.
+func ReadTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
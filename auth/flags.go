@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// ServerFlags holds the command line flags configuring authentication and
+// authorization on a server.
+type ServerFlags struct {
+	APIKeys      string
+	JWKS         string
+	JWTIssuer    string
+	JWTAudience  string
+	Policy       string
+	PolicyReload time.Duration
+	AuditLog     string
+
+	// enforcer enforces Policy once ServerOptions built it.
+	enforcer *Enforcer
+}
+
+// RegisterFlags defines the server authentication flags on fs.
+func (f *ServerFlags) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&f.APIKeys, "api-keys", "", "JSON file with the accepted API keys")
+	fs.StringVar(&f.JWKS, "jwks", "", "JWKS file with the keys used to verify JWTs")
+	fs.StringVar(&f.JWTIssuer, "jwt-issuer", "", "required \"iss\" claim of JWTs")
+	fs.StringVar(&f.JWTAudience, "jwt-audience", "", "required \"aud\" claim of JWTs")
+	fs.StringVar(&f.Policy, "policy", "", "YAML file mapping principals and roles to the methods they may call")
+	fs.DurationVar(&f.PolicyReload, "policy-reload", 5*time.Second, "how often the policy file is checked for changes (0 disables reloading)")
+	fs.StringVar(&f.AuditLog, "audit-log", "", "file receiving the audit log of denied calls (default stderr)")
+}
+
+// Authenticator builds an Authenticator from the flags. It returns nil when
+// neither API keys nor a JWKS were configured.
+func (f *ServerFlags) Authenticator() (*Authenticator, error) {
+	var verifiers []Verifier
+	if f.APIKeys != "" {
+		keys, err := LoadAPIKeys(f.APIKeys)
+		if err != nil {
+			return nil, err
+		}
+		verifiers = append(verifiers, keys)
+	}
+	if f.JWKS != "" {
+		v, err := LoadJWKS(f.JWKS, f.JWTIssuer, f.JWTAudience)
+		if err != nil {
+			return nil, err
+		}
+		verifiers = append(verifiers, v)
+	}
+	if len(verifiers) == 0 {
+		return nil, nil
+	}
+	return NewAuthenticator(verifiers...), nil
+}
+
+// Enforcer builds an Enforcer from the flags and starts watching the policy
+// file for changes. It returns nil when no policy was configured.
+func (f *ServerFlags) Enforcer() (*Enforcer, error) {
+	if f.Policy == "" {
+		return nil, nil
+	}
+
+	var audit io.Writer = os.Stderr
+	if f.AuditLog != "" {
+		file, err := os.OpenFile(f.AuditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		audit = file
+	}
+
+	e, err := NewEnforcer(f.Policy, audit)
+	if err != nil {
+		return nil, err
+	}
+	go e.Watch(f.PolicyReload, nil)
+	return e, nil
+}
+
+// ServerOptions returns the interceptors authenticating and authorizing
+// calls as configured by the flags.
+func (f *ServerFlags) ServerOptions() ([]grpc.ServerOption, error) {
+	authenticator, err := f.Authenticator()
+	if err != nil {
+		return nil, err
+	}
+	enforcer, err := f.Enforcer()
+	if err != nil {
+		return nil, err
+	}
+	f.enforcer = enforcer
+
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	if authenticator != nil {
+		if enforcer != nil {
+			authenticator.Public = enforcer.IsPublic
+		}
+		unary = append(unary, authenticator.UnaryServerInterceptor())
+		stream = append(stream, authenticator.StreamServerInterceptor())
+	} else {
+		log.Println("Authentication disabled: neither -api-keys nor -jwks were given")
+	}
+	if enforcer != nil {
+		unary = append(unary, enforcer.UnaryServerInterceptor())
+		stream = append(stream, enforcer.StreamServerInterceptor())
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}, nil
+}
+
+// Restricts reports whether the policy enforced by the interceptors of
+// ServerOptions lets only some principals or roles call method. It is false
+// without a policy.
+func (f *ServerFlags) Restricts(method string) bool {
+	return f.enforcer != nil && f.enforcer.Restricts(method)
+}
+
+// ClientFlags holds the command line flags configuring the token sent by a
+// client.
+type ClientFlags struct {
+	Token     string
+	TokenFile string
+}
+
+// RegisterFlags defines the client authentication flags on fs.
+func (f *ClientFlags) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&f.Token, "token", "", "bearer token (API key or JWT) sent with every call")
+	fs.StringVar(&f.TokenFile, "token-file", "", "file holding the bearer token sent with every call")
+}
+
+// DialOptions returns the options attaching the configured token to every
+// call, or none when no token was given.
+func (f *ClientFlags) DialOptions() ([]grpc.DialOption, error) {
+	token := f.Token
+	if f.TokenFile != "" {
+		t, err := ReadTokenFile(f.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		token = t
+	}
+	if token == "" {
+		return nil, nil
+	}
+	creds := TokenCredentials{Token: token, Insecure: true}
+	return []grpc.DialOption{grpc.WithPerRPCCredentials(creds)}, nil
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// healthPrefix is the prefix of the health service methods, which anyone
+// may call: balancing clients check every server without credentials, and
+// a policy denying them would mark every server NOT_SERVING.
+const healthPrefix = "/grpc.health.v1.Health/"
+
+// isHealth reports whether method belongs to the health service.
+func isHealth(method string) bool {
+	return strings.HasPrefix(method, healthPrefix)
+}
+
+// Authenticator checks the bearer token of every incoming call against its
+// verifiers and stores the resulting Principal in the handler context.
+type Authenticator struct {
+	verifiers []Verifier
+	// Public, when set, lets calls to the methods it reports as public
+	// through without a token.
+	Public func(fullMethod string) bool
+}
+
+// NewAuthenticator returns an Authenticator trying each verifier in order.
+func NewAuthenticator(verifiers ...Verifier) *Authenticator {
+	return &Authenticator{verifiers: verifiers}
+}
+
+// UnaryServerInterceptor authenticates unary calls.
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor authenticates streaming calls.
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (a *Authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		if isHealth(method) || a.Public != nil && a.Public(method) {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	for _, v := range a.verifiers {
+		p, err := v.Verify(token)
+		switch {
+		case err == nil:
+			return NewContext(ctx, p), nil
+		case errors.Is(err, errUnsupported):
+			continue
+		case errors.Is(err, ErrForbidden):
+			log.Printf("auth: rejected call to %s: %v", method, err)
+			return nil, status.Error(codes.PermissionDenied, "credentials are not allowed on this server")
+		default:
+			log.Printf("auth: rejected call to %s: %v", method, err)
+			return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+		}
+	}
+	return nil, status.Error(codes.Unauthenticated, "unsupported bearer token")
+}
+
+// bearerToken extracts the token from the "authorization" metadata entry.
+// It returns an empty token when the entry is missing.
+func bearerToken(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return "", status.Error(codes.Unauthenticated, "authorization must use the Bearer scheme")
+	}
+	return strings.TrimSpace(token), nil
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		authorization []string
+		want          string
+		code          codes.Code
+	}{
+		{nil, "", codes.OK},
+		{[]string{"Bearer s3cr3t"}, "s3cr3t", codes.OK},
+		{[]string{"bearer  s3cr3t "}, "s3cr3t", codes.OK},
+		{[]string{"Basic dXNlcjpwYXNz"}, "", codes.Unauthenticated},
+		{[]string{"Bearer"}, "", codes.Unauthenticated},
+		{[]string{"Bearer "}, "", codes.Unauthenticated},
+		{[]string{"s3cr3t"}, "", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		md := metadata.MD{}
+		if tt.authorization != nil {
+			md.Set("authorization", tt.authorization...)
+		}
+		got, err := bearerToken(metadata.NewIncomingContext(context.Background(), md))
+		if got != tt.want || status.Code(err) != tt.code {
+			t.Errorf("bearerToken(%q) = %q, %v, want %q, %v", tt.authorization, got, err, tt.want, tt.code)
+		}
+	}
+}
+
+func TestAuthenticator(t *testing.T) {
+	keys, err := LoadAPIKeys(writeAPIKeys(t, `{"keys": [
+		{"key": "s3cr3t", "principal": "billing-service"},
+		{"key": "0ld", "principal": "legacy-job", "disabled": true}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, jwtKeys := writeJWKS(t)
+	jwts, err := LoadJWKS(path, "", "greet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := NewAuthenticator(keys, jwts)
+	a.Public = func(method string) bool { return method == "/test.Service/Public" }
+
+	intercept := a.UnaryServerInterceptor()
+	call := func(method, authorization string) (*Principal, error) {
+		md := metadata.MD{}
+		if authorization != "" {
+			md.Set("authorization", authorization)
+		}
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		var principal *Principal
+		_, err := intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			principal, _ = FromContext(ctx)
+			return nil, nil
+		})
+		return principal, err
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		principal     string
+		want          codes.Code
+	}{
+		{"API key", "/test.Service/Call", "Bearer s3cr3t", "billing-service", codes.OK},
+		{"JWT", "/test.Service/Call", "Bearer " + sign(t, jwt.SigningMethodEdDSA, "ed", jwtKeys.ed, jwtClaimsFor("alice", "greet")), "alice", codes.OK},
+		{"no token", "/test.Service/Call", "", "", codes.Unauthenticated},
+		{"no token on a public method", "/test.Service/Public", "", "", codes.OK},
+		{"no token on the health service", "/grpc.health.v1.Health/Check", "", "", codes.OK},
+		{"token on a public method", "/test.Service/Public", "Bearer s3cr3t", "billing-service", codes.OK},
+		{"other scheme", "/test.Service/Call", "Basic s3cr3t", "", codes.Unauthenticated},
+		{"unknown API key", "/test.Service/Call", "Bearer wrong", "", codes.Unauthenticated},
+		{"disabled API key", "/test.Service/Call", "Bearer 0ld", "", codes.PermissionDenied},
+		{"forged JWT", "/test.Service/Call", "Bearer " + sign(t, jwt.SigningMethodEdDSA, "ed", jwtKeys.unknown, jwtClaimsFor("alice", "greet")), "", codes.Unauthenticated},
+		{"JWT of another audience", "/test.Service/Call", "Bearer " + sign(t, jwt.SigningMethodEdDSA, "ed", jwtKeys.ed, jwtClaimsFor("alice", "calculator")), "", codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		p, err := call(tt.method, tt.authorization)
+		if status.Code(err) != tt.want {
+			t.Errorf("%s: code = %v, want %v", tt.name, err, tt.want)
+			continue
+		}
+		got := ""
+		if p != nil {
+			got = p.Name
+		}
+		if got != tt.principal {
+			t.Errorf("%s: principal = %q, want %q", tt.name, got, tt.principal)
+		}
+	}
+
+	// Without a verifier understanding the token, calls are rejected.
+	if _, err := NewAuthenticator(keys).UnaryServerInterceptor()(
+		metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer a.b.c")),
+		nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Call"},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil },
+	); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("unsupported token = %v, want Unauthenticated", err)
+	}
+}
@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testKeys are the signing keys of the JWKS written by writeJWKS.
+type testKeys struct {
+	ec      *ecdsa.PrivateKey
+	ed      ed25519.PrivateKey
+	unknown ed25519.PrivateKey
+}
+
+// writeJWKS writes a JWKS with an EC key "ec" and an Ed25519 key "ed" to a
+// file, and returns its path and the private keys.
+func writeJWKS(t *testing.T) (string, *testKeys) {
+	t.Helper()
+	ec, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, ed, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, unknown, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b64 := base64.RawURLEncoding.EncodeToString
+	set := map[string][]jsonWebKey{"keys": {
+		{Kty: "EC", Kid: "ec", Crv: "P-256", X: b64(ec.X.FillBytes(make([]byte, 32))), Y: b64(ec.Y.FillBytes(make([]byte, 32)))},
+		{Kty: "OKP", Kid: "ed", Use: "sig", Crv: "Ed25519", X: b64(edPub)},
+		// Encryption keys are ignored.
+		{Kty: "OKP", Kid: "enc", Use: "enc", Crv: "X25519", X: b64(edPub)},
+	}}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path, &testKeys{ec: ec, ed: ed, unknown: unknown}
+}
+
+// sign returns a token with claims signed by key under the key ID kid.
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// jwtClaimsFor returns valid claims of subject for audience.
+func jwtClaimsFor(subject, audience string) jwt.Claims {
+	return jwt.RegisteredClaims{
+		Subject:   subject,
+		Audience:  jwt.ClaimStrings{audience},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+func TestJWTVerifier(t *testing.T) {
+	path, keys := writeJWKS(t)
+	v, err := LoadJWKS(path, "https://issuer", "greet")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hour := jwt.NewNumericDate(time.Now().Add(time.Hour))
+	valid := func() *jwtClaims {
+		return &jwtClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "alice",
+				Issuer:    "https://issuer",
+				Audience:  jwt.ClaimStrings{"greet", "calculator"},
+				ExpiresAt: hour,
+			},
+			Roles: []string{"reader"},
+			Scope: "writer admin",
+		}
+	}
+	with := func(change func(c *jwtClaims)) *jwtClaims {
+		c := valid()
+		change(c)
+		return c
+	}
+
+	p, err := v.Verify(sign(t, jwt.SigningMethodES256, "ec", keys.ec, valid()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "alice" || p.Source != "jwt" || !p.HasRole("reader") || !p.HasRole("admin") {
+		t.Errorf("Verify = %+v, want alice with the roles and scopes of the token", p)
+	}
+	if _, err := v.Verify(sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed, valid())); err != nil {
+		t.Errorf("Verify of an EdDSA token = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"unknown key", sign(t, jwt.SigningMethodEdDSA, "ed", keys.unknown, valid()), ErrInvalidToken},
+		{"unknown key ID", sign(t, jwt.SigningMethodEdDSA, "other", keys.ed, valid()), ErrInvalidToken},
+		{"no key ID with several keys", sign(t, jwt.SigningMethodEdDSA, "", keys.ed, valid()), ErrInvalidToken},
+		{"HMAC", sign(t, jwt.SigningMethodHS256, "ed", []byte("secret"), valid()), ErrInvalidToken},
+		{"expired", sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed, with(func(c *jwtClaims) {
+			c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		})), ErrInvalidToken},
+		{"no expiry", sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed, with(func(c *jwtClaims) { c.ExpiresAt = nil })), ErrInvalidToken},
+		{"other issuer", sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed, with(func(c *jwtClaims) { c.Issuer = "https://other" })), ErrInvalidToken},
+		{"no subject", sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed, with(func(c *jwtClaims) { c.Subject = "" })), ErrInvalidToken},
+		{"other audience", sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed, with(func(c *jwtClaims) {
+			c.Audience = jwt.ClaimStrings{"calculator"}
+		})), ErrForbidden},
+		{"tampered", sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed, valid()) + "x", ErrInvalidToken},
+		{"API key", "s3cr3t", errUnsupported},
+	}
+	for _, tt := range tests {
+		if _, err := v.Verify(tt.token); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Verify = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestJWTVerifierSingleKey(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(map[string][]jsonWebKey{"keys": {
+		{Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(pub)},
+	}})
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Without an issuer or an audience, neither claim is checked.
+	v, err := LoadJWKS(path, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := sign(t, jwt.SigningMethodEdDSA, "", key, jwt.RegisteredClaims{
+		Subject:   "job",
+		Issuer:    "anyone",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	})
+	if p, err := v.Verify(token); err != nil || p.Name != "job" {
+		t.Errorf("Verify of a token without key ID = %v, %v", p, err)
+	}
+}
+
+func TestLoadJWKSErrors(t *testing.T) {
+	dir := t.TempDir()
+	for name, text := range map[string]string{
+		"not JSON":        "{",
+		"no keys":         `{"keys": []}`,
+		"only encryption": `{"keys": [{"kty": "RSA", "use": "enc", "n": "AQAB", "e": "AQAB"}]}`,
+		"unknown type":    `{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`,
+		"unknown curve":   `{"keys": [{"kty": "EC", "crv": "P-192", "x": "AQ", "y": "AQ"}]}`,
+		"off the curve":   `{"keys": [{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`,
+		"short Ed25519":   `{"keys": [{"kty": "OKP", "crv": "Ed25519", "x": "AQ"}]}`,
+	} {
+		path := filepath.Join(dir, "jwks.json")
+		if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadJWKS(path, "", ""); err == nil {
+			t.Errorf("%s: LoadJWKS succeeded", name)
+		}
+	}
+}
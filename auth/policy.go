@@ -0,0 +1,288 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/alias"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+// Policy maps full gRPC method names to the callers allowed to invoke them.
+// It is loaded from a YAML file such as:
+//
+//	default: deny
+//	rules:
+//	  - method: /calculator.v2.CalculatorService/Calculate
+//	    roles: [billing]
+//	  - method: /greet.v1.GreetService/GreetEveryone
+//	    public: true
+//	  - method: /greet.v1.GreetService/*
+//	    principals: [alice]
+//
+// A rule's method is either a full method name, a service wildcard
+// ("/greet.v1.GreetService/*") or "*". The most specific matching rule decides;
+// calls matching no rule are handled according to Default. Methods are
+// matched under the current names of their services, so a rule applies to
+// the aliases of its method too, such as /greet.GreetService/Greet for
+// /greet.v1.GreetService/Greet.
+type Policy struct {
+	Default string `yaml:"default"`
+	Rules   []Rule `yaml:"rules"`
+}
+
+// Rule grants access to a method.
+type Rule struct {
+	Method string `yaml:"method"`
+	// Public methods can be called by anyone, even without a token.
+	Public     bool     `yaml:"public"`
+	Principals []string `yaml:"principals"`
+	Roles      []string `yaml:"roles"`
+}
+
+// LoadPolicy reads and validates the YAML policy at path.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing policy %s: %v", path, err)
+	}
+	switch p.Default {
+	case "":
+		p.Default = "deny"
+	case "allow", "deny":
+	default:
+		return nil, fmt.Errorf("parsing policy %s: default must be allow or deny, got %q", path, p.Default)
+	}
+	for i, r := range p.Rules {
+		if r.Method != "*" && !strings.HasPrefix(r.Method, "/") {
+			return nil, fmt.Errorf("parsing policy %s: rule %d: method %q must be a full method name", path, i, r.Method)
+		}
+		p.Rules[i].Method = alias.Method(r.Method)
+	}
+	return &p, nil
+}
+
+// rule returns the most specific rule matching method, or nil.
+func (p *Policy) rule(method string) *Rule {
+	method = alias.Method(method)
+	service := method
+	if i := strings.LastIndex(method, "/"); i >= 0 {
+		service = method[:i+1] + "*"
+	}
+
+	var best *Rule
+	bestScore := 0
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		score := 0
+		switch r.Method {
+		case method:
+			score = 3
+		case service:
+			score = 2
+		case "*":
+			score = 1
+		}
+		if score > bestScore {
+			best, bestScore = r, score
+		}
+	}
+	return best
+}
+
+// IsPublic reports whether method can be called without credentials.
+func (p *Policy) IsPublic(method string) bool {
+	r := p.rule(method)
+	if r == nil {
+		return p.Default == "allow"
+	}
+	return r.Public
+}
+
+// Restricts reports whether only some principals or roles may call method:
+// the rule matching it is not public, or no rule matches and the default
+// denies.
+func (p *Policy) Restricts(method string) bool {
+	r := p.rule(method)
+	if r == nil {
+		return p.Default != "allow"
+	}
+	return !r.Public
+}
+
+// Allowed reports whether the principal may call method. A nil principal
+// stands for an anonymous caller. When access is denied, the returned string
+// explains why.
+func (p *Policy) Allowed(method string, principal *Principal) (bool, string) {
+	r := p.rule(method)
+	if r == nil {
+		if p.Default == "allow" {
+			return true, ""
+		}
+		return false, "no rule matches the method"
+	}
+	if r.Public {
+		return true, ""
+	}
+	if principal == nil {
+		return false, fmt.Sprintf("rule %s requires credentials", r.Method)
+	}
+	if containsString(r.Principals, principal.Name) {
+		return true, ""
+	}
+	for _, role := range r.Roles {
+		if principal.HasRole(role) {
+			return true, ""
+		}
+	}
+	return false, fmt.Sprintf("rule %s does not grant access", r.Method)
+}
+
+// Enforcer checks every call against a Policy loaded from a file, reloading
+// it when the file changes, and writes an audit line for each denial.
+type Enforcer struct {
+	path  string
+	audit *log.Logger
+
+	mu      sync.RWMutex
+	policy  *Policy
+	modTime time.Time
+}
+
+// NewEnforcer loads the policy at path. Denials are written to audit.
+func NewEnforcer(path string, audit io.Writer) (*Enforcer, error) {
+	e := &Enforcer{
+		path:  path,
+		audit: log.New(audit, "audit: ", log.LstdFlags|log.LUTC),
+	}
+	if err := e.reload(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Policy returns the policy currently enforced.
+func (e *Enforcer) Policy() *Policy {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.policy
+}
+
+// IsPublic reports whether the current policy lets anyone call method. The
+// health service is always public.
+func (e *Enforcer) IsPublic(method string) bool {
+	return isHealth(method) || e.Policy().IsPublic(method)
+}
+
+// Restricts reports whether the current policy lets only some principals
+// or roles call method.
+func (e *Enforcer) Restricts(method string) bool {
+	return e.Policy().Restricts(method)
+}
+
+// Watch reloads the policy whenever its file modification time changes,
+// checking every interval until stop is closed. A policy file that fails to
+// parse is logged and the previous policy is kept. An interval of 0 or less
+// disables reloading.
+func (e *Enforcer) Watch(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			info, err := os.Stat(e.path)
+			if err != nil {
+				log.Printf("policy: %v", err)
+				continue
+			}
+			e.mu.RLock()
+			changed := !info.ModTime().Equal(e.modTime)
+			e.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := e.reload(); err != nil {
+				log.Printf("policy: keeping previous policy: %v", err)
+				continue
+			}
+			log.Printf("policy: reloaded %s", e.path)
+		}
+	}
+}
+
+func (e *Enforcer) reload() error {
+	info, err := os.Stat(e.path)
+	if err != nil {
+		return err
+	}
+	p, err := LoadPolicy(e.path)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	e.policy = p
+	e.modTime = info.ModTime()
+	e.mu.Unlock()
+	return nil
+}
+
+// UnaryServerInterceptor enforces the policy on unary calls, except those
+// of the health service. It must run after the Authenticator interceptor.
+func (e *Enforcer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := e.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor enforces the policy on streaming calls, except
+// those of the health service. It must run after the Authenticator
+// interceptor.
+func (e *Enforcer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := e.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (e *Enforcer) authorize(ctx context.Context, method string) error {
+	if isHealth(method) {
+		return nil
+	}
+	principal, _ := FromContext(ctx)
+	allowed, reason := e.Policy().Allowed(method, principal)
+	if allowed {
+		return nil
+	}
+
+	if principal == nil {
+		e.audit.Printf("denied method=%s principal=<anonymous> reason=%q", method, reason)
+		return status.Error(codes.Unauthenticated, "credentials required")
+	}
+	e.audit.Printf("denied method=%s principal=%s source=%s roles=%v reason=%q",
+		method, principal.Name, principal.Source, principal.Roles, reason)
+	return status.Errorf(codes.PermissionDenied, "%s may not call %s", principal.Name, method)
+}
@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/alias"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testPolicy = `
+default: deny
+rules:
+  - method: /test.v1.Service/Get
+    roles: [reader]
+  - method: /test.v1.Service/*
+    principals: [alice]
+  - method: /test.v1.Service/Health
+    public: true
+  - method: "*"
+    roles: [admin]
+`
+
+// writePolicy writes text to a policy file in dir and returns its path.
+func writePolicy(t *testing.T, dir, text string) string {
+	t.Helper()
+	path := filepath.Join(dir, "policy.yaml")
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPolicyAllowed(t *testing.T) {
+	alias.Register("test.Service", "test.v1.Service")
+	p, err := LoadPolicy(writePolicy(t, t.TempDir(), testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice := &Principal{Name: "alice"}
+	reader := &Principal{Name: "bob", Roles: []string{"reader"}}
+	admin := &Principal{Name: "carol", Roles: []string{"admin"}}
+	tests := []struct {
+		method    string
+		principal *Principal
+		want      bool
+	}{
+		// The exact rule wins over the service wildcard.
+		{"/test.v1.Service/Get", reader, true},
+		{"/test.v1.Service/Get", alice, false},
+		// The service wildcard wins over "*".
+		{"/test.v1.Service/Put", alice, true},
+		{"/test.v1.Service/Put", reader, false},
+		{"/test.v1.Service/Put", admin, false},
+		{"/test.v1.Service/Health", nil, true},
+		{"/other.Service/Put", admin, true},
+		{"/other.Service/Put", alice, false},
+		{"/other.Service/Put", nil, false},
+		// Aliases are matched under the current name.
+		{"/test.Service/Get", reader, true},
+		{"/test.Service/Get", alice, false},
+		{"/test.Service/Put", alice, true},
+	}
+	for _, tt := range tests {
+		if got, reason := p.Allowed(tt.method, tt.principal); got != tt.want {
+			t.Errorf("Allowed(%s, %v) = %v (%s), want %v", tt.method, tt.principal, got, reason, tt.want)
+		}
+	}
+
+	if !p.IsPublic("/test.Service/Health") || p.IsPublic("/test.v1.Service/Get") {
+		t.Error("IsPublic does not follow the public rules")
+	}
+	if !p.Restricts("/other.Service/Put") || p.Restricts("/test.v1.Service/Health") {
+		t.Error("Restricts does not follow the rules")
+	}
+}
+
+func TestPolicyDefault(t *testing.T) {
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		text string
+		want bool
+	}{
+		{"rules: []", false},
+		{"default: deny", false},
+		{"default: allow", true},
+	} {
+		p, err := LoadPolicy(writePolicy(t, dir, tt.text))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := p.Allowed("/test.v1.Service/Get", nil); got != tt.want {
+			t.Errorf("%q: Allowed = %v, want %v", tt.text, got, tt.want)
+		}
+		if p.Restricts("/test.v1.Service/Get") == tt.want {
+			t.Errorf("%q: Restricts = %v, want %v", tt.text, tt.want, !tt.want)
+		}
+	}
+}
+
+func TestLoadPolicyErrors(t *testing.T) {
+	dir := t.TempDir()
+	for _, text := range []string{
+		"default: maybe",
+		"rules: [{method: test.v1.Service/Get}]",
+		"rules: {",
+	} {
+		if _, err := LoadPolicy(writePolicy(t, dir, text)); err == nil {
+			t.Errorf("LoadPolicy(%q) succeeded", text)
+		}
+	}
+	if _, err := LoadPolicy(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("LoadPolicy of a missing file succeeded")
+	}
+}
+
+func TestEnforcerAudit(t *testing.T) {
+	var audit bytes.Buffer
+	e, err := NewEnforcer(writePolicy(t, t.TempDir(), testPolicy), &audit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := e.authorize(context.Background(), "/test.v1.Service/Get"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("anonymous call = %v, want Unauthenticated", err)
+	}
+	ctx := NewContext(context.Background(), &Principal{Name: "bob", Roles: []string{"writer"}, Source: "apikey"})
+	if err := e.authorize(ctx, "/test.v1.Service/Get"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("call without the role = %v, want PermissionDenied", err)
+	}
+	if err := e.authorize(context.Background(), "/test.v1.Service/Health"); err != nil {
+		t.Errorf("call of a public method = %v", err)
+	}
+	if err := e.authorize(context.Background(), "/grpc.health.v1.Health/Check"); err != nil {
+		t.Errorf("call of the health service = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(audit.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("audit log = %q, want a line per denial", audit.String())
+	}
+	if !strings.Contains(lines[0], "method=/test.v1.Service/Get principal=<anonymous>") {
+		t.Errorf("audit line = %q, want the method and an anonymous principal", lines[0])
+	}
+	if !strings.Contains(lines[1], "principal=bob source=apikey roles=[writer]") {
+		t.Errorf("audit line = %q, want the principal", lines[1])
+	}
+}
+
+func TestEnforcerWatch(t *testing.T) {
+	path := writePolicy(t, t.TempDir(), "default: deny")
+	e, err := NewEnforcer(path, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		e.Watch(time.Millisecond, stop)
+		close(done)
+	}()
+
+	// Modification times can be coarse, so the new files are dated later.
+	touch := func(text string, age time.Duration) {
+		t.Helper()
+		writePolicy(t, filepath.Dir(path), text)
+		mtime := time.Now().Add(age)
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitFor := func(allowed bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			if got, _ := e.Policy().Allowed("/test.v1.Service/Get", nil); got == allowed {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("policy still allows = %v", !allowed)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	touch("default: allow", time.Minute)
+	waitFor(true)
+	// A broken file keeps the previous policy, until the file is fixed.
+	touch("default: maybe", 2*time.Minute)
+	time.Sleep(20 * time.Millisecond)
+	if got, _ := e.Policy().Allowed("/test.v1.Service/Get", nil); !got {
+		t.Error("a broken policy file replaced the policy")
+	}
+	touch("default: deny", 3*time.Minute)
+	waitFor(false)
+	close(stop)
+	<-done
+
+	// Reloading is disabled by an interval of 0.
+	e.Watch(0, nil)
+}
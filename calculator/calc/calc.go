@@ -0,0 +1,76 @@
+// Package calc holds the arithmetic of CalculatorService, independently of
+// how it is requested: operations on two numbers, prime factorizations, and
+// the running average and maximum of a series of numbers. The gRPC service
+// in calcserver is a thin layer over it.
+package calc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownOperation is returned for an operation calc does not know.
+var ErrUnknownOperation = errors.New("unknown operation")
+
+// Operation is an operation on two numbers. Its values are those of the
+// Operation enums of the protos.
+type Operation int
+
+const (
+	Sum Operation = iota
+	Sub
+	Mul
+	Div
+)
+
+// Calculate returns a op b. Dividing by zero gives an infinity, or NaN for
+// 0 / 0, as in IEEE 754.
+func Calculate(op Operation, a, b float64) (float64, error) {
+	switch op {
+	case Sum:
+		return a + b, nil
+	case Sub:
+		return a - b, nil
+	case Mul:
+		return a * b, nil
+	case Div:
+		return a / b, nil
+	}
+	return 0, fmt.Errorf("%w: %d", ErrUnknownOperation, op)
+}
+
+// Average is the running average of a series of numbers.
+type Average struct {
+	// Count is how many numbers were averaged.
+	Count uint64
+	// Value is their average, 0 until Count is not.
+	Value float64
+}
+
+// Add adds x to the series. Both x and the average are divided before
+// being subtracted, so that the difference cannot overflow even when they
+// are finite numbers of opposite signs close to math.MaxFloat64.
+func (a *Average) Add(x float64) {
+	a.Count++
+	n := float64(a.Count)
+	a.Value += x/n - a.Value/n
+}
+
+// Maximum is the running maximum of a series of numbers.
+type Maximum struct {
+	// Count is how many numbers were compared.
+	Count uint64
+	// Value is the greatest of them, 0 until Count is not.
+	Value float64
+}
+
+// Add adds x to the series, and reports whether it is a new maximum, as
+// the first number always is.
+func (m *Maximum) Add(x float64) bool {
+	m.Count++
+	if m.Count == 1 || x > m.Value {
+		m.Value = x
+		return true
+	}
+	return false
+}
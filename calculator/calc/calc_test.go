@@ -0,0 +1,95 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		op   Operation
+		a, b float64
+		want float64
+	}{
+		{Sum, 1.5, 2.25, 3.75},
+		{Sub, 1.5, 2.25, -0.75},
+		{Mul, 1.5, -2, -3},
+		{Div, 3, 4, 0.75},
+		{Div, 1, 0, math.Inf(1)},
+		{Div, -1, 0, math.Inf(-1)},
+	}
+	for _, tt := range tests {
+		got, err := Calculate(tt.op, tt.a, tt.b)
+		if err != nil || got != tt.want {
+			t.Errorf("Calculate(%v, %v, %v) = %v, %v, want %v", tt.op, tt.a, tt.b, got, err, tt.want)
+		}
+	}
+
+	if got, err := Calculate(Div, 0, 0); err != nil || !math.IsNaN(got) {
+		t.Errorf("Calculate(Div, 0, 0) = %v, %v, want NaN", got, err)
+	}
+	if _, err := Calculate(Operation(9), 1, 2); !errors.Is(err, ErrUnknownOperation) {
+		t.Errorf("Calculate of an unknown operation = %v, want ErrUnknownOperation", err)
+	}
+}
+
+func TestFactors(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want []uint64
+	}{
+		{0, nil},
+		{1, nil},
+		{2, []uint64{2}},
+		{97, []uint64{97}},
+		{120, []uint64{2, 2, 2, 3, 5}},
+		{600851475143, []uint64{71, 839, 1471, 6857}},
+		{1021 * 1021, []uint64{1021, 1021}},
+		{18446744073709551557, []uint64{18446744073709551557}},
+		{18446744073709551615, []uint64{3, 5, 17, 257, 641, 65537, 6700417}},
+		{4294967279 * 4294967291, []uint64{4294967279, 4294967291}},
+		{1 << 63, slices.Repeat([]uint64{2}, 63)},
+	}
+	for _, tt := range tests {
+		if got := slices.Collect(Factors(tt.n)); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("Factors(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+
+	// Stopping early stops factorizing.
+	for prime := range Factors(1024) {
+		if prime != 2 {
+			t.Errorf("factor %d of 1024", prime)
+		}
+		break
+	}
+}
+
+func TestAverage(t *testing.T) {
+	var a Average
+	for _, x := range []float64{1, 2, 6, -1} {
+		a.Add(x)
+	}
+	if a.Count != 4 || a.Value != 2 {
+		t.Errorf("Average = %+v, want 2 of 4 numbers", a)
+	}
+}
+
+func TestMaximum(t *testing.T) {
+	var m Maximum
+	var maxima []float64
+	for _, x := range []float64{-3, -5, 2, 2, 7, 1} {
+		if m.Add(x) {
+			maxima = append(maxima, x)
+		}
+	}
+	if m.Count != 6 || m.Value != 7 {
+		t.Errorf("Maximum = %+v, want 7 of 6 numbers", m)
+	}
+	if fmt.Sprint(maxima) != "[-3 2 7]" {
+		t.Errorf("new maxima = %v, want [-3 2 7]", maxima)
+	}
+}
@@ -0,0 +1,144 @@
+package calc
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// trialLimit bounds the primes Factors tries by division. Larger factors
+// are found by Pollard's rho.
+const trialLimit = 1 << 10
+
+// Factors yields the prime factors of n in increasing order, each as many
+// times as it divides n. 0 and 1 have none. Small factors are found by
+// trial division and the others by Pollard's rho, so that any uint64,
+// large primes included, is factorized in milliseconds.
+func Factors(n uint64) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		if n < 2 {
+			return
+		}
+		prime := uint64(2)
+		for ; prime < trialLimit && prime <= n/prime; prime++ {
+			for n%prime == 0 {
+				if !yield(prime) {
+					return
+				}
+				n /= prime
+			}
+		}
+		if n == 1 {
+			return
+		}
+		if prime < trialLimit {
+			// No prime up to the square root of n divides it.
+			yield(n)
+			return
+		}
+		large := split(n, nil)
+		slices.Sort(large)
+		for _, p := range large {
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// split appends the prime factors of n, which has none below trialLimit,
+// to factors.
+func split(n uint64, factors []uint64) []uint64 {
+	if isPrime(n) {
+		return append(factors, n)
+	}
+	d := rho(n)
+	return split(n/d, split(d, factors))
+}
+
+// isPrime reports whether n is prime, by the Miller-Rabin test with the
+// first twelve primes as bases, which is deterministic below 2^64.
+func isPrime(n uint64) bool {
+	bases := []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+	for _, p := range bases {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+	if n < 2 {
+		return false
+	}
+	// n - 1 = d * 2^s with d odd.
+	d := n - 1
+	s := bits.TrailingZeros64(d)
+	d >>= s
+	for _, a := range bases {
+		x := powMod(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		composite := true
+		for range s - 1 {
+			x = mulMod(x, x, n)
+			if x == n-1 {
+				composite = false
+				break
+			}
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+// rho returns a nontrivial divisor of the odd composite n, by Pollard's rho
+// with Floyd's cycle detection.
+func rho(n uint64) uint64 {
+	for c := uint64(1); ; c++ {
+		// f is x^2 + c modulo n.
+		f := func(x uint64) uint64 {
+			sum, carry := bits.Add64(mulMod(x, x, n), c, 0)
+			if carry != 0 || sum >= n {
+				sum -= n
+			}
+			return sum
+		}
+		x, y, d := uint64(2), uint64(2), uint64(1)
+		for d == 1 {
+			x = f(x)
+			y = f(f(y))
+			d = gcd(max(x, y)-min(x, y), n)
+		}
+		if d != n {
+			return d
+		}
+	}
+}
+
+// mulMod returns a * b modulo m, for a and b below m.
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, rem := bits.Div64(hi, lo, m)
+	return rem
+}
+
+// powMod returns a^e modulo m.
+func powMod(a, e, m uint64) uint64 {
+	result := uint64(1)
+	a %= m
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = mulMod(result, a, m)
+		}
+		a = mulMod(a, a, m)
+	}
+	return result
+}
+
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
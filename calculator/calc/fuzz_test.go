@@ -0,0 +1,172 @@
+package calc
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// exact is the precision of the reference computations: enough bits to
+// hold the exact sum, difference or product of any two float64, and any
+// sum of them from the fuzz targets.
+const exact = 4096
+
+// reference returns a op b computed exactly with big.Float, then rounded
+// once to the nearest float64, as IEEE 754 requires of a + b, a - b, a * b
+// and a / b. Operations big.Float cannot carry out, such as Inf - Inf or
+// 0 / 0, give NaN.
+func reference(op Operation, a, b float64) (result float64) {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.NaN()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(big.ErrNaN); !ok {
+				panic(r)
+			}
+			result = math.NaN()
+		}
+	}()
+	x, y := big.NewFloat(a), big.NewFloat(b)
+	z := new(big.Float).SetPrec(exact)
+	switch op {
+	case Sum:
+		z.Add(x, y)
+	case Sub:
+		z.Sub(x, y)
+	case Mul:
+		z.Mul(x, y)
+	case Div:
+		z.Quo(x, y)
+	}
+	result, _ = z.Float64()
+	return result
+}
+
+// sameFloat reports whether a and b are the same float64, telling zeros
+// apart by sign and taking every NaN as the same.
+func sameFloat(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return math.Float64bits(a) == math.Float64bits(b)
+}
+
+func FuzzCalculate(f *testing.F) {
+	// testdata/fuzz/FuzzCalculate holds the edge cases of every operation:
+	// zeros of both signs, infinities, NaN and denormals.
+	f.Add(int(Sum), 0.1, 0.2)
+	f.Add(int(Div), 1.0, 3.0)
+	f.Fuzz(func(t *testing.T, op int, a, b float64) {
+		got, err := Calculate(Operation(op), a, b)
+		if op < int(Sum) || op > int(Div) {
+			if !errors.Is(err, ErrUnknownOperation) {
+				t.Fatalf("Calculate(%d, %v, %v) = %v, %v, want ErrUnknownOperation", op, a, b, got, err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("Calculate(%d, %v, %v) = %v", op, a, b, err)
+		}
+		if want := reference(Operation(op), a, b); !sameFloat(got, want) {
+			t.Errorf("Calculate(%d, %v, %v) = %v, want %v", op, a, b, got, want)
+		}
+	})
+}
+
+// floats decodes data as little endian float64, ignoring a trailing
+// partial number and NaN, which the service rejects, and keeping at most
+// limit numbers. Infinities are ignored too when finite is set.
+func floats(data []byte, limit int, finite bool) []float64 {
+	var xs []float64
+	for len(data) >= 8 && len(xs) < limit {
+		x := math.Float64frombits(binary.LittleEndian.Uint64(data))
+		data = data[8:]
+		if math.IsNaN(x) || finite && math.IsInf(x, 0) {
+			continue
+		}
+		xs = append(xs, x)
+	}
+	return xs
+}
+
+// encodeFloats encodes xs for floats.
+func encodeFloats(xs ...float64) []byte {
+	var data []byte
+	for _, x := range xs {
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(x))
+	}
+	return data
+}
+
+func FuzzAverage(f *testing.F) {
+	f.Add(encodeFloats(1, 2, 6, -1))
+	f.Add(encodeFloats(math.MaxFloat64, -math.MaxFloat64))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Only finite numbers can be averaged by the service.
+		xs := floats(data, 256, true)
+		var a Average
+		for _, x := range xs {
+			a.Add(x)
+		}
+		if a.Count != uint64(len(xs)) {
+			t.Fatalf("Count = %d, want %d", a.Count, len(xs))
+		}
+		if len(xs) == 0 {
+			if a.Value != 0 {
+				t.Fatalf("average of no numbers = %v, want 0", a.Value)
+			}
+			return
+		}
+
+		// The reference sums exactly and rounds once. The running average
+		// rounds at every number, by a few ulps of the largest number at
+		// most, or by denormals when they underflow.
+		sum := new(big.Float).SetPrec(exact)
+		largest := 0.0
+		for _, x := range xs {
+			sum.Add(sum, big.NewFloat(x))
+			largest = max(largest, math.Abs(x))
+		}
+		want, _ := sum.Quo(sum, new(big.Float).SetInt64(int64(len(xs)))).Float64()
+		n := float64(len(xs))
+		tolerance := largest*0x1p-52*4*n + n*math.SmallestNonzeroFloat64
+		if math.IsInf(a.Value, 0) || math.Abs(a.Value-want) > tolerance {
+			t.Errorf("average of %v = %v, want %v within %v", xs, a.Value, want, tolerance)
+		}
+	})
+}
+
+func FuzzMaximum(f *testing.F) {
+	f.Add(encodeFloats(-3, -5, 2, 2, 7, 1))
+	f.Add(encodeFloats(math.Copysign(0, -1), 0, math.Inf(-1)))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		xs := floats(data, 256, false)
+		var m Maximum
+		for i, x := range xs {
+			// The reference: a number is a new maximum when it exceeds the
+			// greatest number before it.
+			want := i == 0 || x > maxOf(xs[:i])
+			if got := m.Add(x); got != want {
+				t.Fatalf("Add(%v) after %v = %v, want %v", x, xs[:i], got, want)
+			}
+		}
+		if m.Count != uint64(len(xs)) {
+			t.Fatalf("Count = %d, want %d", m.Count, len(xs))
+		}
+		if len(xs) > 0 && m.Value != maxOf(xs) {
+			t.Errorf("maximum of %v = %v, want %v", xs, m.Value, maxOf(xs))
+		}
+	})
+}
+
+// maxOf returns the greatest of xs, which are not NaN.
+func maxOf(xs []float64) float64 {
+	result := xs[0]
+	for _, x := range xs[1:] {
+		result = max(result, x)
+	}
+	return result
+}
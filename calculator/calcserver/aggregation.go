@@ -0,0 +1,362 @@
+package calcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/auth"
+	"github.com/AlanKev117/go-grpc/calculator/calc"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorpb"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"github.com/AlanKev117/go-grpc/session"
+	"github.com/AlanKev117/go-grpc/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// aggregations checkpoints ComputeAverage aggregations and FindMaximum
+// sessions in a store, so they can be queried and resumed after a restart
+// of the server.
+type aggregations struct {
+	store store.Store
+	// interval is the least time between two checkpoints of a running
+	// aggregation. Aggregations are also saved when their stream ends.
+	interval time.Duration
+	// ttl is how long finished aggregations stay in the store, readable by
+	// GetAggregation, before they are deleted.
+	ttl time.Duration
+
+	// mu orders the checkpoints with the deletion of finished aggregations,
+	// so that a new aggregation reusing the ID of an expired one is not
+	// deleted in its place.
+	mu sync.Mutex
+	// finished holds the aggregations finished by this server, oldest
+	// first, until they expire.
+	finished []finishedAggregation
+}
+
+// finishedAggregation is an aggregation to delete once it expires.
+type finishedAggregation struct {
+	id      string
+	expires time.Time
+}
+
+// aggregationState is the session state of an aggregation.
+type aggregationState struct {
+	agg *calculatorv2pb.Aggregation
+	// unacked counts the numbers processed since the last response, for
+	// FindMaximum.
+	unacked int
+}
+
+func aggregationKey(id string) string {
+	return "aggregation/" + id
+}
+
+// ownerKey is the key of the auth.Owner of the stream that created
+// aggregation id.
+func ownerKey(id string) string {
+	return "aggregation-owner/" + id
+}
+
+// errNotOwner is returned for aggregations created by another caller.
+var errNotOwner = errors.New("aggregation belongs to another caller")
+
+// claim records owner as the creator of the new aggregation id.
+func (a *aggregations) claim(id, owner string) error {
+	return a.store.Put(ownerKey(id), []byte(owner))
+}
+
+// checkOwner returns errNotOwner unless owner created aggregation id.
+// Aggregations checkpointed before their owner was recorded belong to
+// everyone.
+func (a *aggregations) checkOwner(id, owner string) error {
+	creator, err := a.store.Get(ownerKey(id))
+	if err == store.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if string(creator) != owner {
+		return errNotOwner
+	}
+	return nil
+}
+
+// load returns the last checkpoint of aggregation id, or store.ErrNotFound
+// when it is missing or finished longer ago than the TTL. Expired
+// aggregations are deleted, including those finished before a restart.
+func (a *aggregations) load(id string) (*calculatorv2pb.Aggregation, error) {
+	data, err := a.store.Get(aggregationKey(id))
+	if err != nil {
+		return nil, err
+	}
+	agg, err := decodeAggregation(data)
+	if err != nil {
+		return nil, err
+	}
+	if a.expired(agg) {
+		a.mu.Lock()
+		err := a.deleteExpired(id)
+		a.mu.Unlock()
+		if err != nil {
+			log.Printf("Failed to delete finished aggregation %s: %v", id, err)
+		}
+		return nil, store.ErrNotFound
+	}
+	return agg, nil
+}
+
+func decodeAggregation(data []byte) (*calculatorv2pb.Aggregation, error) {
+	agg := &calculatorv2pb.Aggregation{}
+	if err := proto.Unmarshal(data, agg); err != nil {
+		return nil, err
+	}
+	if len(agg.ProtoReflect().GetUnknown()) > 0 {
+		// Checkpoints written before calculator.v2 keep the maximum in
+		// the int32 field that v2 reserved.
+		old := &calculatorpb.Aggregation{}
+		if err := proto.Unmarshal(data, old); err != nil {
+			return nil, err
+		}
+		if agg.GetMaximum() == 0 {
+			agg.Maximum = float64(old.GetMaximum())
+		}
+		agg.ProtoReflect().SetUnknown(nil)
+	}
+	return agg, nil
+}
+
+// save checkpoints agg, and deletes the finished aggregations that expired.
+func (a *aggregations) save(agg *calculatorv2pb.Aggregation) error {
+	agg.UpdateTime = timestamppb.Now()
+	data, err := proto.Marshal(agg)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sweep(time.Now())
+	if err := a.store.Put(aggregationKey(agg.GetId()), data); err != nil {
+		return err
+	}
+	if agg.GetDone() {
+		a.finished = append(a.finished, finishedAggregation{
+			id:      agg.GetId(),
+			expires: agg.GetUpdateTime().AsTime().Add(a.ttl),
+		})
+	}
+	return nil
+}
+
+// expired reports whether agg finished longer ago than the TTL.
+func (a *aggregations) expired(agg *calculatorv2pb.Aggregation) bool {
+	return agg.GetDone() && time.Since(agg.GetUpdateTime().AsTime()) >= a.ttl
+}
+
+// sweep deletes the finished aggregations that expired. It must be called
+// with a.mu held.
+func (a *aggregations) sweep(now time.Time) {
+	for len(a.finished) > 0 && !now.Before(a.finished[0].expires) {
+		id := a.finished[0].id
+		a.finished = a.finished[1:]
+		if err := a.deleteExpired(id); err != nil {
+			log.Printf("Failed to delete finished aggregation %s: %v", id, err)
+		}
+	}
+}
+
+// deleteExpired deletes aggregation id if it is still the expired one, and
+// not a new aggregation reusing its ID. It must be called with a.mu held.
+func (a *aggregations) deleteExpired(id string) error {
+	data, err := a.store.Get(aggregationKey(id))
+	if err == store.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	agg, err := decodeAggregation(data)
+	if err != nil {
+		return err
+	}
+	if !a.expired(agg) {
+		return nil
+	}
+	if err := a.store.Delete(aggregationKey(id)); err != nil {
+		return err
+	}
+	return a.store.Delete(ownerKey(id))
+}
+
+// checkpoint saves agg when the checkpoint interval elapsed since it was
+// last saved.
+func (a *aggregations) checkpoint(agg *calculatorv2pb.Aggregation) error {
+	if agg.GetUpdateTime() != nil && time.Since(agg.GetUpdateTime().AsTime()) < a.interval {
+		return nil
+	}
+	return a.save(agg)
+}
+
+// attachAggregation binds a stream of owner to the aggregation id of the
+// given kind. When the session is not known to this server, its state is
+// restored from the last checkpoint. The second value reports whether an
+// existing aggregation was resumed. Aggregations created by another owner
+// fail with PermissionDenied.
+func (s *Server) attachAggregation(id, owner string, kind calculatorv2pb.AggregationKind) (*session.Handle, bool, error) {
+	sess, resumed, err := s.sessions.Attach(id, owner)
+	if err == session.ErrNotOwner {
+		return nil, false, status.Errorf(codes.PermissionDenied, "aggregation %s: %v", id, err)
+	}
+	if err != nil {
+		return nil, false, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var attachErr error
+	err = sess.Do(func(st *session.State) {
+		if _, ok := st.Value.(*aggregationState); !ok {
+			agg, loadErr := s.aggregations.load(id)
+			if loadErr == nil {
+				loadErr = s.aggregations.checkOwner(id, owner)
+			}
+			switch {
+			case loadErr == store.ErrNotFound:
+				if err := s.aggregations.claim(id, owner); err != nil {
+					attachErr = status.Errorf(codes.Internal, "saving aggregation %s: %v", id, err)
+					return
+				}
+				agg = &calculatorv2pb.Aggregation{Id: id, Kind: kind}
+			case loadErr == errNotOwner:
+				attachErr = status.Errorf(codes.PermissionDenied, "aggregation %s: %v", id, loadErr)
+				return
+			case loadErr != nil:
+				attachErr = status.Errorf(codes.Internal, "loading aggregation %s: %v", id, loadErr)
+				return
+			default:
+				resumed = true
+			}
+			st.Value = &aggregationState{agg: agg}
+			st.LastSequence = agg.GetLastSequence()
+		}
+
+		agg := st.Value.(*aggregationState).agg
+		if agg.GetKind() != kind {
+			attachErr = status.Errorf(codes.FailedPrecondition, "aggregation %s is not a %v aggregation", id, kind)
+		} else if agg.GetDone() {
+			attachErr = status.Errorf(codes.FailedPrecondition, "aggregation %s is finished", id)
+		}
+	})
+	if err != nil {
+		return nil, false, status.Error(codes.Aborted, err.Error())
+	}
+	if attachErr != nil {
+		sess.Detach()
+		return nil, false, attachErr
+	}
+	return sess, resumed, nil
+}
+
+// GetAggregation returns the last checkpoint of an aggregation created by
+// the caller.
+func (s *Server) GetAggregation(ctx context.Context, req *calculatorv2pb.GetAggregationRequest) (*calculatorv2pb.Aggregation, error) {
+	agg, err := s.aggregations.load(req.GetId())
+	if err == nil {
+		err = s.aggregations.checkOwner(req.GetId(), auth.Owner(ctx))
+	}
+	if err == store.ErrNotFound {
+		return nil, status.Errorf(codes.NotFound, "no aggregation %s", req.GetId())
+	}
+	if err == errNotOwner {
+		return nil, status.Errorf(codes.PermissionDenied, "aggregation %s: %v", req.GetId(), err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "loading aggregation %s: %v", req.GetId(), err)
+	}
+	return agg, nil
+}
+
+// computeAverageAggregation serves a ComputeAverage stream whose first
+// message req names an aggregation. The running average is checkpointed, so
+// a client that lost its connection can ask GetAggregation for the last
+// sequence aggregated and resend the numbers after it.
+func (s *Server) computeAverageAggregation(stream calculatorv2pb.CalculatorService_ComputeAverageServer, req *calculatorv2pb.ComputeAverageRequest) error {
+	sess, resumed, err := s.attachAggregation(req.GetAggregationId(), auth.Owner(stream.Context()), calculatorv2pb.AggregationKind_AGGREGATION_AVERAGE)
+	if err != nil {
+		return err
+	}
+	if resumed {
+		log.Printf("Resuming ComputeAverage aggregation %s", sess.ID())
+	}
+
+	for {
+		var saveErr error
+		err := sess.Do(func(st *session.State) {
+			// Sequence 0 only attaches the stream, and numbers sent again
+			// after a reconnection were already aggregated.
+			if req.GetSequence() == 0 || req.GetSequence() <= st.LastSequence {
+				return
+			}
+			agg := st.Value.(*aggregationState).agg
+			st.LastSequence = req.GetSequence()
+			agg.LastSequence = st.LastSequence
+			avg := calc.Average{Count: agg.GetCount(), Value: agg.GetAverage()}
+			avg.Add(req.GetNumber())
+			agg.Count, agg.Average = avg.Count, avg.Value
+			saveErr = s.aggregations.checkpoint(agg)
+		})
+		if err != nil {
+			return status.Error(codes.Aborted, err.Error())
+		}
+		if saveErr != nil {
+			log.Printf("Failed to checkpoint aggregation %s: %v", sess.ID(), saveErr)
+		}
+
+		req, err = stream.Recv()
+		if err == io.EOF {
+			log.Printf("no more numbers left to calculate average of aggregation %s", sess.ID())
+			var res *calculatorv2pb.ComputeAverageResponse
+			err := sess.Do(func(st *session.State) {
+				agg := st.Value.(*aggregationState).agg
+				agg.Done = true
+				saveErr = s.aggregations.save(agg)
+				res = &calculatorv2pb.ComputeAverageResponse{
+					Average:       agg.GetAverage(),
+					AggregationId: sess.ID(),
+					Count:         agg.GetCount(),
+				}
+			})
+			if err != nil {
+				return status.Error(codes.Aborted, err.Error())
+			}
+			if saveErr != nil {
+				log.Printf("Failed to checkpoint aggregation %s: %v", sess.ID(), saveErr)
+			}
+			sess.Close()
+			return stream.SendAndClose(res)
+		}
+		if err != nil {
+			log.Printf("Aggregation %s interrupted: %v", sess.ID(), err)
+			s.detachAggregation(sess)
+			return err
+		}
+	}
+}
+
+// detachAggregation saves the state of an aggregation whose stream broke
+// and releases it.
+func (s *Server) detachAggregation(sess *session.Handle) {
+	sess.Do(func(st *session.State) {
+		if err := s.aggregations.save(st.Value.(*aggregationState).agg); err != nil {
+			log.Printf("Failed to checkpoint aggregation %s: %v", sess.ID(), err)
+		}
+	})
+	sess.Detach()
+}
@@ -0,0 +1,121 @@
+package calcserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+)
+
+// The benchmarks call every handler through startServer, so they include
+// the validation interceptors and the bufconn transport.
+
+func BenchmarkCalculate(b *testing.B) {
+	c, _ := startServer(b)
+	ctx := context.Background()
+	req := &calculatorv2pb.OperationRequest{OperationArgs: &calculatorv2pb.OperationArgs{
+		Value1: 3, Value2: 4, Operation: calculatorv2pb.Operation_OPCODE_DIV,
+	}}
+	for b.Loop() {
+		if _, err := c.Calculate(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPrimeNumberDecomposition(b *testing.B) {
+	c, _ := startServer(b)
+	ctx := context.Background()
+	req := &calculatorv2pb.PrimeNumberDecompositionRequest{Number: 600851475143}
+	for b.Loop() {
+		stream, err := c.PrimeNumberDecomposition(ctx, req)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for {
+			_, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// benchmarkComputeAverage averages 10 numbers per call, checkpointing them
+// in a new aggregation every call when aggregate is set.
+func benchmarkComputeAverage(b *testing.B, aggregate bool) {
+	c, _ := startServer(b)
+	ctx := context.Background()
+	calls := 0
+	for b.Loop() {
+		calls++
+		var id string
+		if aggregate {
+			id = fmt.Sprint("avg-", calls)
+		}
+		stream, err := c.ComputeAverage(ctx)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for i := range 10 {
+			if err := stream.Send(&calculatorv2pb.ComputeAverageRequest{Number: float64(i), AggregationId: id}); err != nil {
+				b.Fatal(err)
+			}
+		}
+		if _, err := stream.CloseAndRecv(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkComputeAverage(b *testing.B) { benchmarkComputeAverage(b, false) }
+
+func BenchmarkComputeAverageAggregation(b *testing.B) { benchmarkComputeAverage(b, true) }
+
+func BenchmarkFindMaximum(b *testing.B) {
+	c, _ := startServer(b)
+	ctx := context.Background()
+	for b.Loop() {
+		stream, err := c.FindMaximum(ctx)
+		if err != nil {
+			b.Fatal(err)
+		}
+		// Increasing numbers are all answered.
+		for i := range 10 {
+			if err := stream.Send(&calculatorv2pb.FindMaximumRequest{Number: float64(i)}); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := stream.Recv(); err != nil {
+				b.Fatal(err)
+			}
+		}
+		stream.CloseSend()
+		if _, err := stream.Recv(); err != io.EOF {
+			b.Fatalf("Recv after CloseSend = %v, want io.EOF", err)
+		}
+	}
+}
+
+func BenchmarkGetAggregation(b *testing.B) {
+	c, _ := startServer(b)
+	ctx := context.Background()
+	stream, err := c.ComputeAverage(ctx)
+	if err != nil {
+		b.Fatal(err)
+	}
+	stream.Send(&calculatorv2pb.ComputeAverageRequest{Number: 1, AggregationId: "avg"})
+	if _, err := stream.CloseAndRecv(); err != nil {
+		b.Fatal(err)
+	}
+	req := &calculatorv2pb.GetAggregationRequest{Id: "avg"}
+	for b.Loop() {
+		if _, err := c.GetAggregation(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
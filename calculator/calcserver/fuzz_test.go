@@ -0,0 +1,62 @@
+package calcserver
+
+import (
+	"context"
+	"io"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+)
+
+func FuzzPrimeNumberDecomposition(f *testing.F) {
+	// testdata/fuzz/FuzzPrimeNumberDecomposition holds more seeds: primes,
+	// prime powers, products of large primes, and numbers without factors.
+	for _, n := range []uint64{0, 1, 2, 120, 97, 1 << 63, math.MaxUint64} {
+		f.Add(n)
+	}
+	c, _ := startServer(f)
+	f.Fuzz(func(t *testing.T, n uint64) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		stream, err := c.PrimeNumberDecomposition(ctx, &calculatorv2pb.PrimeNumberDecompositionRequest{Number: n})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var factors []uint64
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("PrimeNumberDecomposition(%d): %v", n, err)
+			}
+			factors = append(factors, res.GetPrime())
+		}
+
+		// 0 and 1 have no factors. Every other number is the product of
+		// its factors, which are primes streamed in increasing order.
+		if n < 2 {
+			if len(factors) != 0 {
+				t.Fatalf("factors of %d = %v, want none", n, factors)
+			}
+			return
+		}
+		product := uint64(1)
+		for i, p := range factors {
+			if !new(big.Int).SetUint64(p).ProbablyPrime(0) {
+				t.Errorf("factor %d of %d is not prime", p, n)
+			}
+			if i > 0 && p < factors[i-1] {
+				t.Errorf("factors of %d = %v, not in increasing order", n, factors)
+			}
+			product *= p
+		}
+		if product != n {
+			t.Errorf("factors of %d = %v, whose product is %d", n, factors, product)
+		}
+	})
+}
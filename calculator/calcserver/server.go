@@ -0,0 +1,183 @@
+// Package calcserver serves CalculatorService over gRPC, in versions 1 and
+// 2. Its handlers turn requests into calls of calc, and checkpoint the
+// aggregations of streams so they can be resumed, so programs can serve the
+// service next to their own.
+package calcserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/calculator/calc"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorpb"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"github.com/AlanKev117/go-grpc/session"
+	"github.com/AlanKev117/go-grpc/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Options configures a Server. Zero fields get the defaults of
+// calculator_server.
+type Options struct {
+	// Store keeps the checkpoints of aggregations; they are kept in memory
+	// when nil.
+	Store store.Store
+	// SessionTTL is how long a broken FindMaximum session is kept in
+	// memory; 5 minutes when 0.
+	SessionTTL time.Duration
+	// CheckpointInterval is the least time between two checkpoints of a
+	// running aggregation; 0 checkpoints every number.
+	CheckpointInterval time.Duration
+	// AggregationTTL is how long a finished aggregation can still be read
+	// with GetAggregation before it is deleted from the store; 1 hour when
+	// 0.
+	AggregationTTL time.Duration
+}
+
+// Server implements calculator.v2 CalculatorService.
+type Server struct {
+	calculatorv2pb.UnimplementedCalculatorServiceServer
+
+	// sessions holds the state of resumable FindMaximum streams and of
+	// ComputeAverage aggregations.
+	sessions *session.Manager
+	// aggregations checkpoints the sessions.
+	aggregations *aggregations
+}
+
+// New returns a Server configured by opts.
+func New(opts Options) *Server {
+	if opts.Store == nil {
+		opts.Store = store.NewMemory()
+	}
+	if opts.SessionTTL == 0 {
+		opts.SessionTTL = 5 * time.Minute
+	}
+	if opts.AggregationTTL == 0 {
+		opts.AggregationTTL = time.Hour
+	}
+	return &Server{
+		sessions: session.NewManager(opts.SessionTTL),
+		aggregations: &aggregations{
+			store:    opts.Store,
+			interval: opts.CheckpointInterval,
+			ttl:      opts.AggregationTTL,
+		},
+	}
+}
+
+// Register registers both versions of the service on gs, v1 under its
+// current and legacy names. calculator.v1 shares the sessions and
+// aggregations of v2.
+func (s *Server) Register(gs *grpc.Server) {
+	calculatorv2pb.RegisterCalculatorServiceServer(gs, s)
+	v1 := &v1Server{v2: s}
+	calculatorpb.RegisterCalculatorServiceServer(gs, v1)
+	gs.RegisterService(&calculatorpb.LegacyCalculatorService_ServiceDesc, v1)
+}
+
+// Calculate returns the result of the operation of req.
+func (*Server) Calculate(ctx context.Context, req *calculatorv2pb.OperationRequest) (*calculatorv2pb.OperationResponse, error) {
+
+	fmt.Printf("Calculate function invoked with %v\n", req)
+
+	args := req.GetOperationArgs()
+	operationResult, err := calc.Calculate(calc.Operation(args.GetOperation()), args.GetValue1(), args.GetValue2())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result := &calculatorv2pb.OperationResponse{
+		Result: operationResult,
+	}
+
+	return result, nil
+}
+
+func (*Server) PrimeNumberDecomposition(req *calculatorv2pb.PrimeNumberDecompositionRequest, stream calculatorv2pb.CalculatorService_PrimeNumberDecompositionServer) error {
+	for prime := range calc.Factors(req.GetNumber()) {
+		// A client that gives up stops the decomposition.
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		err := stream.Send(&calculatorv2pb.PrimeNumberDecompositionResponse{
+			Prime: prime,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) ComputeAverage(stream calculatorv2pb.CalculatorService_ComputeAverageServer) error {
+	fmt.Println("Starting reading client stream...")
+
+	var avg calc.Average
+	for {
+		req, err := stream.Recv()
+
+		if err == io.EOF {
+			// The average of no numbers is undefined.
+			if avg.Count == 0 {
+				return status.Error(codes.InvalidArgument, "no numbers to average")
+			}
+			log.Println("no more numbers left to calculate average")
+			return stream.SendAndClose(&calculatorv2pb.ComputeAverageResponse{
+				Average: avg.Value,
+				Count:   avg.Count,
+			})
+		}
+		if err != nil {
+			log.Printf("error while reading from client stream: %v", err)
+			return err
+		}
+
+		// Clients naming an aggregation hand the stream over to the
+		// checkpointed implementation.
+		if req.GetAggregationId() != "" {
+			return s.computeAverageAggregation(stream, req)
+		}
+
+		avg.Add(req.GetNumber())
+	}
+}
+
+func (s *Server) FindMaximum(stream calculatorv2pb.CalculatorService_FindMaximumServer) error {
+	fmt.Println("Starting reading client stream...")
+	var maximum calc.Maximum
+	for {
+		req, err := stream.Recv()
+
+		if err == io.EOF {
+			log.Println("Max values found.")
+			return nil
+		}
+		if err != nil {
+			log.Printf("error while reading from client stream: %v", err)
+			return err
+		}
+
+		// Clients opening a session hand the stream over to the
+		// resumable implementation.
+		if req.GetSessionId() != "" {
+			return s.findMaximumSession(stream, req)
+		}
+
+		if maximum.Add(req.GetNumber()) {
+			err = stream.Send(&calculatorv2pb.FindMaximumResponse{
+				Maximum: maximum.Value,
+			})
+
+			if err != nil {
+				log.Printf("Error while sending maximum to client: %v", err)
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,542 @@
+package calcserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/auth"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorpb"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"github.com/AlanKev117/go-grpc/grpctest"
+	"github.com/AlanKev117/go-grpc/store"
+	"github.com/AlanKev117/go-grpc/validate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// startServer serves both versions of the calculator in memory, behind the
+// validation interceptors of the real server. Aggregations are checkpointed
+// after every number.
+func startServer(t testing.TB) (calculatorv2pb.CalculatorServiceClient, calculatorpb.CalculatorServiceClient) {
+	t.Helper()
+	conn := grpctest.Serve(t, New(Options{}).Register, grpctest.Options{ServerOptions: validate.ServerOptions()})
+	return calculatorv2pb.NewCalculatorServiceClient(conn), calculatorpb.NewCalculatorServiceClient(conn)
+}
+
+// testContext returns a context ending with the test.
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestCalculate(t *testing.T) {
+	c, _ := startServer(t)
+	tests := []struct {
+		op     calculatorv2pb.Operation
+		v1, v2 float64
+		want   float64
+	}{
+		{calculatorv2pb.Operation_OPCODE_SUM, 1.5, 2.25, 3.75},
+		{calculatorv2pb.Operation_OPCODE_SUB, 1.5, 2.25, -0.75},
+		{calculatorv2pb.Operation_OPCODE_MUL, 1.5, -2, -3},
+		{calculatorv2pb.Operation_OPCODE_DIV, 3, 4, 0.75},
+		{calculatorv2pb.Operation_OPCODE_DIV, 1, 0, math.Inf(1)},
+		{calculatorv2pb.Operation_OPCODE_DIV, -1, 0, math.Inf(-1)},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.op, tt.v1, tt.v2), func(t *testing.T) {
+			res, err := c.Calculate(testContext(t), &calculatorv2pb.OperationRequest{
+				OperationArgs: &calculatorv2pb.OperationArgs{Operation: tt.op, Value1: tt.v1, Value2: tt.v2},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.GetResult() != tt.want {
+				t.Errorf("Calculate = %v, want %v", res.GetResult(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateErrors(t *testing.T) {
+	c, _ := startServer(t)
+	tests := []struct {
+		name string
+		args *calculatorv2pb.OperationArgs
+	}{
+		{"no arguments", nil},
+		{"undefined operation", &calculatorv2pb.OperationArgs{Operation: 9}},
+		{"NaN", &calculatorv2pb.OperationArgs{Value1: math.NaN()}},
+		{"infinity", &calculatorv2pb.OperationArgs{Value2: math.Inf(1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.Calculate(testContext(t), &calculatorv2pb.OperationRequest{OperationArgs: tt.args})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Calculate = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestPrimeNumberDecomposition(t *testing.T) {
+	c, _ := startServer(t)
+	tests := []struct {
+		number uint64
+		want   []uint64
+	}{
+		{0, nil},
+		{1, nil},
+		{2, []uint64{2}},
+		{97, []uint64{97}},
+		{120, []uint64{2, 2, 2, 3, 5}},
+		{600851475143, []uint64{71, 839, 1471, 6857}},
+		// The largest primes below 2^64 and 2^32 are decomposed at once.
+		{18446744073709551557, []uint64{18446744073709551557}},
+		{4294967279 * 4294967291, []uint64{4294967279, 4294967291}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.number), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(testContext(t), time.Second)
+			defer cancel()
+			stream, err := c.PrimeNumberDecomposition(ctx, &calculatorv2pb.PrimeNumberDecompositionRequest{Number: tt.number})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []uint64
+			for {
+				res, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, res.GetPrime())
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("PrimeNumberDecomposition = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// average sends numbers on a ComputeAverage stream, the first with
+// aggregation id; sequences start at 1 when id is not empty.
+func average(ctx context.Context, c calculatorv2pb.CalculatorServiceClient, id string, numbers ...float64) (*calculatorv2pb.ComputeAverageResponse, error) {
+	stream, err := c.ComputeAverage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i, n := range numbers {
+		req := &calculatorv2pb.ComputeAverageRequest{Number: n}
+		if id != "" {
+			req.AggregationId = id
+			req.Sequence = uint64(i + 1)
+		}
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+func TestComputeAverage(t *testing.T) {
+	c, _ := startServer(t)
+
+	res, err := average(testContext(t), c, "", 1, 2, 3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetAverage() != 2.5 || res.GetCount() != 4 || res.GetAggregationId() != "" {
+		t.Errorf("ComputeAverage = %v, want 2.5 of 4 numbers", res)
+	}
+
+	if _, err := average(testContext(t), c, ""); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ComputeAverage of no numbers = %v, want InvalidArgument", err)
+	}
+	if _, err := average(testContext(t), c, "", 1, math.NaN()); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ComputeAverage of NaN = %v, want InvalidArgument", err)
+	}
+}
+
+func TestComputeAverageAggregation(t *testing.T) {
+	c, _ := startServer(t)
+	ctx := testContext(t)
+
+	res, err := average(ctx, c, "avg", 1, 2, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetAverage() != 3 || res.GetCount() != 3 || res.GetAggregationId() != "avg" {
+		t.Errorf("ComputeAverage = %v, want 3 of 3 numbers in avg", res)
+	}
+
+	agg, err := c.GetAggregation(ctx, &calculatorv2pb.GetAggregationRequest{Id: "avg"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agg.GetKind() != calculatorv2pb.AggregationKind_AGGREGATION_AVERAGE || agg.GetAverage() != 3 ||
+		agg.GetCount() != 3 || agg.GetLastSequence() != 3 || !agg.GetDone() || agg.GetUpdateTime() == nil {
+		t.Errorf("GetAggregation = %v", agg)
+	}
+
+	if _, err := average(ctx, c, "avg", 1); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("ComputeAverage of a finished aggregation = %v, want FailedPrecondition", err)
+	}
+}
+
+func TestAggregationTTL(t *testing.T) {
+	const ttl = 200 * time.Millisecond
+	state := store.NewMemory()
+	conn := grpctest.Serve(t, New(Options{Store: state, AggregationTTL: ttl}).Register, grpctest.Options{ServerOptions: validate.ServerOptions()})
+	c := calculatorv2pb.NewCalculatorServiceClient(conn)
+	ctx := testContext(t)
+
+	if _, err := average(ctx, c, "avg", 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetAggregation(ctx, &calculatorv2pb.GetAggregationRequest{Id: "avg"}); err != nil {
+		t.Fatalf("GetAggregation before the TTL: %v", err)
+	}
+	time.Sleep(ttl)
+
+	// Saving another aggregation deletes the expired one from the store.
+	if _, err := average(ctx, c, "other", 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := state.Get(aggregationKey("avg")); err != store.ErrNotFound {
+		t.Errorf("expired aggregation in the store: %v, want ErrNotFound", err)
+	}
+	if _, err := c.GetAggregation(ctx, &calculatorv2pb.GetAggregationRequest{Id: "avg"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetAggregation after the TTL = %v, want NotFound", err)
+	}
+
+	// The ID of an expired aggregation starts a new one.
+	res, err := average(ctx, c, "avg", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetAverage() != 5 || res.GetCount() != 1 {
+		t.Errorf("ComputeAverage reusing an expired ID = %v, want 5 of 1 number", res)
+	}
+
+	// Reading an expired aggregation deletes it too.
+	time.Sleep(ttl)
+	if _, err := c.GetAggregation(ctx, &calculatorv2pb.GetAggregationRequest{Id: "other"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetAggregation after the TTL = %v, want NotFound", err)
+	}
+	if _, err := state.Get(aggregationKey("other")); err != store.ErrNotFound {
+		t.Errorf("expired aggregation in the store: %v, want ErrNotFound", err)
+	}
+}
+
+func TestComputeAverageResume(t *testing.T) {
+	c, _ := startServer(t)
+
+	// The first stream breaks after two numbers.
+	ctx, cancel := context.WithCancel(testContext(t))
+	stream, err := c.ComputeAverage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, n := range []float64{2, 4} {
+		stream.Send(&calculatorv2pb.ComputeAverageRequest{Number: n, AggregationId: "avg", Sequence: uint64(i + 1)})
+	}
+	for {
+		agg, err := c.GetAggregation(testContext(t), &calculatorv2pb.GetAggregationRequest{Id: "avg"})
+		if err == nil && agg.GetLastSequence() == 2 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+
+	// The second stream resends the first two numbers, which are ignored.
+	res, err := average(testContext(t), c, "avg", 2, 4, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetAverage() != 5 || res.GetCount() != 3 {
+		t.Errorf("resumed ComputeAverage = %v, want 5 of 3 numbers", res)
+	}
+}
+
+// maxima sends numbers on a FindMaximum stream and returns every response,
+// after closing the stream.
+func maxima(ctx context.Context, c calculatorv2pb.CalculatorServiceClient, reqs ...*calculatorv2pb.FindMaximumRequest) ([]*calculatorv2pb.FindMaximumResponse, error) {
+	stream, err := c.FindMaximum(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range reqs {
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+	}
+	stream.CloseSend()
+	var responses []*calculatorv2pb.FindMaximumResponse
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return responses, nil
+		}
+		if err != nil {
+			return responses, err
+		}
+		responses = append(responses, res)
+	}
+}
+
+func TestFindMaximum(t *testing.T) {
+	c, _ := startServer(t)
+	var reqs []*calculatorv2pb.FindMaximumRequest
+	for _, n := range []float64{1, 5, 3, 6.5, 2, -10} {
+		reqs = append(reqs, &calculatorv2pb.FindMaximumRequest{Number: n})
+	}
+	responses, err := maxima(testContext(t), c, reqs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []float64
+	for _, res := range responses {
+		got = append(got, res.GetMaximum())
+	}
+	if want := []float64{1, 5, 6.5}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("FindMaximum = %v, want %v", got, want)
+	}
+
+	// Negative numbers are maxima too.
+	responses, err = maxima(testContext(t), c, &calculatorv2pb.FindMaximumRequest{Number: -3})
+	if err != nil || len(responses) != 1 || responses[0].GetMaximum() != -3 {
+		t.Errorf("FindMaximum of -3 = %v, %v", responses, err)
+	}
+
+	if responses, err := maxima(testContext(t), c); err != nil || len(responses) > 0 {
+		t.Errorf("FindMaximum of no numbers = %v, %v, want no maximum", responses, err)
+	}
+	if _, err := maxima(testContext(t), c, &calculatorv2pb.FindMaximumRequest{Number: math.Inf(1)}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("FindMaximum of infinity = %v, want InvalidArgument", err)
+	}
+}
+
+func TestFindMaximumSession(t *testing.T) {
+	c, _ := startServer(t)
+	number := func(n float64, sequence uint64) *calculatorv2pb.FindMaximumRequest {
+		return &calculatorv2pb.FindMaximumRequest{Number: n, SessionId: "max", Sequence: sequence}
+	}
+
+	// The first stream breaks after a maximum was acknowledged.
+	ctx, cancel := context.WithCancel(testContext(t))
+	stream, err := c.FindMaximum(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Send(number(4, 1))
+	stream.Send(number(2, 2))
+	stream.Send(number(7, 3))
+	for _, want := range []float64{4, 7} {
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.GetMaximum() != want || res.GetSessionId() != "max" || res.GetAckOnly() {
+			t.Errorf("FindMaximum = %v, want maximum %v", res, want)
+		}
+	}
+	cancel()
+
+	// Resuming acknowledges the numbers already taken into account, and
+	// ignores them when they are resent.
+	responses, err := maxima(testContext(t), c, number(0, 0), number(7, 3), number(9, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(responses) != 2 {
+		t.Fatalf("resumed FindMaximum = %v, want an acknowledgement and a maximum", responses)
+	}
+	if ack := responses[0]; !ack.GetAckOnly() || ack.GetAckedSequence() != 3 || ack.GetMaximum() != 7 {
+		t.Errorf("acknowledgement = %v, want sequence 3 with maximum 7", ack)
+	}
+	if res := responses[1]; res.GetMaximum() != 9 || res.GetAckedSequence() != 4 {
+		t.Errorf("maximum = %v, want 9 at sequence 4", res)
+	}
+
+	agg, err := c.GetAggregation(testContext(t), &calculatorv2pb.GetAggregationRequest{Id: "max"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agg.GetKind() != calculatorv2pb.AggregationKind_AGGREGATION_MAXIMUM || agg.GetMaximum() != 9 || agg.GetCount() != 4 || !agg.GetDone() {
+		t.Errorf("GetAggregation = %v", agg)
+	}
+
+	// The session is not an average.
+	if _, err := average(testContext(t), c, "max", 1); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("ComputeAverage of a FindMaximum session = %v, want FailedPrecondition", err)
+	}
+}
+
+func TestFindMaximumAcknowledgements(t *testing.T) {
+	c, _ := startServer(t)
+	reqs := []*calculatorv2pb.FindMaximumRequest{{Number: 100, SessionId: "acks", Sequence: 1}}
+	for i := 0; i < ackInterval; i++ {
+		reqs = append(reqs, &calculatorv2pb.FindMaximumRequest{Number: 1, SessionId: "acks", Sequence: uint64(i + 2)})
+	}
+	responses, err := maxima(testContext(t), c, reqs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(responses) != 2 || !responses[1].GetAckOnly() || responses[1].GetAckedSequence() != ackInterval+1 {
+		t.Errorf("FindMaximum = %v, want the maximum and an acknowledgement of sequence %d", responses, ackInterval+1)
+	}
+}
+
+func TestGetAggregationErrors(t *testing.T) {
+	c, _ := startServer(t)
+	if _, err := c.GetAggregation(testContext(t), &calculatorv2pb.GetAggregationRequest{Id: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetAggregation of a missing aggregation = %v, want NotFound", err)
+	}
+	if _, err := c.GetAggregation(testContext(t), &calculatorv2pb.GetAggregationRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetAggregation without an ID = %v, want InvalidArgument", err)
+	}
+}
+
+// nameVerifier authenticates the callers whose token is their name.
+type nameVerifier struct{}
+
+func (nameVerifier) Verify(token string) (*auth.Principal, error) {
+	return &auth.Principal{Name: token, Source: "test"}, nil
+}
+
+func TestAggregationOwner(t *testing.T) {
+	a := auth.NewAuthenticator(nameVerifier{})
+	conn := grpctest.Serve(t, New(Options{}).Register, grpctest.Options{ServerOptions: []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(a.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(a.StreamServerInterceptor()),
+	}})
+	c := calculatorv2pb.NewCalculatorServiceClient(conn)
+	as := func(name string) context.Context {
+		return metadata.AppendToOutgoingContext(testContext(t), "authorization", "Bearer "+name)
+	}
+
+	if _, err := average(as("alice"), c, "avg", 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetAggregation(as("alice"), &calculatorv2pb.GetAggregationRequest{Id: "avg"}); err != nil {
+		t.Errorf("GetAggregation by its creator = %v", err)
+	}
+	if _, err := c.GetAggregation(as("bob"), &calculatorv2pb.GetAggregationRequest{Id: "avg"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("GetAggregation by another caller = %v, want PermissionDenied", err)
+	}
+
+	// A running aggregation cannot be taken over either.
+	stream, err := c.ComputeAverage(as("alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&calculatorv2pb.ComputeAverageRequest{Number: 1, AggregationId: "running", Sequence: 1}); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err := c.GetAggregation(as("alice"), &calculatorv2pb.GetAggregationRequest{Id: "running"}); err == nil {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := average(as("bob"), c, "running", 5); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ComputeAverage of another caller's aggregation = %v, want PermissionDenied", err)
+	}
+	if _, err := maxima(as("bob"), c, &calculatorv2pb.FindMaximumRequest{Number: 1, SessionId: "avg", Sequence: 1}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("FindMaximum of another caller's aggregation = %v, want PermissionDenied", err)
+	}
+	if res, err := stream.CloseAndRecv(); err != nil || res.GetCount() != 1 {
+		t.Errorf("ComputeAverage by its creator = %v, %v", res, err)
+	}
+}
+
+func TestV1(t *testing.T) {
+	c2, c := startServer(t)
+	ctx := testContext(t)
+
+	calc, err := c.Calculate(ctx, &calculatorpb.OperationRequest{
+		OperationArgs: &calculatorpb.OperationArgs{Operation: calculatorpb.Operation_OPCODE_DIV, Value1: 1, Value2: 4},
+	})
+	if err != nil || calc.GetResult() != 0.25 {
+		t.Errorf("Calculate = %v, %v, want 0.25", calc, err)
+	}
+
+	primes, err := c.PrimeNumberDecomposition(ctx, &calculatorpb.PrimeNumberDecompositionRequest{Number: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var factors []uint32
+	for {
+		res, err := primes.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		factors = append(factors, res.GetPrime())
+	}
+	if fmt.Sprint(factors) != "[2 2 3]" {
+		t.Errorf("PrimeNumberDecomposition = %v, want [2 2 3]", factors)
+	}
+
+	avg, err := c.ComputeAverage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, n := range []int32{1, 2} {
+		avg.Send(&calculatorpb.ComputeAverageRequest{Number: n, AggregationId: "v1", Sequence: uint64(i + 1)})
+	}
+	if res, err := avg.CloseAndRecv(); err != nil || res.GetAverage() != 1.5 || res.GetCount() != 2 || res.GetAggregationId() != "v1" {
+		t.Errorf("ComputeAverage = %v, %v, want 1.5 of 2 numbers", res, err)
+	}
+	empty, err := c.ComputeAverage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := empty.CloseAndRecv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ComputeAverage of no numbers = %v, want InvalidArgument", err)
+	}
+
+	// Aggregations are shared with v2.
+	if agg, err := c2.GetAggregation(ctx, &calculatorv2pb.GetAggregationRequest{Id: "v1"}); err != nil || agg.GetAverage() != 1.5 {
+		t.Errorf("v2 GetAggregation = %v, %v", agg, err)
+	}
+
+	maxStream, err := c.FindMaximum(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, n := range []int32{3, 1, 8} {
+		maxStream.Send(&calculatorpb.FindMaximumRequest{Number: n, SessionId: "v1max", Sequence: uint64(i + 1)})
+	}
+	maxStream.CloseSend()
+	var got []int32
+	for {
+		res, err := maxStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, res.GetMaximum())
+	}
+	if fmt.Sprint(got) != "[3 8]" {
+		t.Errorf("FindMaximum = %v, want [3 8]", got)
+	}
+	if agg, err := c.GetAggregation(ctx, &calculatorpb.GetAggregationRequest{Id: "v1max"}); err != nil || agg.GetMaximum() != 8 || !agg.GetDone() {
+		t.Errorf("GetAggregation = %v, %v", agg, err)
+	}
+}
@@ -0,0 +1,112 @@
+package calcserver
+
+import (
+	"io"
+	"log"
+
+	"github.com/AlanKev117/go-grpc/auth"
+	"github.com/AlanKev117/go-grpc/calculator/calc"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"github.com/AlanKev117/go-grpc/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ackInterval is how many numbers a session can process without finding a
+// new maximum before the server acknowledges them, so that clients can stop
+// keeping them around for resending.
+const ackInterval = 16
+
+// findMaximumSession serves a FindMaximum stream whose first message req
+// opened or resumed a session. The running maximum outlives the stream, so
+// a client that lost its connection can reconnect with the same session ID
+// and resend the numbers the server did not acknowledge.
+func (s *Server) findMaximumSession(stream calculatorv2pb.CalculatorService_FindMaximumServer, req *calculatorv2pb.FindMaximumRequest) error {
+	sess, resumed, err := s.attachAggregation(req.GetSessionId(), auth.Owner(stream.Context()), calculatorv2pb.AggregationKind_AGGREGATION_MAXIMUM)
+	if err != nil {
+		return err
+	}
+
+	if resumed {
+		log.Printf("Resuming FindMaximum session %s", sess.ID())
+		var res *calculatorv2pb.FindMaximumResponse
+		sess.Do(func(st *session.State) {
+			res = &calculatorv2pb.FindMaximumResponse{
+				Maximum:       st.Value.(*aggregationState).agg.GetMaximum(),
+				SessionId:     sess.ID(),
+				AckedSequence: st.LastSequence,
+				AckOnly:       true,
+			}
+		})
+		if err := stream.Send(res); err != nil {
+			s.detachAggregation(sess)
+			return err
+		}
+	}
+
+	for {
+		var res *calculatorv2pb.FindMaximumResponse
+		var saveErr error
+		err := sess.Do(func(st *session.State) {
+			// Sequence 0 only attaches the stream, and numbers sent again
+			// after a reconnection were already taken into account.
+			if req.GetSequence() == 0 || req.GetSequence() <= st.LastSequence {
+				return
+			}
+			m := st.Value.(*aggregationState)
+			agg := m.agg
+			st.LastSequence = req.GetSequence()
+			agg.LastSequence = st.LastSequence
+			m.unacked++
+
+			maximum := calc.Maximum{Count: agg.GetCount(), Value: agg.GetMaximum()}
+			isMax := maximum.Add(req.GetNumber())
+			agg.Count, agg.Maximum = maximum.Count, maximum.Value
+			if isMax {
+				res = &calculatorv2pb.FindMaximumResponse{Maximum: maximum.Value}
+			} else if m.unacked >= ackInterval {
+				res = &calculatorv2pb.FindMaximumResponse{Maximum: agg.Maximum, AckOnly: true}
+			}
+			if res != nil {
+				res.SessionId = sess.ID()
+				res.AckedSequence = st.LastSequence
+				m.unacked = 0
+			}
+			saveErr = s.aggregations.checkpoint(agg)
+		})
+		if err != nil {
+			return status.Error(codes.Aborted, err.Error())
+		}
+		if saveErr != nil {
+			log.Printf("Failed to checkpoint session %s: %v", sess.ID(), saveErr)
+		}
+
+		if res != nil {
+			if err := stream.Send(res); err != nil {
+				log.Printf("Error while sending maximum to client: %v", err)
+				s.detachAggregation(sess)
+				return err
+			}
+		}
+
+		req, err = stream.Recv()
+		if err == io.EOF {
+			log.Printf("Max values found for session %s.", sess.ID())
+			sess.Do(func(st *session.State) {
+				agg := st.Value.(*aggregationState).agg
+				agg.Done = true
+				saveErr = s.aggregations.save(agg)
+			})
+			if saveErr != nil {
+				log.Printf("Failed to checkpoint session %s: %v", sess.ID(), saveErr)
+			}
+			sess.Close()
+			return nil
+		}
+		if err != nil {
+			log.Printf("Session %s interrupted: %v", sess.ID(), err)
+			s.detachAggregation(sess)
+			return err
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package calcserver
+
+import (
+	"context"
+
+	"github.com/AlanKev117/go-grpc/calculator/calculatorpb"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"google.golang.org/grpc"
+)
+
+// v1Server serves calculator.v1 by converting its calls to calculator.v2,
+// which server implements, and the results back. v1 numbers always fit in
+// their v2 counterparts; v2 results are narrowed to the v1 types, so a
+// maximum found by a v2 client and read through v1 loses its fraction.
+type v1Server struct {
+	calculatorpb.UnimplementedCalculatorServiceServer
+
+	v2 *Server
+}
+
+func (s *v1Server) Calculate(ctx context.Context, req *calculatorpb.OperationRequest) (*calculatorpb.OperationResponse, error) {
+	args := req.GetOperationArgs()
+	res, err := s.v2.Calculate(ctx, &calculatorv2pb.OperationRequest{
+		OperationArgs: &calculatorv2pb.OperationArgs{
+			Operation: calculatorv2pb.Operation(args.GetOperation()),
+			Value1:    float64(args.GetValue1()),
+			Value2:    float64(args.GetValue2()),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &calculatorpb.OperationResponse{Result: float32(res.GetResult())}, nil
+}
+
+func (s *v1Server) PrimeNumberDecomposition(req *calculatorpb.PrimeNumberDecompositionRequest, stream calculatorpb.CalculatorService_PrimeNumberDecompositionServer) error {
+	return s.v2.PrimeNumberDecomposition(
+		&calculatorv2pb.PrimeNumberDecompositionRequest{Number: uint64(req.GetNumber())},
+		&v1Stream[calculatorpb.PrimeNumberDecompositionRequest, calculatorv2pb.PrimeNumberDecompositionRequest, calculatorpb.PrimeNumberDecompositionResponse, calculatorv2pb.PrimeNumberDecompositionResponse]{
+			ServerStream: stream,
+			toV1: func(res *calculatorv2pb.PrimeNumberDecompositionResponse) *calculatorpb.PrimeNumberDecompositionResponse {
+				// The factors of a uint32 are uint32s.
+				return &calculatorpb.PrimeNumberDecompositionResponse{Prime: uint32(res.GetPrime())}
+			},
+		},
+	)
+}
+
+func (s *v1Server) ComputeAverage(stream calculatorpb.CalculatorService_ComputeAverageServer) error {
+	return s.v2.ComputeAverage(&v1Stream[calculatorpb.ComputeAverageRequest, calculatorv2pb.ComputeAverageRequest, calculatorpb.ComputeAverageResponse, calculatorv2pb.ComputeAverageResponse]{
+		ServerStream: stream,
+		toV2: func(req *calculatorpb.ComputeAverageRequest) *calculatorv2pb.ComputeAverageRequest {
+			return &calculatorv2pb.ComputeAverageRequest{
+				Number:        float64(req.GetNumber()),
+				AggregationId: req.GetAggregationId(),
+				Sequence:      req.GetSequence(),
+			}
+		},
+		toV1: func(res *calculatorv2pb.ComputeAverageResponse) *calculatorpb.ComputeAverageResponse {
+			return &calculatorpb.ComputeAverageResponse{
+				Average:       res.GetAverage(),
+				AggregationId: res.GetAggregationId(),
+				Count:         res.GetCount(),
+			}
+		},
+	})
+}
+
+func (s *v1Server) FindMaximum(stream calculatorpb.CalculatorService_FindMaximumServer) error {
+	return s.v2.FindMaximum(&v1Stream[calculatorpb.FindMaximumRequest, calculatorv2pb.FindMaximumRequest, calculatorpb.FindMaximumResponse, calculatorv2pb.FindMaximumResponse]{
+		ServerStream: stream,
+		toV2: func(req *calculatorpb.FindMaximumRequest) *calculatorv2pb.FindMaximumRequest {
+			return &calculatorv2pb.FindMaximumRequest{
+				Number:    float64(req.GetNumber()),
+				SessionId: req.GetSessionId(),
+				Sequence:  req.GetSequence(),
+			}
+		},
+		toV1: func(res *calculatorv2pb.FindMaximumResponse) *calculatorpb.FindMaximumResponse {
+			return &calculatorpb.FindMaximumResponse{
+				Maximum:       int32(res.GetMaximum()),
+				SessionId:     res.GetSessionId(),
+				AckedSequence: res.GetAckedSequence(),
+				AckOnly:       res.GetAckOnly(),
+			}
+		},
+	})
+}
+
+func (s *v1Server) GetAggregation(ctx context.Context, req *calculatorpb.GetAggregationRequest) (*calculatorpb.Aggregation, error) {
+	agg, err := s.v2.GetAggregation(ctx, &calculatorv2pb.GetAggregationRequest{Id: req.GetId()})
+	if err != nil {
+		return nil, err
+	}
+	return &calculatorpb.Aggregation{
+		Id:           agg.GetId(),
+		Kind:         calculatorpb.AggregationKind(agg.GetKind()),
+		Count:        agg.GetCount(),
+		LastSequence: agg.GetLastSequence(),
+		Average:      agg.GetAverage(),
+		Maximum:      int32(agg.GetMaximum()),
+		Done:         agg.GetDone(),
+		UpdateTime:   agg.GetUpdateTime(),
+	}, nil
+}
+
+// v1Stream passes the stream of a v1 call to the v2 implementation,
+// converting the requests it receives from Req1 to Req2 and the responses it
+// sends from Res2 to Res1.
+type v1Stream[Req1, Req2, Res1, Res2 any] struct {
+	grpc.ServerStream
+
+	toV2 func(*Req1) *Req2
+	toV1 func(*Res2) *Res1
+}
+
+func (s *v1Stream[Req1, Req2, Res1, Res2]) Recv() (*Req2, error) {
+	req := new(Req1)
+	if err := s.ServerStream.RecvMsg(req); err != nil {
+		return nil, err
+	}
+	return s.toV2(req), nil
+}
+
+func (s *v1Stream[Req1, Req2, Res1, Res2]) Send(res *Res2) error {
+	return s.ServerStream.SendMsg(s.toV1(res))
+}
+
+func (s *v1Stream[Req1, Req2, Res1, Res2]) SendAndClose(res *Res2) error {
+	return s.Send(res)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"github.com/AlanKev117/go-grpc/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// doCalculateAverage averages numbers within an aggregation checkpointed by
+// the server. When the connection drops, it asks the server how many numbers
+// were aggregated and resends the rest.
+func doCalculateAverage(c calculatorv2pb.CalculatorServiceClient, numbers []float64, reconnects int) {
+
+	id := session.NewID()
+	log.Printf("Calculating average for %v numbers in aggregation %s", len(numbers), id)
+
+	var average float64
+	err := session.Reconnect(reconnects, time.Second, func(resume bool) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		next := uint64(0)
+		if resume {
+			agg, err := c.GetAggregation(ctx, &calculatorv2pb.GetAggregationRequest{Id: id})
+			switch status.Code(err) {
+			case codes.OK:
+				if agg.GetDone() {
+					average = agg.GetAverage()
+					return nil
+				}
+				next = agg.GetLastSequence()
+			case codes.NotFound:
+				// Nothing was checkpointed, start over.
+			default:
+				return err
+			}
+			log.Printf("Resuming aggregation %s after %v numbers", id, next)
+		}
+
+		stream, err := c.ComputeAverage(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Attaching the stream to the aggregation, then sending values to
+		// calculate average. When a Send fails, CloseAndRecv reports why.
+		err = stream.Send(&calculatorv2pb.ComputeAverageRequest{AggregationId: id})
+		for seq := next + 1; err == nil && seq <= uint64(len(numbers)); seq++ {
+			log.Printf("sending %v to server", numbers[seq-1])
+			err = stream.Send(&calculatorv2pb.ComputeAverageRequest{
+				Number:        numbers[seq-1],
+				AggregationId: id,
+				Sequence:      seq,
+			})
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		// Receiving average
+		res, err := stream.CloseAndRecv()
+		if err != nil {
+			return err
+		}
+		average = res.GetAverage()
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("error while receiving average: %v", err)
+	}
+
+	fmt.Printf("Average for %v is: %v\n", numbers, average)
+}
+
+func doGetAggregation(c calculatorv2pb.CalculatorServiceClient, id string) {
+	agg, err := c.GetAggregation(context.Background(), &calculatorv2pb.GetAggregationRequest{Id: id})
+	if err != nil {
+		log.Fatalf("Error while getting aggregation %s: %v", id, err)
+	}
+	fmt.Printf("Aggregation %s (%v, done: %v, updated %v):\n", agg.GetId(), agg.GetKind(), agg.GetDone(), agg.GetUpdateTime().AsTime())
+	fmt.Printf("  %v numbers, last sequence %v\n", agg.GetCount(), agg.GetLastSequence())
+	switch agg.GetKind() {
+	case calculatorv2pb.AggregationKind_AGGREGATION_AVERAGE:
+		fmt.Printf("  average: %v\n", agg.GetAverage())
+	case calculatorv2pb.AggregationKind_AGGREGATION_MAXIMUM:
+		fmt.Printf("  maximum: %v\n", agg.GetMaximum())
+	}
+}
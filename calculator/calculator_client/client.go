@@ -2,19 +2,22 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
-	"time"
 
-	"github.com/AlanKev117/go-grpc/calculator/calculatorpb"
+	"github.com/AlanKev117/go-grpc/auth"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"github.com/AlanKev117/go-grpc/config"
+	"github.com/AlanKev117/go-grpc/validate"
 	"google.golang.org/grpc"
 )
 
-func doOperation(c calculatorpb.CalculatorServiceClient, opcode calculatorpb.Operation, value1 float32, value2 float32) {
+func doOperation(c calculatorv2pb.CalculatorServiceClient, opcode calculatorv2pb.Operation, value1 float64, value2 float64) {
 	log.Printf("Executing Operation %v\n", opcode)
-	req := &calculatorpb.OperationRequest{
-		OperationArgs: &calculatorpb.OperationArgs{
+	req := &calculatorv2pb.OperationRequest{
+		OperationArgs: &calculatorv2pb.OperationArgs{
 			Operation: opcode,
 			Value1:    value1,
 			Value2:    12.0,
@@ -30,9 +33,9 @@ func doOperation(c calculatorpb.CalculatorServiceClient, opcode calculatorpb.Ope
 	fmt.Printf("Response from server Calculate: %v", res.Result)
 }
 
-func doGetPrimeFactors(c calculatorpb.CalculatorServiceClient, number uint32) {
+func doGetPrimeFactors(c calculatorv2pb.CalculatorServiceClient, number uint64) {
 	log.Printf("Calculating prime factors for %v", number)
-	req := &calculatorpb.PrimeNumberDecompositionRequest{
+	req := &calculatorv2pb.PrimeNumberDecompositionRequest{
 		Number: number,
 	}
 
@@ -42,7 +45,7 @@ func doGetPrimeFactors(c calculatorpb.CalculatorServiceClient, number uint32) {
 		log.Fatalf("error while calling PrimerNumberDecomposition: %v", err)
 	}
 
-	primes := []uint32{}
+	primes := []uint64{}
 
 	// Receiving primes from server stream
 	for {
@@ -62,91 +65,44 @@ func doGetPrimeFactors(c calculatorpb.CalculatorServiceClient, number uint32) {
 	fmt.Printf("%v\n", primes)
 }
 
-func doCalculateAverage(c calculatorpb.CalculatorServiceClient, numbers []int32) {
-
-	log.Printf("Calculating average for %v numbers", len(numbers))
-
-	stream, err := c.ComputeAverage(context.Background())
-
-	if err != nil {
-		log.Fatalf("Error while getting stream for client: %v", err)
-	}
-
-	// Sending values to calculate average
-	for _, number := range numbers {
-		log.Printf("sending %v to server", number)
-		stream.Send(&calculatorpb.ComputeAverageRequest{
-			Number: number,
-		})
-		time.Sleep(100 * time.Millisecond)
+func main() {
+	clientConfig := config.DefaultClient()
+	clientConfig.RegisterFlags(flag.CommandLine)
+	var authFlags auth.ClientFlags
+	authFlags.RegisterFlags(flag.CommandLine)
+	reconnects := flag.Int("reconnects", 5, "how many times ComputeAverage and FindMaximum reconnect after their connection drops")
+	aggregation := flag.String("aggregation", "", "print the last checkpoint of this aggregation and exit")
+	validateRequests := flag.Bool("validate", true, "check requests against the proto validation rules before sending them")
+	flag.Parse()
+
+	if err := clientConfig.Validate(); err != nil {
+		log.Fatalf("Invalid client settings: %v", err)
 	}
 
-	// Receiving average
-	res, err := stream.CloseAndRecv()
+	authOpts, err := authFlags.DialOptions()
 	if err != nil {
-		log.Fatalf("error while receiving average: %v", err)
+		log.Fatalf("Couldn't read token: %v", err)
 	}
 
-	average := res.GetAverage()
-
-	fmt.Printf("Average for %v is: %v\n", numbers, average)
-}
-
-func doGetMaximumValues(c calculatorpb.CalculatorServiceClient, numbers []int32) {
-	log.Printf("Calculating max values for %v numbers\n", len(numbers))
-
-	stream, err := c.FindMaximum(context.Background())
-
-	if err != nil {
-		log.Fatalf("Error while getting stream for client: %v", err)
+	opts := append([]grpc.DialOption{grpc.WithInsecure()}, authOpts...)
+	if *validateRequests {
+		opts = append(opts, validate.DialOptions()...)
 	}
-
-	waitc := make(chan struct{})
-
-	// Sending each value
-	go func() {
-		for _, number := range numbers {
-			fmt.Printf("Sending %v to server.\n", number)
-			stream.Send(&calculatorpb.FindMaximumRequest{
-				Number: number,
-			})
-			time.Sleep(200 * time.Millisecond)
-		}
-		stream.CloseSend()
-	}()
-
-	// Receiving and handling new max value
-	go func() {
-		defer close(waitc)
-		for {
-			res, err := stream.Recv()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				log.Fatalf("Error while receiving server response: %v", err)
-			}
-			nextMaximum := res.GetMaximum()
-			fmt.Printf("Received new max value: %v\n", nextMaximum)
-		}
-	}()
-
-	// Finish function after async streaming
-	<-waitc
-}
-
-func main() {
-	conn, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
-
+	conn, err := clientConfig.Dial(opts...)
 	if err != nil {
 		log.Fatalf("Couldn't connect: %v", err)
 	}
 	defer conn.Close()
 
-	c := calculatorpb.NewCalculatorServiceClient(conn)
+	c := calculatorv2pb.NewCalculatorServiceClient(conn)
+
+	if *aggregation != "" {
+		doGetAggregation(c, *aggregation)
+		return
+	}
 
-	doOperation(c, calculatorpb.Operation_OPCODE_SUM, 23, 54)
+	doOperation(c, calculatorv2pb.Operation_OPCODE_SUM, 23, 54)
 	doGetPrimeFactors(c, 1)
-	doCalculateAverage(c, []int32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
-	doGetMaximumValues(c, []int32{1, 5, 3, 6, 2, 20})
+	doCalculateAverage(c, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, *reconnects)
+	doGetMaximumValues(c, []float64{1, 5, 3, 6, 2, 20}, *reconnects)
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"github.com/AlanKev117/go-grpc/session"
+)
+
+// maximumSession streams numbers to FindMaximum within a session, so that
+// the stream can be resumed when the connection drops.
+type maximumSession struct {
+	c       calculatorv2pb.CalculatorServiceClient
+	id      string
+	numbers []float64
+
+	mu sync.Mutex
+	// acked is the sequence of the last number the server acknowledged;
+	// number i is sent with sequence i+1.
+	acked uint64
+}
+
+func doGetMaximumValues(c calculatorv2pb.CalculatorServiceClient, numbers []float64, reconnects int) {
+	s := &maximumSession{c: c, id: session.NewID(), numbers: numbers}
+	log.Printf("Calculating max values for %v numbers in session %s\n", len(numbers), s.id)
+
+	if err := session.Reconnect(reconnects, time.Second, s.stream); err != nil {
+		log.Fatalf("Error while finding max values: %v", err)
+	}
+}
+
+// stream runs one FindMaximum stream, sending the numbers the server did not
+// acknowledge yet. Numbers sent again are ignored by the server.
+func (s *maximumSession) stream(resume bool) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := s.c.FindMaximum(ctx)
+	if err != nil {
+		return err
+	}
+	// Attach the stream to the session before sending any number.
+	if err := stream.Send(&calculatorv2pb.FindMaximumRequest{SessionId: s.id}); err != nil {
+		_, err := stream.Recv()
+		return err
+	}
+
+	// Receiving and handling new max value
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				errc <- nil
+				return
+			}
+			if err != nil {
+				errc <- err
+				return
+			}
+			s.ack(res.GetAckedSequence())
+			switch {
+			case !res.GetAckOnly():
+				fmt.Printf("Received new max value: %v\n", res.GetMaximum())
+			case resume:
+				fmt.Printf("Resumed session, max value so far: %v\n", res.GetMaximum())
+				resume = false
+			}
+		}
+	}()
+
+	// Sending each value
+	s.mu.Lock()
+	next := s.acked
+	s.mu.Unlock()
+	for seq := next + 1; seq <= uint64(len(s.numbers)); seq++ {
+		number := s.numbers[seq-1]
+		fmt.Printf("Sending %v to server.\n", number)
+		err := stream.Send(&calculatorv2pb.FindMaximumRequest{
+			Number:    number,
+			SessionId: s.id,
+			Sequence:  seq,
+		})
+		if err != nil {
+			// The stream broke: the receiving side reports why.
+			return <-errc
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	stream.CloseSend()
+
+	return <-errc
+}
+
+func (s *maximumSession) ack(seq uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if seq > s.acked {
+		s.acked = seq
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/auth"
+	"github.com/AlanKev117/go-grpc/calculator/calcserver"
+	"github.com/AlanKev117/go-grpc/config"
+	"github.com/AlanKev117/go-grpc/ratelimit"
+	"github.com/AlanKev117/go-grpc/store"
+	"github.com/AlanKev117/go-grpc/validate"
+	"github.com/AlanKev117/go-grpc/web"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func main() {
+	serverConfig := config.DefaultServer()
+	serverConfig.RegisterFlags(flag.CommandLine)
+	var authFlags auth.ServerFlags
+	authFlags.RegisterFlags(flag.CommandLine)
+	var limitFlags ratelimit.Flags
+	limitFlags.RegisterFlags(flag.CommandLine)
+	sessionTTL := flag.Duration("session-ttl", 5*time.Minute, "how long a broken FindMaximum session is kept in memory")
+	checkpointInterval := flag.Duration("checkpoint-interval", time.Second, "least time between two checkpoints of a running aggregation")
+	aggregationTTL := flag.Duration("aggregation-ttl", time.Hour, "how long a finished aggregation can still be read before it is deleted")
+	var storeFlags store.Flags
+	storeFlags.RegisterFlags(flag.CommandLine)
+	var webFlags web.Flags
+	webFlags.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := serverConfig.Validate(); err != nil {
+		log.Fatalf("Invalid server settings: %v", err)
+	}
+	opts := serverConfig.ServerOptions()
+
+	accessOpts, err := authFlags.ServerOptions()
+	if err != nil {
+		log.Fatalf("Failed to load access control settings: %v", err)
+	}
+	opts = append(opts, accessOpts...)
+	opts = append(opts, limitFlags.ServerOptions()...)
+	opts = append(opts, validate.ServerOptions()...)
+
+	state, err := storeFlags.Open()
+	if err != nil {
+		log.Fatalf("Failed to open state store: %v", err)
+	}
+	defer state.Close()
+
+	lis, err := net.Listen("tcp", serverConfig.Address)
+
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+
+	s := grpc.NewServer(opts...)
+	calcserver.New(calcserver.Options{
+		Store:              state,
+		SessionTTL:         *sessionTTL,
+		CheckpointInterval: *checkpointInterval,
+		AggregationTTL:     *aggregationTTL,
+	}).Register(s)
+
+	// Every name the service is registered under reports its health.
+	healthServer := health.NewServer()
+	for name := range s.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(s, healthServer)
+
+	webServer, err := webFlags.Serve(s, web.CalculatorService, web.CalculatorV2Service)
+	if err != nil {
+		log.Fatalf("Failed to serve gRPC-Web and Connect: %v", err)
+	}
+
+	// On shutdown, report NOT_SERVING first so that balancing clients stop
+	// sending new calls before the server goes away.
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+		log.Println("Shutting down...")
+		healthServer.Shutdown()
+		time.Sleep(serverConfig.DrainDelay)
+		if webServer != nil {
+			webServer.Shutdown(context.Background())
+		}
+		s.GracefulStop()
+	}()
+
+	if err := s.Serve(lis); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
@@ -1,20 +1,20 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.26.0
-// 	protoc        v3.15.8
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
 // source: calculator/calculatorpb/calculator.proto
 
 package calculatorpb
 
 import (
-	context "context"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -76,23 +76,74 @@ func (Operation) EnumDescriptor() ([]byte, []int) {
 	return file_calculator_calculatorpb_calculator_proto_rawDescGZIP(), []int{0}
 }
 
+type AggregationKind int32
+
+const (
+	AggregationKind_AGGREGATION_UNSPECIFIED AggregationKind = 0
+	AggregationKind_AGGREGATION_AVERAGE     AggregationKind = 1
+	AggregationKind_AGGREGATION_MAXIMUM     AggregationKind = 2
+)
+
+// Enum value maps for AggregationKind.
+var (
+	AggregationKind_name = map[int32]string{
+		0: "AGGREGATION_UNSPECIFIED",
+		1: "AGGREGATION_AVERAGE",
+		2: "AGGREGATION_MAXIMUM",
+	}
+	AggregationKind_value = map[string]int32{
+		"AGGREGATION_UNSPECIFIED": 0,
+		"AGGREGATION_AVERAGE":     1,
+		"AGGREGATION_MAXIMUM":     2,
+	}
+)
+
+func (x AggregationKind) Enum() *AggregationKind {
+	p := new(AggregationKind)
+	*p = x
+	return p
+}
+
+func (x AggregationKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AggregationKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_calculator_calculatorpb_calculator_proto_enumTypes[1].Descriptor()
+}
+
+func (AggregationKind) Type() protoreflect.EnumType {
+	return &file_calculator_calculatorpb_calculator_proto_enumTypes[1]
+}
+
+func (x AggregationKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AggregationKind.Descriptor instead.
+func (AggregationKind) EnumDescriptor() ([]byte, []int) {
+	return file_calculator_calculatorpb_calculator_proto_rawDescGZIP(), []int{1}
+}
+
 type OperationArgs struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Operation Operation              `protobuf:"varint,1,opt,name=operation,proto3,enum=calculator.v1.Operation" json:"operation,omitempty"`
+	// Single precision loses digits beyond the seventh; calculator.v2 takes
+	// doubles.
+	//
+	// Deprecated: Marked as deprecated in calculator/calculatorpb/calculator.proto.
+	Value1 float32 `protobuf:"fixed32,2,opt,name=value1,proto3" json:"value1,omitempty"`
+	// Deprecated: Marked as deprecated in calculator/calculatorpb/calculator.proto.
+	Value2        float32 `protobuf:"fixed32,3,opt,name=value2,proto3" json:"value2,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Operation Operation `protobuf:"varint,1,opt,name=operation,proto3,enum=calculator.Operation" json:"operation,omitempty"`
-	Value1    float32   `protobuf:"fixed32,2,opt,name=value1,proto3" json:"value1,omitempty"`
-	Value2    float32   `protobuf:"fixed32,3,opt,name=value2,proto3" json:"value2,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OperationArgs) Reset() {
 	*x = OperationArgs{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *OperationArgs) String() string {
@@ -103,7 +154,7 @@ func (*OperationArgs) ProtoMessage() {}
 
 func (x *OperationArgs) ProtoReflect() protoreflect.Message {
 	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -125,6 +176,7 @@ func (x *OperationArgs) GetOperation() Operation {
 	return Operation_OPCODE_SUM
 }
 
+// Deprecated: Marked as deprecated in calculator/calculatorpb/calculator.proto.
 func (x *OperationArgs) GetValue1() float32 {
 	if x != nil {
 		return x.Value1
@@ -132,6 +184,7 @@ func (x *OperationArgs) GetValue1() float32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in calculator/calculatorpb/calculator.proto.
 func (x *OperationArgs) GetValue2() float32 {
 	if x != nil {
 		return x.Value2
@@ -140,20 +193,17 @@ func (x *OperationArgs) GetValue2() float32 {
 }
 
 type OperationRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OperationArgs *OperationArgs         `protobuf:"bytes,1,opt,name=operation_args,json=operationArgs,proto3" json:"operation_args,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	OperationArgs *OperationArgs `protobuf:"bytes,1,opt,name=operation_args,json=operationArgs,proto3" json:"operation_args,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OperationRequest) Reset() {
 	*x = OperationRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *OperationRequest) String() string {
@@ -164,7 +214,7 @@ func (*OperationRequest) ProtoMessage() {}
 
 func (x *OperationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -187,20 +237,18 @@ func (x *OperationRequest) GetOperationArgs() *OperationArgs {
 }
 
 type OperationResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in calculator/calculatorpb/calculator.proto.
+	Result        float32 `protobuf:"fixed32,1,opt,name=result,proto3" json:"result,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Result float32 `protobuf:"fixed32,1,opt,name=result,proto3" json:"result,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OperationResponse) Reset() {
 	*x = OperationResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *OperationResponse) String() string {
@@ -211,7 +259,7 @@ func (*OperationResponse) ProtoMessage() {}
 
 func (x *OperationResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -226,6 +274,7 @@ func (*OperationResponse) Descriptor() ([]byte, []int) {
 	return file_calculator_calculatorpb_calculator_proto_rawDescGZIP(), []int{2}
 }
 
+// Deprecated: Marked as deprecated in calculator/calculatorpb/calculator.proto.
 func (x *OperationResponse) GetResult() float32 {
 	if x != nil {
 		return x.Result
@@ -234,20 +283,17 @@ func (x *OperationResponse) GetResult() float32 {
 }
 
 type PrimeNumberDecompositionRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        uint32                 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Number uint32 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PrimeNumberDecompositionRequest) Reset() {
 	*x = PrimeNumberDecompositionRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *PrimeNumberDecompositionRequest) String() string {
@@ -258,7 +304,7 @@ func (*PrimeNumberDecompositionRequest) ProtoMessage() {}
 
 func (x *PrimeNumberDecompositionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -281,20 +327,17 @@ func (x *PrimeNumberDecompositionRequest) GetNumber() uint32 {
 }
 
 type PrimeNumberDecompositionResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prime         uint32                 `protobuf:"varint,1,opt,name=prime,proto3" json:"prime,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Prime uint32 `protobuf:"varint,1,opt,name=prime,proto3" json:"prime,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PrimeNumberDecompositionResponse) Reset() {
 	*x = PrimeNumberDecompositionResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *PrimeNumberDecompositionResponse) String() string {
@@ -305,7 +348,7 @@ func (*PrimeNumberDecompositionResponse) ProtoMessage() {}
 
 func (x *PrimeNumberDecompositionResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -328,20 +371,25 @@ func (x *PrimeNumberDecompositionResponse) GetPrime() uint32 {
 }
 
 type ComputeAverageRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Number int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// Identifies an aggregation checkpointed by the server, so that it can
+	// be queried with GetAggregation and resumed by opening a new stream
+	// with the same ID. Clients set it on the first message.
+	AggregationId string `protobuf:"bytes,2,opt,name=aggregation_id,json=aggregationId,proto3" json:"aggregation_id,omitempty"`
+	// Position of the number in the aggregation, starting at 1. A message
+	// with sequence 0 carries no number and only attaches the stream to the
+	// aggregation. Numbers already aggregated are ignored when resent.
+	Sequence      uint64 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Number int32 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ComputeAverageRequest) Reset() {
 	*x = ComputeAverageRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ComputeAverageRequest) String() string {
@@ -352,7 +400,7 @@ func (*ComputeAverageRequest) ProtoMessage() {}
 
 func (x *ComputeAverageRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -374,21 +422,36 @@ func (x *ComputeAverageRequest) GetNumber() int32 {
 	return 0
 }
 
+func (x *ComputeAverageRequest) GetAggregationId() string {
+	if x != nil {
+		return x.AggregationId
+	}
+	return ""
+}
+
+func (x *ComputeAverageRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
 type ComputeAverageResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Average float64                `protobuf:"fixed64,1,opt,name=average,proto3" json:"average,omitempty"`
+	// Echoes the aggregation of the request.
+	AggregationId string `protobuf:"bytes,2,opt,name=aggregation_id,json=aggregationId,proto3" json:"aggregation_id,omitempty"`
+	// How many numbers were averaged.
+	Count         uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Average float64 `protobuf:"fixed64,1,opt,name=average,proto3" json:"average,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ComputeAverageResponse) Reset() {
 	*x = ComputeAverageResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ComputeAverageResponse) String() string {
@@ -399,7 +462,7 @@ func (*ComputeAverageResponse) ProtoMessage() {}
 
 func (x *ComputeAverageResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -421,21 +484,39 @@ func (x *ComputeAverageResponse) GetAverage() float64 {
 	return 0
 }
 
+func (x *ComputeAverageResponse) GetAggregationId() string {
+	if x != nil {
+		return x.AggregationId
+	}
+	return ""
+}
+
+func (x *ComputeAverageResponse) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
 type FindMaximumRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Number int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// Identifies a resumable session. Clients set it on the first message
+	// of a stream, and again when reconnecting to resume the session.
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Position of the number in the session, starting at 1. A message with
+	// sequence 0 carries no number and only attaches the stream to the
+	// session. Numbers already processed are ignored when resent.
+	Sequence      uint64 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Number int32 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FindMaximumRequest) Reset() {
 	*x = FindMaximumRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *FindMaximumRequest) String() string {
@@ -446,7 +527,7 @@ func (*FindMaximumRequest) ProtoMessage() {}
 
 func (x *FindMaximumRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -468,21 +549,39 @@ func (x *FindMaximumRequest) GetNumber() int32 {
 	return 0
 }
 
+func (x *FindMaximumRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *FindMaximumRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
 type FindMaximumResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Maximum int32                  `protobuf:"varint,1,opt,name=maximum,proto3" json:"maximum,omitempty"`
+	// Echoes the session of the request.
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Highest sequence the server has processed in the session.
+	AckedSequence uint64 `protobuf:"varint,3,opt,name=acked_sequence,json=ackedSequence,proto3" json:"acked_sequence,omitempty"`
+	// Set when the response only acknowledges numbers, or reports the state
+	// of a resumed session, rather than announcing a new maximum.
+	AckOnly       bool `protobuf:"varint,4,opt,name=ack_only,json=ackOnly,proto3" json:"ack_only,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Maximum int32 `protobuf:"varint,1,opt,name=maximum,proto3" json:"maximum,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FindMaximumResponse) Reset() {
 	*x = FindMaximumResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[8]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *FindMaximumResponse) String() string {
@@ -493,7 +592,7 @@ func (*FindMaximumResponse) ProtoMessage() {}
 
 func (x *FindMaximumResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[8]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -515,548 +614,321 @@ func (x *FindMaximumResponse) GetMaximum() int32 {
 	return 0
 }
 
-var File_calculator_calculatorpb_calculator_proto protoreflect.FileDescriptor
-
-var file_calculator_calculatorpb_calculator_proto_rawDesc = []byte{
-	0x0a, 0x28, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x63, 0x61, 0x6c,
-	0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x70, 0x62, 0x2f, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
-	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x63, 0x61, 0x6c, 0x63,
-	0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x22, 0x74, 0x0a, 0x0d, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x63, 0x61, 0x6c,
-	0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x31, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x32, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x32, 0x22, 0x54, 0x0a, 0x10,
-	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x40, 0x0a, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x72,
-	0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75,
-	0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41,
-	0x72, 0x67, 0x73, 0x52, 0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72,
-	0x67, 0x73, 0x22, 0x2b, 0x0a, 0x11, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22,
-	0x39, 0x0a, 0x1f, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x44, 0x65,
-	0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x38, 0x0a, 0x20, 0x50, 0x72,
-	0x69, 0x6d, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x6f,
-	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x70, 0x72, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x70,
-	0x72, 0x69, 0x6d, 0x65, 0x22, 0x2f, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x41,
-	0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
-	0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x32, 0x0a, 0x16, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65,
-	0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x07, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x22, 0x2c, 0x0a, 0x12, 0x46, 0x69, 0x6e,
-	0x64, 0x4d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x2f, 0x0a, 0x13, 0x46, 0x69, 0x6e, 0x64, 0x4d,
-	0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x07, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x2a, 0x4b, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f,
-	0x53, 0x55, 0x4d, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f,
-	0x53, 0x55, 0x42, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f,
-	0x4d, 0x55, 0x4c, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f,
-	0x44, 0x49, 0x56, 0x10, 0x03, 0x32, 0x8d, 0x03, 0x0a, 0x11, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c,
-	0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4a, 0x0a, 0x09, 0x43,
-	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75,
-	0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61,
-	0x74, 0x6f, 0x72, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x79, 0x0a, 0x18, 0x50, 0x72, 0x69, 0x6d, 0x65,
-	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
-	0x2e, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x44, 0x65, 0x63, 0x6f,
-	0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x2c, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x50, 0x72,
-	0x69, 0x6d, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x6f,
-	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x30, 0x01, 0x12, 0x5b, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x41, 0x76, 0x65,
-	0x72, 0x61, 0x67, 0x65, 0x12, 0x21, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f,
-	0x72, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
-	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x41, 0x76, 0x65, 0x72,
-	0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12,
-	0x54, 0x0a, 0x0b, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x12, 0x1e,
-	0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x6e, 0x64,
-	0x4d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
-	0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x6e, 0x64,
-	0x4d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x28, 0x01, 0x30, 0x01, 0x42, 0x1b, 0x5a, 0x19, 0x2e, 0x2f, 0x63, 0x61, 0x6c, 0x63, 0x75,
-	0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
-	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_calculator_calculatorpb_calculator_proto_rawDescOnce sync.Once
-	file_calculator_calculatorpb_calculator_proto_rawDescData = file_calculator_calculatorpb_calculator_proto_rawDesc
-)
-
-func file_calculator_calculatorpb_calculator_proto_rawDescGZIP() []byte {
-	file_calculator_calculatorpb_calculator_proto_rawDescOnce.Do(func() {
-		file_calculator_calculatorpb_calculator_proto_rawDescData = protoimpl.X.CompressGZIP(file_calculator_calculatorpb_calculator_proto_rawDescData)
-	})
-	return file_calculator_calculatorpb_calculator_proto_rawDescData
-}
-
-var file_calculator_calculatorpb_calculator_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_calculator_calculatorpb_calculator_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
-var file_calculator_calculatorpb_calculator_proto_goTypes = []interface{}{
-	(Operation)(0),                           // 0: calculator.Operation
-	(*OperationArgs)(nil),                    // 1: calculator.OperationArgs
-	(*OperationRequest)(nil),                 // 2: calculator.OperationRequest
-	(*OperationResponse)(nil),                // 3: calculator.OperationResponse
-	(*PrimeNumberDecompositionRequest)(nil),  // 4: calculator.PrimeNumberDecompositionRequest
-	(*PrimeNumberDecompositionResponse)(nil), // 5: calculator.PrimeNumberDecompositionResponse
-	(*ComputeAverageRequest)(nil),            // 6: calculator.ComputeAverageRequest
-	(*ComputeAverageResponse)(nil),           // 7: calculator.ComputeAverageResponse
-	(*FindMaximumRequest)(nil),               // 8: calculator.FindMaximumRequest
-	(*FindMaximumResponse)(nil),              // 9: calculator.FindMaximumResponse
-}
-var file_calculator_calculatorpb_calculator_proto_depIdxs = []int32{
-	0, // 0: calculator.OperationArgs.operation:type_name -> calculator.Operation
-	1, // 1: calculator.OperationRequest.operation_args:type_name -> calculator.OperationArgs
-	2, // 2: calculator.CalculatorService.Calculate:input_type -> calculator.OperationRequest
-	4, // 3: calculator.CalculatorService.PrimeNumberDecomposition:input_type -> calculator.PrimeNumberDecompositionRequest
-	6, // 4: calculator.CalculatorService.ComputeAverage:input_type -> calculator.ComputeAverageRequest
-	8, // 5: calculator.CalculatorService.FindMaximum:input_type -> calculator.FindMaximumRequest
-	3, // 6: calculator.CalculatorService.Calculate:output_type -> calculator.OperationResponse
-	5, // 7: calculator.CalculatorService.PrimeNumberDecomposition:output_type -> calculator.PrimeNumberDecompositionResponse
-	7, // 8: calculator.CalculatorService.ComputeAverage:output_type -> calculator.ComputeAverageResponse
-	9, // 9: calculator.CalculatorService.FindMaximum:output_type -> calculator.FindMaximumResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+func (x *FindMaximumResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
 }
 
-func init() { file_calculator_calculatorpb_calculator_proto_init() }
-func file_calculator_calculatorpb_calculator_proto_init() {
-	if File_calculator_calculatorpb_calculator_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_calculator_calculatorpb_calculator_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OperationArgs); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_calculator_calculatorpb_calculator_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OperationRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_calculator_calculatorpb_calculator_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OperationResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_calculator_calculatorpb_calculator_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PrimeNumberDecompositionRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_calculator_calculatorpb_calculator_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PrimeNumberDecompositionResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_calculator_calculatorpb_calculator_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ComputeAverageRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_calculator_calculatorpb_calculator_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ComputeAverageResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_calculator_calculatorpb_calculator_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FindMaximumRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_calculator_calculatorpb_calculator_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FindMaximumResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
+func (x *FindMaximumResponse) GetAckedSequence() uint64 {
+	if x != nil {
+		return x.AckedSequence
 	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_calculator_calculatorpb_calculator_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   9,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_calculator_calculatorpb_calculator_proto_goTypes,
-		DependencyIndexes: file_calculator_calculatorpb_calculator_proto_depIdxs,
-		EnumInfos:         file_calculator_calculatorpb_calculator_proto_enumTypes,
-		MessageInfos:      file_calculator_calculatorpb_calculator_proto_msgTypes,
-	}.Build()
-	File_calculator_calculatorpb_calculator_proto = out.File
-	file_calculator_calculatorpb_calculator_proto_rawDesc = nil
-	file_calculator_calculatorpb_calculator_proto_goTypes = nil
-	file_calculator_calculatorpb_calculator_proto_depIdxs = nil
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
-
-// CalculatorServiceClient is the client API for CalculatorService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type CalculatorServiceClient interface {
-	// Unary gRPC
-	Calculate(ctx context.Context, in *OperationRequest, opts ...grpc.CallOption) (*OperationResponse, error)
-	// Server Streaming gRPC
-	PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error)
-	// Client Streaming gRPC
-	ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error)
-	// Bidirectional streaming gRPC
-	FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error)
+func (x *FindMaximumResponse) GetAckOnly() bool {
+	if x != nil {
+		return x.AckOnly
+	}
+	return false
+}
+
+// Aggregation is the checkpointed state of a ComputeAverage aggregation or
+// of a FindMaximum session.
+type Aggregation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind  AggregationKind        `protobuf:"varint,2,opt,name=kind,proto3,enum=calculator.v1.AggregationKind" json:"kind,omitempty"`
+	// How many numbers were aggregated.
+	Count uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// Highest sequence aggregated.
+	LastSequence uint64 `protobuf:"varint,4,opt,name=last_sequence,json=lastSequence,proto3" json:"last_sequence,omitempty"`
+	// Running average, for AGGREGATION_AVERAGE.
+	Average float64 `protobuf:"fixed64,5,opt,name=average,proto3" json:"average,omitempty"`
+	// Running maximum, for AGGREGATION_MAXIMUM once count is not zero.
+	Maximum int32 `protobuf:"varint,6,opt,name=maximum,proto3" json:"maximum,omitempty"`
+	// Set once the client finished the stream.
+	Done          bool                   `protobuf:"varint,7,opt,name=done,proto3" json:"done,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type calculatorServiceClient struct {
-	cc grpc.ClientConnInterface
+func (x *Aggregation) Reset() {
+	*x = Aggregation{}
+	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func NewCalculatorServiceClient(cc grpc.ClientConnInterface) CalculatorServiceClient {
-	return &calculatorServiceClient{cc}
+func (x *Aggregation) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (c *calculatorServiceClient) Calculate(ctx context.Context, in *OperationRequest, opts ...grpc.CallOption) (*OperationResponse, error) {
-	out := new(OperationResponse)
-	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Calculate", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
+func (*Aggregation) ProtoMessage() {}
 
-func (c *calculatorServiceClient) PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[0], "/calculator.CalculatorService/PrimeNumberDecomposition", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &calculatorServicePrimeNumberDecompositionClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (x *Aggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return x, nil
-}
-
-type CalculatorService_PrimeNumberDecompositionClient interface {
-	Recv() (*PrimeNumberDecompositionResponse, error)
-	grpc.ClientStream
+	return mi.MessageOf(x)
 }
 
-type calculatorServicePrimeNumberDecompositionClient struct {
-	grpc.ClientStream
+// Deprecated: Use Aggregation.ProtoReflect.Descriptor instead.
+func (*Aggregation) Descriptor() ([]byte, []int) {
+	return file_calculator_calculatorpb_calculator_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *calculatorServicePrimeNumberDecompositionClient) Recv() (*PrimeNumberDecompositionResponse, error) {
-	m := new(PrimeNumberDecompositionResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (x *Aggregation) GetId() string {
+	if x != nil {
+		return x.Id
 	}
-	return m, nil
+	return ""
 }
 
-func (c *calculatorServiceClient) ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[1], "/calculator.CalculatorService/ComputeAverage", opts...)
-	if err != nil {
-		return nil, err
+func (x *Aggregation) GetKind() AggregationKind {
+	if x != nil {
+		return x.Kind
 	}
-	x := &calculatorServiceComputeAverageClient{stream}
-	return x, nil
-}
-
-type CalculatorService_ComputeAverageClient interface {
-	Send(*ComputeAverageRequest) error
-	CloseAndRecv() (*ComputeAverageResponse, error)
-	grpc.ClientStream
-}
-
-type calculatorServiceComputeAverageClient struct {
-	grpc.ClientStream
+	return AggregationKind_AGGREGATION_UNSPECIFIED
 }
 
-func (x *calculatorServiceComputeAverageClient) Send(m *ComputeAverageRequest) error {
-	return x.ClientStream.SendMsg(m)
-}
-
-func (x *calculatorServiceComputeAverageClient) CloseAndRecv() (*ComputeAverageResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(ComputeAverageResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (x *Aggregation) GetCount() uint64 {
+	if x != nil {
+		return x.Count
 	}
-	return m, nil
+	return 0
 }
 
-func (c *calculatorServiceClient) FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[2], "/calculator.CalculatorService/FindMaximum", opts...)
-	if err != nil {
-		return nil, err
+func (x *Aggregation) GetLastSequence() uint64 {
+	if x != nil {
+		return x.LastSequence
 	}
-	x := &calculatorServiceFindMaximumClient{stream}
-	return x, nil
-}
-
-type CalculatorService_FindMaximumClient interface {
-	Send(*FindMaximumRequest) error
-	Recv() (*FindMaximumResponse, error)
-	grpc.ClientStream
-}
-
-type calculatorServiceFindMaximumClient struct {
-	grpc.ClientStream
-}
-
-func (x *calculatorServiceFindMaximumClient) Send(m *FindMaximumRequest) error {
-	return x.ClientStream.SendMsg(m)
+	return 0
 }
 
-func (x *calculatorServiceFindMaximumClient) Recv() (*FindMaximumResponse, error) {
-	m := new(FindMaximumResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (x *Aggregation) GetAverage() float64 {
+	if x != nil {
+		return x.Average
 	}
-	return m, nil
-}
-
-// CalculatorServiceServer is the server API for CalculatorService service.
-type CalculatorServiceServer interface {
-	// Unary gRPC
-	Calculate(context.Context, *OperationRequest) (*OperationResponse, error)
-	// Server Streaming gRPC
-	PrimeNumberDecomposition(*PrimeNumberDecompositionRequest, CalculatorService_PrimeNumberDecompositionServer) error
-	// Client Streaming gRPC
-	ComputeAverage(CalculatorService_ComputeAverageServer) error
-	// Bidirectional streaming gRPC
-	FindMaximum(CalculatorService_FindMaximumServer) error
-}
-
-// UnimplementedCalculatorServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedCalculatorServiceServer struct {
-}
-
-func (*UnimplementedCalculatorServiceServer) Calculate(context.Context, *OperationRequest) (*OperationResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Calculate not implemented")
-}
-func (*UnimplementedCalculatorServiceServer) PrimeNumberDecomposition(*PrimeNumberDecompositionRequest, CalculatorService_PrimeNumberDecompositionServer) error {
-	return status.Errorf(codes.Unimplemented, "method PrimeNumberDecomposition not implemented")
-}
-func (*UnimplementedCalculatorServiceServer) ComputeAverage(CalculatorService_ComputeAverageServer) error {
-	return status.Errorf(codes.Unimplemented, "method ComputeAverage not implemented")
-}
-func (*UnimplementedCalculatorServiceServer) FindMaximum(CalculatorService_FindMaximumServer) error {
-	return status.Errorf(codes.Unimplemented, "method FindMaximum not implemented")
-}
-
-func RegisterCalculatorServiceServer(s *grpc.Server, srv CalculatorServiceServer) {
-	s.RegisterService(&_CalculatorService_serviceDesc, srv)
+	return 0
 }
 
-func _CalculatorService_Calculate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OperationRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(CalculatorServiceServer).Calculate(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/calculator.CalculatorService/Calculate",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalculatorServiceServer).Calculate(ctx, req.(*OperationRequest))
+func (x *Aggregation) GetMaximum() int32 {
+	if x != nil {
+		return x.Maximum
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _CalculatorService_PrimeNumberDecomposition_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PrimeNumberDecompositionRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (x *Aggregation) GetDone() bool {
+	if x != nil {
+		return x.Done
 	}
-	return srv.(CalculatorServiceServer).PrimeNumberDecomposition(m, &calculatorServicePrimeNumberDecompositionServer{stream})
+	return false
 }
 
-type CalculatorService_PrimeNumberDecompositionServer interface {
-	Send(*PrimeNumberDecompositionResponse) error
-	grpc.ServerStream
+func (x *Aggregation) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
 }
 
-type calculatorServicePrimeNumberDecompositionServer struct {
-	grpc.ServerStream
+type GetAggregationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *calculatorServicePrimeNumberDecompositionServer) Send(m *PrimeNumberDecompositionResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (x *GetAggregationRequest) Reset() {
+	*x = GetAggregationRequest{}
+	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func _CalculatorService_ComputeAverage_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(CalculatorServiceServer).ComputeAverage(&calculatorServiceComputeAverageServer{stream})
+func (x *GetAggregationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type CalculatorService_ComputeAverageServer interface {
-	SendAndClose(*ComputeAverageResponse) error
-	Recv() (*ComputeAverageRequest, error)
-	grpc.ServerStream
-}
+func (*GetAggregationRequest) ProtoMessage() {}
 
-type calculatorServiceComputeAverageServer struct {
-	grpc.ServerStream
+func (x *GetAggregationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_calculatorpb_calculator_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *calculatorServiceComputeAverageServer) SendAndClose(m *ComputeAverageResponse) error {
-	return x.ServerStream.SendMsg(m)
+// Deprecated: Use GetAggregationRequest.ProtoReflect.Descriptor instead.
+func (*GetAggregationRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_calculatorpb_calculator_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *calculatorServiceComputeAverageServer) Recv() (*ComputeAverageRequest, error) {
-	m := new(ComputeAverageRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+func (x *GetAggregationRequest) GetId() string {
+	if x != nil {
+		return x.Id
 	}
-	return m, nil
+	return ""
 }
 
-func _CalculatorService_FindMaximum_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(CalculatorServiceServer).FindMaximum(&calculatorServiceFindMaximumServer{stream})
-}
+var File_calculator_calculatorpb_calculator_proto protoreflect.FileDescriptor
 
-type CalculatorService_FindMaximumServer interface {
-	Send(*FindMaximumResponse) error
-	Recv() (*FindMaximumRequest, error)
-	grpc.ServerStream
-}
+const file_calculator_calculatorpb_calculator_proto_rawDesc = "" +
+	"\n" +
+	"(calculator/calculatorpb/calculator.proto\x12\rcalculator.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x97\x01\n" +
+	"\rOperationArgs\x12@\n" +
+	"\toperation\x18\x01 \x01(\x0e2\x18.calculator.v1.OperationB\b\xbaH\x05\x82\x01\x02\x10\x01R\toperation\x12!\n" +
+	"\x06value1\x18\x02 \x01(\x02B\t\xbaH\x04\n" +
+	"\x02@\x01\x18\x01R\x06value1\x12!\n" +
+	"\x06value2\x18\x03 \x01(\x02B\t\xbaH\x04\n" +
+	"\x02@\x01\x18\x01R\x06value2\"_\n" +
+	"\x10OperationRequest\x12K\n" +
+	"\x0eoperation_args\x18\x01 \x01(\v2\x1c.calculator.v1.OperationArgsB\x06\xbaH\x03\xc8\x01\x01R\roperationArgs\"/\n" +
+	"\x11OperationResponse\x12\x1a\n" +
+	"\x06result\x18\x01 \x01(\x02B\x02\x18\x01R\x06result\"9\n" +
+	"\x1fPrimeNumberDecompositionRequest\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\rR\x06number\"8\n" +
+	" PrimeNumberDecompositionResponse\x12\x14\n" +
+	"\x05prime\x18\x01 \x01(\rR\x05prime\"|\n" +
+	"\x15ComputeAverageRequest\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12/\n" +
+	"\x0eaggregation_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\raggregationId\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x04R\bsequence\"o\n" +
+	"\x16ComputeAverageResponse\x12\x18\n" +
+	"\aaverage\x18\x01 \x01(\x01R\aaverage\x12%\n" +
+	"\x0eaggregation_id\x18\x02 \x01(\tR\raggregationId\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x04R\x05count\"q\n" +
+	"\x12FindMaximumRequest\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12'\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\tsessionId\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x04R\bsequence\"\x90\x01\n" +
+	"\x13FindMaximumResponse\x12\x18\n" +
+	"\amaximum\x18\x01 \x01(\x05R\amaximum\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12%\n" +
+	"\x0eacked_sequence\x18\x03 \x01(\x04R\rackedSequence\x12\x19\n" +
+	"\back_only\x18\x04 \x01(\bR\aackOnly\"\x91\x02\n" +
+	"\vAggregation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x122\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x1e.calculator.v1.AggregationKindR\x04kind\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x04R\x05count\x12#\n" +
+	"\rlast_sequence\x18\x04 \x01(\x04R\flastSequence\x12\x18\n" +
+	"\aaverage\x18\x05 \x01(\x01R\aaverage\x12\x18\n" +
+	"\amaximum\x18\x06 \x01(\x05R\amaximum\x12\x12\n" +
+	"\x04done\x18\a \x01(\bR\x04done\x12;\n" +
+	"\vupdate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\"4\n" +
+	"\x15GetAggregationRequest\x12\x1b\n" +
+	"\x02id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\x18\x80\x01R\x02id*K\n" +
+	"\tOperation\x12\x0e\n" +
+	"\n" +
+	"OPCODE_SUM\x10\x00\x12\x0e\n" +
+	"\n" +
+	"OPCODE_SUB\x10\x01\x12\x0e\n" +
+	"\n" +
+	"OPCODE_MUL\x10\x02\x12\x0e\n" +
+	"\n" +
+	"OPCODE_DIV\x10\x03*`\n" +
+	"\x0fAggregationKind\x12\x1b\n" +
+	"\x17AGGREGATION_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13AGGREGATION_AVERAGE\x10\x01\x12\x17\n" +
+	"\x13AGGREGATION_MAXIMUM\x10\x022\xd1\x04\n" +
+	"\x11CalculatorService\x12h\n" +
+	"\tCalculate\x12\x1f.calculator.v1.OperationRequest\x1a .calculator.v1.OperationResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/calculate\x12\x9a\x01\n" +
+	"\x18PrimeNumberDecomposition\x12..calculator.v1.PrimeNumberDecompositionRequest\x1a/.calculator.v1.PrimeNumberDecompositionResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/primes/{number}0\x01\x12a\n" +
+	"\x0eComputeAverage\x12$.calculator.v1.ComputeAverageRequest\x1a%.calculator.v1.ComputeAverageResponse\"\x00(\x01\x12Z\n" +
+	"\vFindMaximum\x12!.calculator.v1.FindMaximumRequest\x1a\".calculator.v1.FindMaximumResponse\"\x00(\x010\x01\x12q\n" +
+	"\x0eGetAggregation\x12$.calculator.v1.GetAggregationRequest\x1a\x1a.calculator.v1.Aggregation\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/aggregations/{id}\x1a\x03\x88\x02\x01B7Z5github.com/AlanKev117/go-grpc/calculator/calculatorpbb\x06proto3"
 
-type calculatorServiceFindMaximumServer struct {
-	grpc.ServerStream
-}
+var (
+	file_calculator_calculatorpb_calculator_proto_rawDescOnce sync.Once
+	file_calculator_calculatorpb_calculator_proto_rawDescData []byte
+)
 
-func (x *calculatorServiceFindMaximumServer) Send(m *FindMaximumResponse) error {
-	return x.ServerStream.SendMsg(m)
+func file_calculator_calculatorpb_calculator_proto_rawDescGZIP() []byte {
+	file_calculator_calculatorpb_calculator_proto_rawDescOnce.Do(func() {
+		file_calculator_calculatorpb_calculator_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_calculator_calculatorpb_calculator_proto_rawDesc), len(file_calculator_calculatorpb_calculator_proto_rawDesc)))
+	})
+	return file_calculator_calculatorpb_calculator_proto_rawDescData
 }
 
-func (x *calculatorServiceFindMaximumServer) Recv() (*FindMaximumRequest, error) {
-	m := new(FindMaximumRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+var file_calculator_calculatorpb_calculator_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_calculator_calculatorpb_calculator_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_calculator_calculatorpb_calculator_proto_goTypes = []any{
+	(Operation)(0),                           // 0: calculator.v1.Operation
+	(AggregationKind)(0),                     // 1: calculator.v1.AggregationKind
+	(*OperationArgs)(nil),                    // 2: calculator.v1.OperationArgs
+	(*OperationRequest)(nil),                 // 3: calculator.v1.OperationRequest
+	(*OperationResponse)(nil),                // 4: calculator.v1.OperationResponse
+	(*PrimeNumberDecompositionRequest)(nil),  // 5: calculator.v1.PrimeNumberDecompositionRequest
+	(*PrimeNumberDecompositionResponse)(nil), // 6: calculator.v1.PrimeNumberDecompositionResponse
+	(*ComputeAverageRequest)(nil),            // 7: calculator.v1.ComputeAverageRequest
+	(*ComputeAverageResponse)(nil),           // 8: calculator.v1.ComputeAverageResponse
+	(*FindMaximumRequest)(nil),               // 9: calculator.v1.FindMaximumRequest
+	(*FindMaximumResponse)(nil),              // 10: calculator.v1.FindMaximumResponse
+	(*Aggregation)(nil),                      // 11: calculator.v1.Aggregation
+	(*GetAggregationRequest)(nil),            // 12: calculator.v1.GetAggregationRequest
+	(*timestamppb.Timestamp)(nil),            // 13: google.protobuf.Timestamp
+}
+var file_calculator_calculatorpb_calculator_proto_depIdxs = []int32{
+	0,  // 0: calculator.v1.OperationArgs.operation:type_name -> calculator.v1.Operation
+	2,  // 1: calculator.v1.OperationRequest.operation_args:type_name -> calculator.v1.OperationArgs
+	1,  // 2: calculator.v1.Aggregation.kind:type_name -> calculator.v1.AggregationKind
+	13, // 3: calculator.v1.Aggregation.update_time:type_name -> google.protobuf.Timestamp
+	3,  // 4: calculator.v1.CalculatorService.Calculate:input_type -> calculator.v1.OperationRequest
+	5,  // 5: calculator.v1.CalculatorService.PrimeNumberDecomposition:input_type -> calculator.v1.PrimeNumberDecompositionRequest
+	7,  // 6: calculator.v1.CalculatorService.ComputeAverage:input_type -> calculator.v1.ComputeAverageRequest
+	9,  // 7: calculator.v1.CalculatorService.FindMaximum:input_type -> calculator.v1.FindMaximumRequest
+	12, // 8: calculator.v1.CalculatorService.GetAggregation:input_type -> calculator.v1.GetAggregationRequest
+	4,  // 9: calculator.v1.CalculatorService.Calculate:output_type -> calculator.v1.OperationResponse
+	6,  // 10: calculator.v1.CalculatorService.PrimeNumberDecomposition:output_type -> calculator.v1.PrimeNumberDecompositionResponse
+	8,  // 11: calculator.v1.CalculatorService.ComputeAverage:output_type -> calculator.v1.ComputeAverageResponse
+	10, // 12: calculator.v1.CalculatorService.FindMaximum:output_type -> calculator.v1.FindMaximumResponse
+	11, // 13: calculator.v1.CalculatorService.GetAggregation:output_type -> calculator.v1.Aggregation
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
-var _CalculatorService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "calculator.CalculatorService",
-	HandlerType: (*CalculatorServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Calculate",
-			Handler:    _CalculatorService_Calculate_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "PrimeNumberDecomposition",
-			Handler:       _CalculatorService_PrimeNumberDecomposition_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "ComputeAverage",
-			Handler:       _CalculatorService_ComputeAverage_Handler,
-			ClientStreams: true,
-		},
-		{
-			StreamName:    "FindMaximum",
-			Handler:       _CalculatorService_FindMaximum_Handler,
-			ServerStreams: true,
-			ClientStreams: true,
+func init() { file_calculator_calculatorpb_calculator_proto_init() }
+func file_calculator_calculatorpb_calculator_proto_init() {
+	if File_calculator_calculatorpb_calculator_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_calculator_calculatorpb_calculator_proto_rawDesc), len(file_calculator_calculatorpb_calculator_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
 		},
-	},
-	Metadata: "calculator/calculatorpb/calculator.proto",
+		GoTypes:           file_calculator_calculatorpb_calculator_proto_goTypes,
+		DependencyIndexes: file_calculator_calculatorpb_calculator_proto_depIdxs,
+		EnumInfos:         file_calculator_calculatorpb_calculator_proto_enumTypes,
+		MessageInfos:      file_calculator_calculatorpb_calculator_proto_msgTypes,
+	}.Build()
+	File_calculator_calculatorpb_calculator_proto = out.File
+	file_calculator_calculatorpb_calculator_proto_goTypes = nil
+	file_calculator_calculatorpb_calculator_proto_depIdxs = nil
 }
@@ -0,0 +1,243 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: calculator/calculatorpb/calculator.proto
+
+package calculatorpbconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	calculatorpb "github.com/AlanKev117/go-grpc/calculator/calculatorpb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// CalculatorServiceName is the fully-qualified name of the CalculatorService service.
+	CalculatorServiceName = "calculator.v1.CalculatorService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// CalculatorServiceCalculateProcedure is the fully-qualified name of the CalculatorService's
+	// Calculate RPC.
+	CalculatorServiceCalculateProcedure = "/calculator.v1.CalculatorService/Calculate"
+	// CalculatorServicePrimeNumberDecompositionProcedure is the fully-qualified name of the
+	// CalculatorService's PrimeNumberDecomposition RPC.
+	CalculatorServicePrimeNumberDecompositionProcedure = "/calculator.v1.CalculatorService/PrimeNumberDecomposition"
+	// CalculatorServiceComputeAverageProcedure is the fully-qualified name of the CalculatorService's
+	// ComputeAverage RPC.
+	CalculatorServiceComputeAverageProcedure = "/calculator.v1.CalculatorService/ComputeAverage"
+	// CalculatorServiceFindMaximumProcedure is the fully-qualified name of the CalculatorService's
+	// FindMaximum RPC.
+	CalculatorServiceFindMaximumProcedure = "/calculator.v1.CalculatorService/FindMaximum"
+	// CalculatorServiceGetAggregationProcedure is the fully-qualified name of the CalculatorService's
+	// GetAggregation RPC.
+	CalculatorServiceGetAggregationProcedure = "/calculator.v1.CalculatorService/GetAggregation"
+)
+
+// CalculatorServiceClient is a client for the calculator.v1.CalculatorService service.
+//
+// Deprecated: do not use.
+type CalculatorServiceClient interface {
+	// Unary gRPC
+	Calculate(context.Context, *connect.Request[calculatorpb.OperationRequest]) (*connect.Response[calculatorpb.OperationResponse], error)
+	// Server Streaming gRPC
+	PrimeNumberDecomposition(context.Context, *connect.Request[calculatorpb.PrimeNumberDecompositionRequest]) (*connect.ServerStreamForClient[calculatorpb.PrimeNumberDecompositionResponse], error)
+	// Client Streaming gRPC
+	ComputeAverage(context.Context) *connect.ClientStreamForClient[calculatorpb.ComputeAverageRequest, calculatorpb.ComputeAverageResponse]
+	// Bidirectional streaming gRPC
+	FindMaximum(context.Context) *connect.BidiStreamForClient[calculatorpb.FindMaximumRequest, calculatorpb.FindMaximumResponse]
+	// Returns the last checkpoint of an aggregation
+	GetAggregation(context.Context, *connect.Request[calculatorpb.GetAggregationRequest]) (*connect.Response[calculatorpb.Aggregation], error)
+}
+
+// NewCalculatorServiceClient constructs a client for the calculator.v1.CalculatorService service.
+// By default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped
+// responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+//
+// Deprecated: do not use.
+func NewCalculatorServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) CalculatorServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	calculatorServiceMethods := calculatorpb.File_calculator_calculatorpb_calculator_proto.Services().ByName("CalculatorService").Methods()
+	return &calculatorServiceClient{
+		calculate: connect.NewClient[calculatorpb.OperationRequest, calculatorpb.OperationResponse](
+			httpClient,
+			baseURL+CalculatorServiceCalculateProcedure,
+			connect.WithSchema(calculatorServiceMethods.ByName("Calculate")),
+			connect.WithClientOptions(opts...),
+		),
+		primeNumberDecomposition: connect.NewClient[calculatorpb.PrimeNumberDecompositionRequest, calculatorpb.PrimeNumberDecompositionResponse](
+			httpClient,
+			baseURL+CalculatorServicePrimeNumberDecompositionProcedure,
+			connect.WithSchema(calculatorServiceMethods.ByName("PrimeNumberDecomposition")),
+			connect.WithClientOptions(opts...),
+		),
+		computeAverage: connect.NewClient[calculatorpb.ComputeAverageRequest, calculatorpb.ComputeAverageResponse](
+			httpClient,
+			baseURL+CalculatorServiceComputeAverageProcedure,
+			connect.WithSchema(calculatorServiceMethods.ByName("ComputeAverage")),
+			connect.WithClientOptions(opts...),
+		),
+		findMaximum: connect.NewClient[calculatorpb.FindMaximumRequest, calculatorpb.FindMaximumResponse](
+			httpClient,
+			baseURL+CalculatorServiceFindMaximumProcedure,
+			connect.WithSchema(calculatorServiceMethods.ByName("FindMaximum")),
+			connect.WithClientOptions(opts...),
+		),
+		getAggregation: connect.NewClient[calculatorpb.GetAggregationRequest, calculatorpb.Aggregation](
+			httpClient,
+			baseURL+CalculatorServiceGetAggregationProcedure,
+			connect.WithSchema(calculatorServiceMethods.ByName("GetAggregation")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// calculatorServiceClient implements CalculatorServiceClient.
+type calculatorServiceClient struct {
+	calculate                *connect.Client[calculatorpb.OperationRequest, calculatorpb.OperationResponse]
+	primeNumberDecomposition *connect.Client[calculatorpb.PrimeNumberDecompositionRequest, calculatorpb.PrimeNumberDecompositionResponse]
+	computeAverage           *connect.Client[calculatorpb.ComputeAverageRequest, calculatorpb.ComputeAverageResponse]
+	findMaximum              *connect.Client[calculatorpb.FindMaximumRequest, calculatorpb.FindMaximumResponse]
+	getAggregation           *connect.Client[calculatorpb.GetAggregationRequest, calculatorpb.Aggregation]
+}
+
+// Calculate calls calculator.v1.CalculatorService.Calculate.
+func (c *calculatorServiceClient) Calculate(ctx context.Context, req *connect.Request[calculatorpb.OperationRequest]) (*connect.Response[calculatorpb.OperationResponse], error) {
+	return c.calculate.CallUnary(ctx, req)
+}
+
+// PrimeNumberDecomposition calls calculator.v1.CalculatorService.PrimeNumberDecomposition.
+func (c *calculatorServiceClient) PrimeNumberDecomposition(ctx context.Context, req *connect.Request[calculatorpb.PrimeNumberDecompositionRequest]) (*connect.ServerStreamForClient[calculatorpb.PrimeNumberDecompositionResponse], error) {
+	return c.primeNumberDecomposition.CallServerStream(ctx, req)
+}
+
+// ComputeAverage calls calculator.v1.CalculatorService.ComputeAverage.
+func (c *calculatorServiceClient) ComputeAverage(ctx context.Context) *connect.ClientStreamForClient[calculatorpb.ComputeAverageRequest, calculatorpb.ComputeAverageResponse] {
+	return c.computeAverage.CallClientStream(ctx)
+}
+
+// FindMaximum calls calculator.v1.CalculatorService.FindMaximum.
+func (c *calculatorServiceClient) FindMaximum(ctx context.Context) *connect.BidiStreamForClient[calculatorpb.FindMaximumRequest, calculatorpb.FindMaximumResponse] {
+	return c.findMaximum.CallBidiStream(ctx)
+}
+
+// GetAggregation calls calculator.v1.CalculatorService.GetAggregation.
+func (c *calculatorServiceClient) GetAggregation(ctx context.Context, req *connect.Request[calculatorpb.GetAggregationRequest]) (*connect.Response[calculatorpb.Aggregation], error) {
+	return c.getAggregation.CallUnary(ctx, req)
+}
+
+// CalculatorServiceHandler is an implementation of the calculator.v1.CalculatorService service.
+//
+// Deprecated: do not use.
+type CalculatorServiceHandler interface {
+	// Unary gRPC
+	Calculate(context.Context, *connect.Request[calculatorpb.OperationRequest]) (*connect.Response[calculatorpb.OperationResponse], error)
+	// Server Streaming gRPC
+	PrimeNumberDecomposition(context.Context, *connect.Request[calculatorpb.PrimeNumberDecompositionRequest], *connect.ServerStream[calculatorpb.PrimeNumberDecompositionResponse]) error
+	// Client Streaming gRPC
+	ComputeAverage(context.Context, *connect.ClientStream[calculatorpb.ComputeAverageRequest]) (*connect.Response[calculatorpb.ComputeAverageResponse], error)
+	// Bidirectional streaming gRPC
+	FindMaximum(context.Context, *connect.BidiStream[calculatorpb.FindMaximumRequest, calculatorpb.FindMaximumResponse]) error
+	// Returns the last checkpoint of an aggregation
+	GetAggregation(context.Context, *connect.Request[calculatorpb.GetAggregationRequest]) (*connect.Response[calculatorpb.Aggregation], error)
+}
+
+// NewCalculatorServiceHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+//
+// Deprecated: do not use.
+func NewCalculatorServiceHandler(svc CalculatorServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	calculatorServiceMethods := calculatorpb.File_calculator_calculatorpb_calculator_proto.Services().ByName("CalculatorService").Methods()
+	calculatorServiceCalculateHandler := connect.NewUnaryHandler(
+		CalculatorServiceCalculateProcedure,
+		svc.Calculate,
+		connect.WithSchema(calculatorServiceMethods.ByName("Calculate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	calculatorServicePrimeNumberDecompositionHandler := connect.NewServerStreamHandler(
+		CalculatorServicePrimeNumberDecompositionProcedure,
+		svc.PrimeNumberDecomposition,
+		connect.WithSchema(calculatorServiceMethods.ByName("PrimeNumberDecomposition")),
+		connect.WithHandlerOptions(opts...),
+	)
+	calculatorServiceComputeAverageHandler := connect.NewClientStreamHandler(
+		CalculatorServiceComputeAverageProcedure,
+		svc.ComputeAverage,
+		connect.WithSchema(calculatorServiceMethods.ByName("ComputeAverage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	calculatorServiceFindMaximumHandler := connect.NewBidiStreamHandler(
+		CalculatorServiceFindMaximumProcedure,
+		svc.FindMaximum,
+		connect.WithSchema(calculatorServiceMethods.ByName("FindMaximum")),
+		connect.WithHandlerOptions(opts...),
+	)
+	calculatorServiceGetAggregationHandler := connect.NewUnaryHandler(
+		CalculatorServiceGetAggregationProcedure,
+		svc.GetAggregation,
+		connect.WithSchema(calculatorServiceMethods.ByName("GetAggregation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/calculator.v1.CalculatorService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case CalculatorServiceCalculateProcedure:
+			calculatorServiceCalculateHandler.ServeHTTP(w, r)
+		case CalculatorServicePrimeNumberDecompositionProcedure:
+			calculatorServicePrimeNumberDecompositionHandler.ServeHTTP(w, r)
+		case CalculatorServiceComputeAverageProcedure:
+			calculatorServiceComputeAverageHandler.ServeHTTP(w, r)
+		case CalculatorServiceFindMaximumProcedure:
+			calculatorServiceFindMaximumHandler.ServeHTTP(w, r)
+		case CalculatorServiceGetAggregationProcedure:
+			calculatorServiceGetAggregationHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedCalculatorServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedCalculatorServiceHandler struct{}
+
+func (UnimplementedCalculatorServiceHandler) Calculate(context.Context, *connect.Request[calculatorpb.OperationRequest]) (*connect.Response[calculatorpb.OperationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("calculator.v1.CalculatorService.Calculate is not implemented"))
+}
+
+func (UnimplementedCalculatorServiceHandler) PrimeNumberDecomposition(context.Context, *connect.Request[calculatorpb.PrimeNumberDecompositionRequest], *connect.ServerStream[calculatorpb.PrimeNumberDecompositionResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("calculator.v1.CalculatorService.PrimeNumberDecomposition is not implemented"))
+}
+
+func (UnimplementedCalculatorServiceHandler) ComputeAverage(context.Context, *connect.ClientStream[calculatorpb.ComputeAverageRequest]) (*connect.Response[calculatorpb.ComputeAverageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("calculator.v1.CalculatorService.ComputeAverage is not implemented"))
+}
+
+func (UnimplementedCalculatorServiceHandler) FindMaximum(context.Context, *connect.BidiStream[calculatorpb.FindMaximumRequest, calculatorpb.FindMaximumResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("calculator.v1.CalculatorService.FindMaximum is not implemented"))
+}
+
+func (UnimplementedCalculatorServiceHandler) GetAggregation(context.Context, *connect.Request[calculatorpb.GetAggregationRequest]) (*connect.Response[calculatorpb.Aggregation], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("calculator.v1.CalculatorService.GetAggregation is not implemented"))
+}
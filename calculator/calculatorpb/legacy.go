@@ -0,0 +1,16 @@
+package calculatorpb
+
+import "github.com/AlanKev117/go-grpc/alias"
+
+// LegacyCalculatorService_ServiceDesc describes CalculatorService under
+// calculator.CalculatorService, its name before it moved to the
+// calculator.v1 package, with the methods it had then. Servers register it
+// next to CalculatorService_ServiceDesc to keep answering clients built
+// earlier; the messages did not change.
+var LegacyCalculatorService_ServiceDesc = alias.Desc(CalculatorService_ServiceDesc, "calculator.CalculatorService",
+	"Calculate", "PrimeNumberDecomposition", "ComputeAverage", "FindMaximum")
+
+func init() {
+	// calculator.v2 took over the methods of v1.
+	alias.Register(CalculatorService_ServiceDesc.ServiceName, "calculator.v2.CalculatorService")
+}
@@ -0,0 +1,9 @@
+package calculatorpb
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI v2 spec of the HTTP/JSON mapping of
+// CalculatorService, generated by protoc-gen-openapiv2.
+//
+//go:embed calculator.swagger.json
+var OpenAPI []byte
@@ -0,0 +1,923 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: calculator/calculatorv2pb/calculator.proto
+
+package calculatorv2pb
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Operation int32
+
+const (
+	Operation_OPCODE_SUM Operation = 0
+	Operation_OPCODE_SUB Operation = 1
+	Operation_OPCODE_MUL Operation = 2
+	Operation_OPCODE_DIV Operation = 3
+)
+
+// Enum value maps for Operation.
+var (
+	Operation_name = map[int32]string{
+		0: "OPCODE_SUM",
+		1: "OPCODE_SUB",
+		2: "OPCODE_MUL",
+		3: "OPCODE_DIV",
+	}
+	Operation_value = map[string]int32{
+		"OPCODE_SUM": 0,
+		"OPCODE_SUB": 1,
+		"OPCODE_MUL": 2,
+		"OPCODE_DIV": 3,
+	}
+)
+
+func (x Operation) Enum() *Operation {
+	p := new(Operation)
+	*p = x
+	return p
+}
+
+func (x Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_calculator_calculatorv2pb_calculator_proto_enumTypes[0].Descriptor()
+}
+
+func (Operation) Type() protoreflect.EnumType {
+	return &file_calculator_calculatorv2pb_calculator_proto_enumTypes[0]
+}
+
+func (x Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Operation.Descriptor instead.
+func (Operation) EnumDescriptor() ([]byte, []int) {
+	return file_calculator_calculatorv2pb_calculator_proto_rawDescGZIP(), []int{0}
+}
+
+type AggregationKind int32
+
+const (
+	AggregationKind_AGGREGATION_UNSPECIFIED AggregationKind = 0
+	AggregationKind_AGGREGATION_AVERAGE     AggregationKind = 1
+	AggregationKind_AGGREGATION_MAXIMUM     AggregationKind = 2
+)
+
+// Enum value maps for AggregationKind.
+var (
+	AggregationKind_name = map[int32]string{
+		0: "AGGREGATION_UNSPECIFIED",
+		1: "AGGREGATION_AVERAGE",
+		2: "AGGREGATION_MAXIMUM",
+	}
+	AggregationKind_value = map[string]int32{
+		"AGGREGATION_UNSPECIFIED": 0,
+		"AGGREGATION_AVERAGE":     1,
+		"AGGREGATION_MAXIMUM":     2,
+	}
+)
+
+func (x AggregationKind) Enum() *AggregationKind {
+	p := new(AggregationKind)
+	*p = x
+	return p
+}
+
+func (x AggregationKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AggregationKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_calculator_calculatorv2pb_calculator_proto_enumTypes[1].Descriptor()
+}
+
+func (AggregationKind) Type() protoreflect.EnumType {
+	return &file_calculator_calculatorv2pb_calculator_proto_enumTypes[1]
+}
+
+func (x AggregationKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AggregationKind.Descriptor instead.
+func (AggregationKind) EnumDescriptor() ([]byte, []int) {
+	return file_calculator_calculatorv2pb_calculator_proto_rawDescGZIP(), []int{1}
+}
+
+type OperationArgs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operation     Operation              `protobuf:"varint,1,opt,name=operation,proto3,enum=calculator.v2.Operation" json:"operation,omitempty"`
+	Value1        float64                `protobuf:"fixed64,2,opt,name=value1,proto3" json:"value1,omitempty"`
+	Value2        float64                `protobuf:"fixed64,3,opt,name=value2,proto3" json:"value2,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationArgs) Reset() {
+	*x = OperationArgs{}
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationArgs) ProtoMessage() {}
+
+func (x *OperationArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationArgs.ProtoReflect.Descriptor instead.
+func (*OperationArgs) Descriptor() ([]byte, []int) {
+	return file_calculator_calculatorv2pb_calculator_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OperationArgs) GetOperation() Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return Operation_OPCODE_SUM
+}
+
+func (x *OperationArgs) GetValue1() float64 {
+	if x != nil {
+		return x.Value1
+	}
+	return 0
+}
+
+func (x *OperationArgs) GetValue2() float64 {
+	if x != nil {
+		return x.Value2
+	}
+	return 0
+}
+
+type OperationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OperationArgs *OperationArgs         `protobuf:"bytes,1,opt,name=operation_args,json=operationArgs,proto3" json:"operation_args,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationRequest) Reset() {
+	*x = OperationRequest{}
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationRequest) ProtoMessage() {}
+
+func (x *OperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationRequest.ProtoReflect.Descriptor instead.
+func (*OperationRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_calculatorv2pb_calculator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OperationRequest) GetOperationArgs() *OperationArgs {
+	if x != nil {
+		return x.OperationArgs
+	}
+	return nil
+}
+
+type OperationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        float64                `protobuf:"fixed64,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationResponse) Reset() {
+	*x = OperationResponse{}
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationResponse) ProtoMessage() {}
+
+func (x *OperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationResponse.ProtoReflect.Descriptor instead.
+func (*OperationResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_calculatorv2pb_calculator_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OperationResponse) GetResult() float64 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+type PrimeNumberDecompositionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        uint64                 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrimeNumberDecompositionRequest) Reset() {
+	*x = PrimeNumberDecompositionRequest{}
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrimeNumberDecompositionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrimeNumberDecompositionRequest) ProtoMessage() {}
+
+func (x *PrimeNumberDecompositionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrimeNumberDecompositionRequest.ProtoReflect.Descriptor instead.
+func (*PrimeNumberDecompositionRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_calculatorv2pb_calculator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PrimeNumberDecompositionRequest) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+type PrimeNumberDecompositionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prime         uint64                 `protobuf:"varint,1,opt,name=prime,proto3" json:"prime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrimeNumberDecompositionResponse) Reset() {
+	*x = PrimeNumberDecompositionResponse{}
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrimeNumberDecompositionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrimeNumberDecompositionResponse) ProtoMessage() {}
+
+func (x *PrimeNumberDecompositionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrimeNumberDecompositionResponse.ProtoReflect.Descriptor instead.
+func (*PrimeNumberDecompositionResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_calculatorv2pb_calculator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PrimeNumberDecompositionResponse) GetPrime() uint64 {
+	if x != nil {
+		return x.Prime
+	}
+	return 0
+}
+
+type ComputeAverageRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Number float64                `protobuf:"fixed64,1,opt,name=number,proto3" json:"number,omitempty"`
+	// Identifies an aggregation checkpointed by the server, so that it can
+	// be queried with GetAggregation and resumed by opening a new stream
+	// with the same ID. Clients set it on the first message.
+	AggregationId string `protobuf:"bytes,2,opt,name=aggregation_id,json=aggregationId,proto3" json:"aggregation_id,omitempty"`
+	// Position of the number in the aggregation, starting at 1. A message
+	// with sequence 0 carries no number and only attaches the stream to the
+	// aggregation. Numbers already aggregated are ignored when resent.
+	Sequence      uint64 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComputeAverageRequest) Reset() {
+	*x = ComputeAverageRequest{}
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComputeAverageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComputeAverageRequest) ProtoMessage() {}
+
+func (x *ComputeAverageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComputeAverageRequest.ProtoReflect.Descriptor instead.
+func (*ComputeAverageRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_calculatorv2pb_calculator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ComputeAverageRequest) GetNumber() float64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *ComputeAverageRequest) GetAggregationId() string {
+	if x != nil {
+		return x.AggregationId
+	}
+	return ""
+}
+
+func (x *ComputeAverageRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type ComputeAverageResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Average float64                `protobuf:"fixed64,1,opt,name=average,proto3" json:"average,omitempty"`
+	// Echoes the aggregation of the request.
+	AggregationId string `protobuf:"bytes,2,opt,name=aggregation_id,json=aggregationId,proto3" json:"aggregation_id,omitempty"`
+	// How many numbers were averaged.
+	Count         uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComputeAverageResponse) Reset() {
+	*x = ComputeAverageResponse{}
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComputeAverageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComputeAverageResponse) ProtoMessage() {}
+
+func (x *ComputeAverageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComputeAverageResponse.ProtoReflect.Descriptor instead.
+func (*ComputeAverageResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_calculatorv2pb_calculator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ComputeAverageResponse) GetAverage() float64 {
+	if x != nil {
+		return x.Average
+	}
+	return 0
+}
+
+func (x *ComputeAverageResponse) GetAggregationId() string {
+	if x != nil {
+		return x.AggregationId
+	}
+	return ""
+}
+
+func (x *ComputeAverageResponse) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type FindMaximumRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Number float64                `protobuf:"fixed64,1,opt,name=number,proto3" json:"number,omitempty"`
+	// Identifies a resumable session. Clients set it on the first message
+	// of a stream, and again when reconnecting to resume the session.
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Position of the number in the session, starting at 1. A message with
+	// sequence 0 carries no number and only attaches the stream to the
+	// session. Numbers already processed are ignored when resent.
+	Sequence      uint64 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindMaximumRequest) Reset() {
+	*x = FindMaximumRequest{}
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindMaximumRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMaximumRequest) ProtoMessage() {}
+
+func (x *FindMaximumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMaximumRequest.ProtoReflect.Descriptor instead.
+func (*FindMaximumRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_calculatorv2pb_calculator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FindMaximumRequest) GetNumber() float64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *FindMaximumRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *FindMaximumRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type FindMaximumResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Maximum float64                `protobuf:"fixed64,1,opt,name=maximum,proto3" json:"maximum,omitempty"`
+	// Echoes the session of the request.
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Highest sequence the server has processed in the session.
+	AckedSequence uint64 `protobuf:"varint,3,opt,name=acked_sequence,json=ackedSequence,proto3" json:"acked_sequence,omitempty"`
+	// Set when the response only acknowledges numbers, or reports the state
+	// of a resumed session, rather than announcing a new maximum.
+	AckOnly       bool `protobuf:"varint,4,opt,name=ack_only,json=ackOnly,proto3" json:"ack_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindMaximumResponse) Reset() {
+	*x = FindMaximumResponse{}
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindMaximumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMaximumResponse) ProtoMessage() {}
+
+func (x *FindMaximumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMaximumResponse.ProtoReflect.Descriptor instead.
+func (*FindMaximumResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_calculatorv2pb_calculator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FindMaximumResponse) GetMaximum() float64 {
+	if x != nil {
+		return x.Maximum
+	}
+	return 0
+}
+
+func (x *FindMaximumResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *FindMaximumResponse) GetAckedSequence() uint64 {
+	if x != nil {
+		return x.AckedSequence
+	}
+	return 0
+}
+
+func (x *FindMaximumResponse) GetAckOnly() bool {
+	if x != nil {
+		return x.AckOnly
+	}
+	return false
+}
+
+// Aggregation is the checkpointed state of a ComputeAverage aggregation or
+// of a FindMaximum session.
+type Aggregation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind  AggregationKind        `protobuf:"varint,2,opt,name=kind,proto3,enum=calculator.v2.AggregationKind" json:"kind,omitempty"`
+	// How many numbers were aggregated.
+	Count uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// Highest sequence aggregated.
+	LastSequence uint64 `protobuf:"varint,4,opt,name=last_sequence,json=lastSequence,proto3" json:"last_sequence,omitempty"`
+	// Running average, for AGGREGATION_AVERAGE.
+	Average float64 `protobuf:"fixed64,5,opt,name=average,proto3" json:"average,omitempty"`
+	// Running maximum, for AGGREGATION_MAXIMUM once count is not zero.
+	Maximum float64 `protobuf:"fixed64,9,opt,name=maximum,proto3" json:"maximum,omitempty"`
+	// Set once the client finished the stream.
+	Done          bool                   `protobuf:"varint,7,opt,name=done,proto3" json:"done,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Aggregation) Reset() {
+	*x = Aggregation{}
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Aggregation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Aggregation) ProtoMessage() {}
+
+func (x *Aggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Aggregation.ProtoReflect.Descriptor instead.
+func (*Aggregation) Descriptor() ([]byte, []int) {
+	return file_calculator_calculatorv2pb_calculator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Aggregation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Aggregation) GetKind() AggregationKind {
+	if x != nil {
+		return x.Kind
+	}
+	return AggregationKind_AGGREGATION_UNSPECIFIED
+}
+
+func (x *Aggregation) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Aggregation) GetLastSequence() uint64 {
+	if x != nil {
+		return x.LastSequence
+	}
+	return 0
+}
+
+func (x *Aggregation) GetAverage() float64 {
+	if x != nil {
+		return x.Average
+	}
+	return 0
+}
+
+func (x *Aggregation) GetMaximum() float64 {
+	if x != nil {
+		return x.Maximum
+	}
+	return 0
+}
+
+func (x *Aggregation) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *Aggregation) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type GetAggregationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAggregationRequest) Reset() {
+	*x = GetAggregationRequest{}
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAggregationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAggregationRequest) ProtoMessage() {}
+
+func (x *GetAggregationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_calculatorv2pb_calculator_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAggregationRequest.ProtoReflect.Descriptor instead.
+func (*GetAggregationRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_calculatorv2pb_calculator_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetAggregationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_calculator_calculatorv2pb_calculator_proto protoreflect.FileDescriptor
+
+const file_calculator_calculatorv2pb_calculator_proto_rawDesc = "" +
+	"\n" +
+	"*calculator/calculatorv2pb/calculator.proto\x12\rcalculator.v2\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x93\x01\n" +
+	"\rOperationArgs\x12@\n" +
+	"\toperation\x18\x01 \x01(\x0e2\x18.calculator.v2.OperationB\b\xbaH\x05\x82\x01\x02\x10\x01R\toperation\x12\x1f\n" +
+	"\x06value1\x18\x02 \x01(\x01B\a\xbaH\x04\x12\x02@\x01R\x06value1\x12\x1f\n" +
+	"\x06value2\x18\x03 \x01(\x01B\a\xbaH\x04\x12\x02@\x01R\x06value2\"_\n" +
+	"\x10OperationRequest\x12K\n" +
+	"\x0eoperation_args\x18\x01 \x01(\v2\x1c.calculator.v2.OperationArgsB\x06\xbaH\x03\xc8\x01\x01R\roperationArgs\"+\n" +
+	"\x11OperationResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\x01R\x06result\"9\n" +
+	"\x1fPrimeNumberDecompositionRequest\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x04R\x06number\"8\n" +
+	" PrimeNumberDecompositionResponse\x12\x14\n" +
+	"\x05prime\x18\x01 \x01(\x04R\x05prime\"\x85\x01\n" +
+	"\x15ComputeAverageRequest\x12\x1f\n" +
+	"\x06number\x18\x01 \x01(\x01B\a\xbaH\x04\x12\x02@\x01R\x06number\x12/\n" +
+	"\x0eaggregation_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\raggregationId\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x04R\bsequence\"o\n" +
+	"\x16ComputeAverageResponse\x12\x18\n" +
+	"\aaverage\x18\x01 \x01(\x01R\aaverage\x12%\n" +
+	"\x0eaggregation_id\x18\x02 \x01(\tR\raggregationId\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x04R\x05count\"z\n" +
+	"\x12FindMaximumRequest\x12\x1f\n" +
+	"\x06number\x18\x01 \x01(\x01B\a\xbaH\x04\x12\x02@\x01R\x06number\x12'\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\tsessionId\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x04R\bsequence\"\x90\x01\n" +
+	"\x13FindMaximumResponse\x12\x18\n" +
+	"\amaximum\x18\x01 \x01(\x01R\amaximum\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12%\n" +
+	"\x0eacked_sequence\x18\x03 \x01(\x04R\rackedSequence\x12\x19\n" +
+	"\back_only\x18\x04 \x01(\bR\aackOnly\"\x97\x02\n" +
+	"\vAggregation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x122\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x1e.calculator.v2.AggregationKindR\x04kind\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x04R\x05count\x12#\n" +
+	"\rlast_sequence\x18\x04 \x01(\x04R\flastSequence\x12\x18\n" +
+	"\aaverage\x18\x05 \x01(\x01R\aaverage\x12\x18\n" +
+	"\amaximum\x18\t \x01(\x01R\amaximum\x12\x12\n" +
+	"\x04done\x18\a \x01(\bR\x04done\x12;\n" +
+	"\vupdate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTimeJ\x04\b\x06\x10\a\"4\n" +
+	"\x15GetAggregationRequest\x12\x1b\n" +
+	"\x02id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\x18\x80\x01R\x02id*K\n" +
+	"\tOperation\x12\x0e\n" +
+	"\n" +
+	"OPCODE_SUM\x10\x00\x12\x0e\n" +
+	"\n" +
+	"OPCODE_SUB\x10\x01\x12\x0e\n" +
+	"\n" +
+	"OPCODE_MUL\x10\x02\x12\x0e\n" +
+	"\n" +
+	"OPCODE_DIV\x10\x03*`\n" +
+	"\x0fAggregationKind\x12\x1b\n" +
+	"\x17AGGREGATION_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13AGGREGATION_AVERAGE\x10\x01\x12\x17\n" +
+	"\x13AGGREGATION_MAXIMUM\x10\x022\xcc\x04\n" +
+	"\x11CalculatorService\x12h\n" +
+	"\tCalculate\x12\x1f.calculator.v2.OperationRequest\x1a .calculator.v2.OperationResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v2/calculate\x12\x9a\x01\n" +
+	"\x18PrimeNumberDecomposition\x12..calculator.v2.PrimeNumberDecompositionRequest\x1a/.calculator.v2.PrimeNumberDecompositionResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v2/primes/{number}0\x01\x12a\n" +
+	"\x0eComputeAverage\x12$.calculator.v2.ComputeAverageRequest\x1a%.calculator.v2.ComputeAverageResponse\"\x00(\x01\x12Z\n" +
+	"\vFindMaximum\x12!.calculator.v2.FindMaximumRequest\x1a\".calculator.v2.FindMaximumResponse\"\x00(\x010\x01\x12q\n" +
+	"\x0eGetAggregation\x12$.calculator.v2.GetAggregationRequest\x1a\x1a.calculator.v2.Aggregation\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v2/aggregations/{id}B9Z7github.com/AlanKev117/go-grpc/calculator/calculatorv2pbb\x06proto3"
+
+var (
+	file_calculator_calculatorv2pb_calculator_proto_rawDescOnce sync.Once
+	file_calculator_calculatorv2pb_calculator_proto_rawDescData []byte
+)
+
+func file_calculator_calculatorv2pb_calculator_proto_rawDescGZIP() []byte {
+	file_calculator_calculatorv2pb_calculator_proto_rawDescOnce.Do(func() {
+		file_calculator_calculatorv2pb_calculator_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_calculator_calculatorv2pb_calculator_proto_rawDesc), len(file_calculator_calculatorv2pb_calculator_proto_rawDesc)))
+	})
+	return file_calculator_calculatorv2pb_calculator_proto_rawDescData
+}
+
+var file_calculator_calculatorv2pb_calculator_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_calculator_calculatorv2pb_calculator_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_calculator_calculatorv2pb_calculator_proto_goTypes = []any{
+	(Operation)(0),                           // 0: calculator.v2.Operation
+	(AggregationKind)(0),                     // 1: calculator.v2.AggregationKind
+	(*OperationArgs)(nil),                    // 2: calculator.v2.OperationArgs
+	(*OperationRequest)(nil),                 // 3: calculator.v2.OperationRequest
+	(*OperationResponse)(nil),                // 4: calculator.v2.OperationResponse
+	(*PrimeNumberDecompositionRequest)(nil),  // 5: calculator.v2.PrimeNumberDecompositionRequest
+	(*PrimeNumberDecompositionResponse)(nil), // 6: calculator.v2.PrimeNumberDecompositionResponse
+	(*ComputeAverageRequest)(nil),            // 7: calculator.v2.ComputeAverageRequest
+	(*ComputeAverageResponse)(nil),           // 8: calculator.v2.ComputeAverageResponse
+	(*FindMaximumRequest)(nil),               // 9: calculator.v2.FindMaximumRequest
+	(*FindMaximumResponse)(nil),              // 10: calculator.v2.FindMaximumResponse
+	(*Aggregation)(nil),                      // 11: calculator.v2.Aggregation
+	(*GetAggregationRequest)(nil),            // 12: calculator.v2.GetAggregationRequest
+	(*timestamppb.Timestamp)(nil),            // 13: google.protobuf.Timestamp
+}
+var file_calculator_calculatorv2pb_calculator_proto_depIdxs = []int32{
+	0,  // 0: calculator.v2.OperationArgs.operation:type_name -> calculator.v2.Operation
+	2,  // 1: calculator.v2.OperationRequest.operation_args:type_name -> calculator.v2.OperationArgs
+	1,  // 2: calculator.v2.Aggregation.kind:type_name -> calculator.v2.AggregationKind
+	13, // 3: calculator.v2.Aggregation.update_time:type_name -> google.protobuf.Timestamp
+	3,  // 4: calculator.v2.CalculatorService.Calculate:input_type -> calculator.v2.OperationRequest
+	5,  // 5: calculator.v2.CalculatorService.PrimeNumberDecomposition:input_type -> calculator.v2.PrimeNumberDecompositionRequest
+	7,  // 6: calculator.v2.CalculatorService.ComputeAverage:input_type -> calculator.v2.ComputeAverageRequest
+	9,  // 7: calculator.v2.CalculatorService.FindMaximum:input_type -> calculator.v2.FindMaximumRequest
+	12, // 8: calculator.v2.CalculatorService.GetAggregation:input_type -> calculator.v2.GetAggregationRequest
+	4,  // 9: calculator.v2.CalculatorService.Calculate:output_type -> calculator.v2.OperationResponse
+	6,  // 10: calculator.v2.CalculatorService.PrimeNumberDecomposition:output_type -> calculator.v2.PrimeNumberDecompositionResponse
+	8,  // 11: calculator.v2.CalculatorService.ComputeAverage:output_type -> calculator.v2.ComputeAverageResponse
+	10, // 12: calculator.v2.CalculatorService.FindMaximum:output_type -> calculator.v2.FindMaximumResponse
+	11, // 13: calculator.v2.CalculatorService.GetAggregation:output_type -> calculator.v2.Aggregation
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_calculator_calculatorv2pb_calculator_proto_init() }
+func file_calculator_calculatorv2pb_calculator_proto_init() {
+	if File_calculator_calculatorv2pb_calculator_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_calculator_calculatorv2pb_calculator_proto_rawDesc), len(file_calculator_calculatorv2pb_calculator_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_calculator_calculatorv2pb_calculator_proto_goTypes,
+		DependencyIndexes: file_calculator_calculatorv2pb_calculator_proto_depIdxs,
+		EnumInfos:         file_calculator_calculatorv2pb_calculator_proto_enumTypes,
+		MessageInfos:      file_calculator_calculatorv2pb_calculator_proto_msgTypes,
+	}.Build()
+	File_calculator_calculatorv2pb_calculator_proto = out.File
+	file_calculator_calculatorv2pb_calculator_proto_goTypes = nil
+	file_calculator_calculatorv2pb_calculator_proto_depIdxs = nil
+}
@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: calculator/calculatorv2pb/calculator.proto
+
+package calculatorv2pbconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	calculatorv2pb "github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// CalculatorServiceName is the fully-qualified name of the CalculatorService service.
+	CalculatorServiceName = "calculator.v2.CalculatorService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// CalculatorServiceCalculateProcedure is the fully-qualified name of the CalculatorService's
+	// Calculate RPC.
+	CalculatorServiceCalculateProcedure = "/calculator.v2.CalculatorService/Calculate"
+	// CalculatorServicePrimeNumberDecompositionProcedure is the fully-qualified name of the
+	// CalculatorService's PrimeNumberDecomposition RPC.
+	CalculatorServicePrimeNumberDecompositionProcedure = "/calculator.v2.CalculatorService/PrimeNumberDecomposition"
+	// CalculatorServiceComputeAverageProcedure is the fully-qualified name of the CalculatorService's
+	// ComputeAverage RPC.
+	CalculatorServiceComputeAverageProcedure = "/calculator.v2.CalculatorService/ComputeAverage"
+	// CalculatorServiceFindMaximumProcedure is the fully-qualified name of the CalculatorService's
+	// FindMaximum RPC.
+	CalculatorServiceFindMaximumProcedure = "/calculator.v2.CalculatorService/FindMaximum"
+	// CalculatorServiceGetAggregationProcedure is the fully-qualified name of the CalculatorService's
+	// GetAggregation RPC.
+	CalculatorServiceGetAggregationProcedure = "/calculator.v2.CalculatorService/GetAggregation"
+)
+
+// CalculatorServiceClient is a client for the calculator.v2.CalculatorService service.
+type CalculatorServiceClient interface {
+	// Unary gRPC
+	Calculate(context.Context, *connect.Request[calculatorv2pb.OperationRequest]) (*connect.Response[calculatorv2pb.OperationResponse], error)
+	// Server Streaming gRPC
+	PrimeNumberDecomposition(context.Context, *connect.Request[calculatorv2pb.PrimeNumberDecompositionRequest]) (*connect.ServerStreamForClient[calculatorv2pb.PrimeNumberDecompositionResponse], error)
+	// Client Streaming gRPC
+	ComputeAverage(context.Context) *connect.ClientStreamForClient[calculatorv2pb.ComputeAverageRequest, calculatorv2pb.ComputeAverageResponse]
+	// Bidirectional streaming gRPC
+	FindMaximum(context.Context) *connect.BidiStreamForClient[calculatorv2pb.FindMaximumRequest, calculatorv2pb.FindMaximumResponse]
+	// Returns the last checkpoint of an aggregation
+	GetAggregation(context.Context, *connect.Request[calculatorv2pb.GetAggregationRequest]) (*connect.Response[calculatorv2pb.Aggregation], error)
+}
+
+// NewCalculatorServiceClient constructs a client for the calculator.v2.CalculatorService service.
+// By default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped
+// responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewCalculatorServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) CalculatorServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	calculatorServiceMethods := calculatorv2pb.File_calculator_calculatorv2pb_calculator_proto.Services().ByName("CalculatorService").Methods()
+	return &calculatorServiceClient{
+		calculate: connect.NewClient[calculatorv2pb.OperationRequest, calculatorv2pb.OperationResponse](
+			httpClient,
+			baseURL+CalculatorServiceCalculateProcedure,
+			connect.WithSchema(calculatorServiceMethods.ByName("Calculate")),
+			connect.WithClientOptions(opts...),
+		),
+		primeNumberDecomposition: connect.NewClient[calculatorv2pb.PrimeNumberDecompositionRequest, calculatorv2pb.PrimeNumberDecompositionResponse](
+			httpClient,
+			baseURL+CalculatorServicePrimeNumberDecompositionProcedure,
+			connect.WithSchema(calculatorServiceMethods.ByName("PrimeNumberDecomposition")),
+			connect.WithClientOptions(opts...),
+		),
+		computeAverage: connect.NewClient[calculatorv2pb.ComputeAverageRequest, calculatorv2pb.ComputeAverageResponse](
+			httpClient,
+			baseURL+CalculatorServiceComputeAverageProcedure,
+			connect.WithSchema(calculatorServiceMethods.ByName("ComputeAverage")),
+			connect.WithClientOptions(opts...),
+		),
+		findMaximum: connect.NewClient[calculatorv2pb.FindMaximumRequest, calculatorv2pb.FindMaximumResponse](
+			httpClient,
+			baseURL+CalculatorServiceFindMaximumProcedure,
+			connect.WithSchema(calculatorServiceMethods.ByName("FindMaximum")),
+			connect.WithClientOptions(opts...),
+		),
+		getAggregation: connect.NewClient[calculatorv2pb.GetAggregationRequest, calculatorv2pb.Aggregation](
+			httpClient,
+			baseURL+CalculatorServiceGetAggregationProcedure,
+			connect.WithSchema(calculatorServiceMethods.ByName("GetAggregation")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// calculatorServiceClient implements CalculatorServiceClient.
+type calculatorServiceClient struct {
+	calculate                *connect.Client[calculatorv2pb.OperationRequest, calculatorv2pb.OperationResponse]
+	primeNumberDecomposition *connect.Client[calculatorv2pb.PrimeNumberDecompositionRequest, calculatorv2pb.PrimeNumberDecompositionResponse]
+	computeAverage           *connect.Client[calculatorv2pb.ComputeAverageRequest, calculatorv2pb.ComputeAverageResponse]
+	findMaximum              *connect.Client[calculatorv2pb.FindMaximumRequest, calculatorv2pb.FindMaximumResponse]
+	getAggregation           *connect.Client[calculatorv2pb.GetAggregationRequest, calculatorv2pb.Aggregation]
+}
+
+// Calculate calls calculator.v2.CalculatorService.Calculate.
+func (c *calculatorServiceClient) Calculate(ctx context.Context, req *connect.Request[calculatorv2pb.OperationRequest]) (*connect.Response[calculatorv2pb.OperationResponse], error) {
+	return c.calculate.CallUnary(ctx, req)
+}
+
+// PrimeNumberDecomposition calls calculator.v2.CalculatorService.PrimeNumberDecomposition.
+func (c *calculatorServiceClient) PrimeNumberDecomposition(ctx context.Context, req *connect.Request[calculatorv2pb.PrimeNumberDecompositionRequest]) (*connect.ServerStreamForClient[calculatorv2pb.PrimeNumberDecompositionResponse], error) {
+	return c.primeNumberDecomposition.CallServerStream(ctx, req)
+}
+
+// ComputeAverage calls calculator.v2.CalculatorService.ComputeAverage.
+func (c *calculatorServiceClient) ComputeAverage(ctx context.Context) *connect.ClientStreamForClient[calculatorv2pb.ComputeAverageRequest, calculatorv2pb.ComputeAverageResponse] {
+	return c.computeAverage.CallClientStream(ctx)
+}
+
+// FindMaximum calls calculator.v2.CalculatorService.FindMaximum.
+func (c *calculatorServiceClient) FindMaximum(ctx context.Context) *connect.BidiStreamForClient[calculatorv2pb.FindMaximumRequest, calculatorv2pb.FindMaximumResponse] {
+	return c.findMaximum.CallBidiStream(ctx)
+}
+
+// GetAggregation calls calculator.v2.CalculatorService.GetAggregation.
+func (c *calculatorServiceClient) GetAggregation(ctx context.Context, req *connect.Request[calculatorv2pb.GetAggregationRequest]) (*connect.Response[calculatorv2pb.Aggregation], error) {
+	return c.getAggregation.CallUnary(ctx, req)
+}
+
+// CalculatorServiceHandler is an implementation of the calculator.v2.CalculatorService service.
+type CalculatorServiceHandler interface {
+	// Unary gRPC
+	Calculate(context.Context, *connect.Request[calculatorv2pb.OperationRequest]) (*connect.Response[calculatorv2pb.OperationResponse], error)
+	// Server Streaming gRPC
+	PrimeNumberDecomposition(context.Context, *connect.Request[calculatorv2pb.PrimeNumberDecompositionRequest], *connect.ServerStream[calculatorv2pb.PrimeNumberDecompositionResponse]) error
+	// Client Streaming gRPC
+	ComputeAverage(context.Context, *connect.ClientStream[calculatorv2pb.ComputeAverageRequest]) (*connect.Response[calculatorv2pb.ComputeAverageResponse], error)
+	// Bidirectional streaming gRPC
+	FindMaximum(context.Context, *connect.BidiStream[calculatorv2pb.FindMaximumRequest, calculatorv2pb.FindMaximumResponse]) error
+	// Returns the last checkpoint of an aggregation
+	GetAggregation(context.Context, *connect.Request[calculatorv2pb.GetAggregationRequest]) (*connect.Response[calculatorv2pb.Aggregation], error)
+}
+
+// NewCalculatorServiceHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewCalculatorServiceHandler(svc CalculatorServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	calculatorServiceMethods := calculatorv2pb.File_calculator_calculatorv2pb_calculator_proto.Services().ByName("CalculatorService").Methods()
+	calculatorServiceCalculateHandler := connect.NewUnaryHandler(
+		CalculatorServiceCalculateProcedure,
+		svc.Calculate,
+		connect.WithSchema(calculatorServiceMethods.ByName("Calculate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	calculatorServicePrimeNumberDecompositionHandler := connect.NewServerStreamHandler(
+		CalculatorServicePrimeNumberDecompositionProcedure,
+		svc.PrimeNumberDecomposition,
+		connect.WithSchema(calculatorServiceMethods.ByName("PrimeNumberDecomposition")),
+		connect.WithHandlerOptions(opts...),
+	)
+	calculatorServiceComputeAverageHandler := connect.NewClientStreamHandler(
+		CalculatorServiceComputeAverageProcedure,
+		svc.ComputeAverage,
+		connect.WithSchema(calculatorServiceMethods.ByName("ComputeAverage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	calculatorServiceFindMaximumHandler := connect.NewBidiStreamHandler(
+		CalculatorServiceFindMaximumProcedure,
+		svc.FindMaximum,
+		connect.WithSchema(calculatorServiceMethods.ByName("FindMaximum")),
+		connect.WithHandlerOptions(opts...),
+	)
+	calculatorServiceGetAggregationHandler := connect.NewUnaryHandler(
+		CalculatorServiceGetAggregationProcedure,
+		svc.GetAggregation,
+		connect.WithSchema(calculatorServiceMethods.ByName("GetAggregation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/calculator.v2.CalculatorService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case CalculatorServiceCalculateProcedure:
+			calculatorServiceCalculateHandler.ServeHTTP(w, r)
+		case CalculatorServicePrimeNumberDecompositionProcedure:
+			calculatorServicePrimeNumberDecompositionHandler.ServeHTTP(w, r)
+		case CalculatorServiceComputeAverageProcedure:
+			calculatorServiceComputeAverageHandler.ServeHTTP(w, r)
+		case CalculatorServiceFindMaximumProcedure:
+			calculatorServiceFindMaximumHandler.ServeHTTP(w, r)
+		case CalculatorServiceGetAggregationProcedure:
+			calculatorServiceGetAggregationHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedCalculatorServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedCalculatorServiceHandler struct{}
+
+func (UnimplementedCalculatorServiceHandler) Calculate(context.Context, *connect.Request[calculatorv2pb.OperationRequest]) (*connect.Response[calculatorv2pb.OperationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("calculator.v2.CalculatorService.Calculate is not implemented"))
+}
+
+func (UnimplementedCalculatorServiceHandler) PrimeNumberDecomposition(context.Context, *connect.Request[calculatorv2pb.PrimeNumberDecompositionRequest], *connect.ServerStream[calculatorv2pb.PrimeNumberDecompositionResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("calculator.v2.CalculatorService.PrimeNumberDecomposition is not implemented"))
+}
+
+func (UnimplementedCalculatorServiceHandler) ComputeAverage(context.Context, *connect.ClientStream[calculatorv2pb.ComputeAverageRequest]) (*connect.Response[calculatorv2pb.ComputeAverageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("calculator.v2.CalculatorService.ComputeAverage is not implemented"))
+}
+
+func (UnimplementedCalculatorServiceHandler) FindMaximum(context.Context, *connect.BidiStream[calculatorv2pb.FindMaximumRequest, calculatorv2pb.FindMaximumResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("calculator.v2.CalculatorService.FindMaximum is not implemented"))
+}
+
+func (UnimplementedCalculatorServiceHandler) GetAggregation(context.Context, *connect.Request[calculatorv2pb.GetAggregationRequest]) (*connect.Response[calculatorv2pb.Aggregation], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("calculator.v2.CalculatorService.GetAggregation is not implemented"))
+}
@@ -1,2 +0,0 @@
-#!/bin/bash
-protoc calculator/calculatorpb/calculator.proto --go_out=plugins=grpc:.
\ No newline at end of file
@@ -0,0 +1,8 @@
+#!/bin/bash
+# Lints the protos and checks they stay compatible with the ones on the main
+# branch. Set AGAINST to compare with another buf input, e.g. a tag:
+#   AGAINST='.git#tag=v1.0.0' ./check-protos.sh
+set -e
+cd "$(dirname "$0")"
+buf lint
+buf breaking --against "${AGAINST:-.git#branch=main}"
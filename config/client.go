@@ -0,0 +1,190 @@
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/lb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/health" // enables client side health checking
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+// Client configures the transport of a gRPC client connection.
+type Client struct {
+	// KeepaliveTime is how long the connection can be idle before the
+	// client pings the server. It must not be shorter than the server's
+	// MinPingInterval or the server will close the connection.
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	// PermitWithoutStream sends pings even when no stream is active.
+	PermitWithoutStream bool
+
+	// MaxRecvMsgSize and MaxSendMsgSize cap the size in bytes of the
+	// messages the client receives and sends.
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+
+	// Compression names the compressor used for requests, e.g. "gzip".
+	// Empty means no compression.
+	Compression string
+
+	// ServiceConfig is the path of a JSON service config with the
+	// timeouts, retry and hedging policies of each method.
+	ServiceConfig string
+
+	// Target is either a comma separated list of server addresses or a
+	// gRPC target such as "dns:///calculator.internal:50051".
+	Target string
+	// LBPolicy is the load balancing policy spreading calls over the
+	// addresses of Target: "round_robin", "least_request" or "pick_first".
+	// A loadBalancingConfig in the service config takes precedence.
+	LBPolicy string
+	// HealthCheck makes the client watch the health service of every
+	// server and skip the ones that are not serving.
+	HealthCheck bool
+}
+
+// DefaultClient returns the settings used by both clients unless overridden
+// by flags. They match the enforcement policy of DefaultServer.
+func DefaultClient() Client {
+	return Client{
+		KeepaliveTime:       time.Minute,
+		KeepaliveTimeout:    20 * time.Second,
+		PermitWithoutStream: true,
+		MaxRecvMsgSize:      4 << 20,
+		MaxSendMsgSize:      4 << 20,
+		Target:              "localhost:50051",
+		LBPolicy:            "round_robin",
+		HealthCheck:         true,
+	}
+}
+
+// RegisterFlags defines flags for every setting on fs, using the current
+// values as defaults.
+func (c *Client) RegisterFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&c.KeepaliveTime, "keepalive-time", c.KeepaliveTime, "idle time before the client pings the server")
+	fs.DurationVar(&c.KeepaliveTimeout, "keepalive-timeout", c.KeepaliveTimeout, "time to wait for a ping acknowledgement before closing the connection")
+	fs.BoolVar(&c.PermitWithoutStream, "keepalive-permit-without-stream", c.PermitWithoutStream, "ping the server even when no stream is active")
+	fs.IntVar(&c.MaxRecvMsgSize, "max-recv-msg-size", c.MaxRecvMsgSize, "largest message accepted, in bytes")
+	fs.IntVar(&c.MaxSendMsgSize, "max-send-msg-size", c.MaxSendMsgSize, "largest message sent, in bytes")
+	fs.StringVar(&c.Compression, "compression", c.Compression, "compressor used for requests (gzip or empty)")
+	fs.StringVar(&c.ServiceConfig, "service-config", c.ServiceConfig, "JSON service config with per method timeouts, retry and hedging policies")
+	fs.StringVar(&c.Target, "target", c.Target, "comma separated server addresses, or a gRPC target such as dns:///host:port")
+	fs.StringVar(&c.LBPolicy, "lb-policy", c.LBPolicy, "load balancing policy: round_robin, least_request or pick_first")
+	fs.BoolVar(&c.HealthCheck, "health-check", c.HealthCheck, "skip servers whose health service reports them as not serving")
+}
+
+// Validate reports settings that cannot be applied.
+func (c *Client) Validate() error {
+	if c.Compression != "" && encoding.GetCompressor(c.Compression) == nil {
+		return fmt.Errorf("unknown compressor %q", c.Compression)
+	}
+	if c.KeepaliveTime > 0 && c.KeepaliveTime < 10*time.Second {
+		return fmt.Errorf("keepalive time %v is shorter than the 10s minimum", c.KeepaliveTime)
+	}
+	switch c.LBPolicy {
+	case "", "pick_first", "round_robin", lb.LeastRequest:
+	default:
+		return fmt.Errorf("unknown load balancing policy %q", c.LBPolicy)
+	}
+	if strings.TrimSpace(c.Target) == "" {
+		return fmt.Errorf("no target given")
+	}
+	return nil
+}
+
+// DialOptions returns the grpc.DialOptions applying the settings. It fails
+// when the service config cannot be loaded.
+func (c *Client) DialOptions() ([]grpc.DialOption, error) {
+	callOpts := []grpc.CallOption{
+		grpc.MaxCallRecvMsgSize(c.MaxRecvMsgSize),
+		grpc.MaxCallSendMsgSize(c.MaxSendMsgSize),
+	}
+	if c.Compression != "" {
+		callOpts = append(callOpts, grpc.UseCompressor(c.Compression))
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                c.KeepaliveTime,
+			Timeout:             c.KeepaliveTimeout,
+			PermitWithoutStream: c.PermitWithoutStream,
+		}),
+		grpc.WithDefaultCallOptions(callOpts...),
+	}
+
+	raw := []byte("{}")
+	if c.ServiceConfig != "" {
+		data, err := os.ReadFile(c.ServiceConfig)
+		if err != nil {
+			return nil, err
+		}
+		raw = data
+	}
+	raw, err := c.withBalancing(raw)
+	if err != nil {
+		return nil, fmt.Errorf("service config: %v", err)
+	}
+	sc, err := ParseServiceConfig(raw)
+	if err != nil {
+		return nil, fmt.Errorf("service config: %v", err)
+	}
+	opts = append(opts, sc.DialOptions()...)
+	return opts, nil
+}
+
+// withBalancing adds the load balancing policy and health checking settings
+// to the service config, unless it already sets them.
+func (c *Client) withBalancing(raw []byte) ([]byte, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		doc = make(map[string]interface{})
+	}
+	if _, ok := doc["loadBalancingConfig"]; !ok && c.LBPolicy != "" {
+		doc["loadBalancingConfig"] = []interface{}{
+			map[string]interface{}{c.LBPolicy: map[string]interface{}{}},
+		}
+	}
+	if _, ok := doc["healthCheckConfig"]; !ok && c.HealthCheck {
+		// The empty service name asks for the health of the whole server.
+		doc["healthCheckConfig"] = map[string]interface{}{"serviceName": ""}
+	}
+	return json.Marshal(doc)
+}
+
+// Dial connects to Target with the settings and the extra options given.
+// A list of addresses is resolved statically; any other target goes
+// through the gRPC resolvers, so "dns:///host:port" follows DNS updates.
+func (c *Client) Dial(extra ...grpc.DialOption) (*grpc.ClientConn, error) {
+	opts, err := c.DialOptions()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, extra...)
+
+	target := strings.TrimSpace(c.Target)
+	if strings.Contains(target, ",") {
+		var state resolver.State
+		for _, addr := range strings.Split(target, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				state.Addresses = append(state.Addresses, resolver.Address{Addr: addr})
+			}
+		}
+		r := manual.NewBuilderWithScheme("static")
+		r.InitialState(state)
+		opts = append(opts, grpc.WithResolvers(r))
+		target = r.Scheme() + ":///" + target
+	}
+	return grpc.Dial(target, opts...)
+}
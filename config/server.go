@@ -0,0 +1,182 @@
+// Package config holds the transport settings shared by the greet and
+// calculator servers and clients: keepalives, connection lifetimes, message
+// sizes and compression.
+package config
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/gzip" // registers the gzip compressor
+	"google.golang.org/grpc/keepalive"
+)
+
+// Server configures the transport of a gRPC server.
+type Server struct {
+	// Address is the TCP address the server listens on.
+	Address string
+	// DrainDelay is how long the server keeps answering calls after being
+	// asked to stop, while its health service already reports it as not
+	// serving, so balancing clients can move away first.
+	DrainDelay time.Duration
+
+	// KeepaliveTime is how long a connection can be idle before the server
+	// pings the client, and KeepaliveTimeout how long it then waits for the
+	// ping to be acknowledged before closing the connection.
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	// MinPingInterval is the shortest interval between client pings the
+	// server tolerates; clients pinging faster are disconnected.
+	MinPingInterval time.Duration
+	// PermitWithoutStream lets clients ping connections with no active
+	// streams.
+	PermitWithoutStream bool
+
+	// MaxConnectionIdle closes connections with no active streams for that
+	// long. MaxConnectionAge closes connections once they get that old,
+	// giving their streams MaxConnectionAgeGrace to finish, so long lived
+	// bidi streams cannot linger forever. Zero means no limit.
+	MaxConnectionIdle     time.Duration
+	MaxConnectionAge      time.Duration
+	MaxConnectionAgeGrace time.Duration
+
+	// MaxConcurrentStreams caps the streams open on each connection.
+	MaxConcurrentStreams uint32
+	// MaxRecvMsgSize and MaxSendMsgSize cap the size in bytes of the
+	// messages the server receives and sends.
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+
+	// Compression names the compressor used for responses, e.g. "gzip",
+	// when the client supports it. Empty means no compression.
+	Compression string
+}
+
+// DefaultServer returns the settings used by both servers unless
+// overridden by flags.
+func DefaultServer() Server {
+	return Server{
+		Address:               "0.0.0.0:50051",
+		DrainDelay:            5 * time.Second,
+		KeepaliveTime:         2 * time.Minute,
+		KeepaliveTimeout:      20 * time.Second,
+		MinPingInterval:       30 * time.Second,
+		PermitWithoutStream:   true,
+		MaxConnectionIdle:     15 * time.Minute,
+		MaxConnectionAge:      30 * time.Minute,
+		MaxConnectionAgeGrace: time.Minute,
+		MaxConcurrentStreams:  100,
+		MaxRecvMsgSize:        4 << 20,
+		MaxSendMsgSize:        4 << 20,
+	}
+}
+
+// RegisterFlags defines flags for every setting on fs, using the current
+// values as defaults.
+func (c *Server) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.Address, "address", c.Address, "address to listen on")
+	fs.DurationVar(&c.DrainDelay, "drain-delay", c.DrainDelay, "time spent reporting NOT_SERVING before stopping")
+	fs.DurationVar(&c.KeepaliveTime, "keepalive-time", c.KeepaliveTime, "idle time before the server pings a client")
+	fs.DurationVar(&c.KeepaliveTimeout, "keepalive-timeout", c.KeepaliveTimeout, "time to wait for a ping acknowledgement before closing the connection")
+	fs.DurationVar(&c.MinPingInterval, "keepalive-min-ping-interval", c.MinPingInterval, "shortest interval between client pings tolerated")
+	fs.BoolVar(&c.PermitWithoutStream, "keepalive-permit-without-stream", c.PermitWithoutStream, "allow client pings on connections without streams")
+	fs.DurationVar(&c.MaxConnectionIdle, "max-connection-idle", c.MaxConnectionIdle, "close connections without streams after this long (0 disables)")
+	fs.DurationVar(&c.MaxConnectionAge, "max-connection-age", c.MaxConnectionAge, "close connections after this long (0 disables)")
+	fs.DurationVar(&c.MaxConnectionAgeGrace, "max-connection-age-grace", c.MaxConnectionAgeGrace, "time given to streams to finish once a connection is too old")
+	fs.Var(uint32Value{&c.MaxConcurrentStreams}, "max-concurrent-streams", "streams allowed per connection")
+	fs.IntVar(&c.MaxRecvMsgSize, "max-recv-msg-size", c.MaxRecvMsgSize, "largest message accepted, in bytes")
+	fs.IntVar(&c.MaxSendMsgSize, "max-send-msg-size", c.MaxSendMsgSize, "largest message sent, in bytes")
+	fs.StringVar(&c.Compression, "compression", c.Compression, "compressor used for responses when the client supports it (gzip or empty)")
+}
+
+// Validate reports settings that cannot be applied.
+func (c *Server) Validate() error {
+	if c.Compression != "" && encoding.GetCompressor(c.Compression) == nil {
+		return fmt.Errorf("unknown compressor %q", c.Compression)
+	}
+	if c.KeepaliveTime > 0 && c.KeepaliveTime < time.Second {
+		return fmt.Errorf("keepalive time %v is shorter than a second", c.KeepaliveTime)
+	}
+	return nil
+}
+
+// ServerOptions returns the grpc.ServerOptions applying the settings.
+func (c *Server) ServerOptions() []grpc.ServerOption {
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:                  c.KeepaliveTime,
+			Timeout:               c.KeepaliveTimeout,
+			MaxConnectionIdle:     infiniteIfZero(c.MaxConnectionIdle),
+			MaxConnectionAge:      infiniteIfZero(c.MaxConnectionAge),
+			MaxConnectionAgeGrace: infiniteIfZero(c.MaxConnectionAgeGrace),
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             c.MinPingInterval,
+			PermitWithoutStream: c.PermitWithoutStream,
+		}),
+		grpc.MaxConcurrentStreams(c.MaxConcurrentStreams),
+		grpc.MaxRecvMsgSize(c.MaxRecvMsgSize),
+		grpc.MaxSendMsgSize(c.MaxSendMsgSize),
+	}
+
+	if name := c.Compression; name != "" {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				setSendCompressor(ctx, name)
+				return handler(ctx, req)
+			}),
+			grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				setSendCompressor(ss.Context(), name)
+				return handler(srv, ss)
+			}),
+		)
+	}
+	return opts
+}
+
+// setSendCompressor compresses responses with name if the client accepts it.
+func setSendCompressor(ctx context.Context, name string) {
+	supported, err := grpc.ClientSupportedCompressors(ctx)
+	if err != nil {
+		return
+	}
+	for _, s := range supported {
+		if s == name {
+			grpc.SetSendCompressor(ctx, name)
+			return
+		}
+	}
+}
+
+// infiniteIfZero maps the "disabled" zero value to the infinite duration
+// expected by the keepalive package.
+func infiniteIfZero(d time.Duration) time.Duration {
+	if d == 0 {
+		return time.Duration(1<<63 - 1)
+	}
+	return d
+}
+
+// uint32Value implements flag.Value for uint32 settings.
+type uint32Value struct{ p *uint32 }
+
+func (v uint32Value) String() string {
+	if v.p == nil {
+		return "0"
+	}
+	return strconv.FormatUint(uint64(*v.p), 10)
+}
+
+func (v uint32Value) Set(s string) error {
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return err
+	}
+	*v.p = uint32(n)
+	return nil
+}
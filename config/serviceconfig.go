@@ -0,0 +1,268 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ServiceConfig is a gRPC service config (see
+// https://github.com/grpc/grpc/blob/master/doc/service_config.md) read from
+// a JSON file. Timeouts and retry policies are applied by grpc itself;
+// hedging policies, which grpc-go ignores, are applied by an interceptor
+// for unary methods.
+type ServiceConfig struct {
+	raw     string
+	hedging map[string]hedgingPolicy
+}
+
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method"`
+}
+
+// key returns the prefix of the full method names matched by n: a full
+// method, every method of a service or, when empty, every method.
+func (n methodName) key() string {
+	if n.Service == "" {
+		return ""
+	}
+	return "/" + n.Service + "/" + n.Method
+}
+
+type jsonHedgingPolicy struct {
+	MaxAttempts         int           `json:"maxAttempts"`
+	HedgingDelay        string        `json:"hedgingDelay"`
+	NonFatalStatusCodes []interface{} `json:"nonFatalStatusCodes"`
+}
+
+type jsonRetryPolicy struct {
+	MaxAttempts          int           `json:"maxAttempts"`
+	RetryableStatusCodes []interface{} `json:"retryableStatusCodes"`
+}
+
+type hedgingPolicy struct {
+	maxAttempts int
+	delay       time.Duration
+	nonFatal    map[codes.Code]bool
+}
+
+// LoadServiceConfig reads the service config at path.
+func LoadServiceConfig(path string) (*ServiceConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := ParseServiceConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing service config %s: %v", path, err)
+	}
+	return sc, nil
+}
+
+// ParseServiceConfig parses a JSON service config.
+func ParseServiceConfig(data []byte) (*ServiceConfig, error) {
+	var doc struct {
+		MethodConfig []struct {
+			Name          []methodName       `json:"name"`
+			Timeout       string             `json:"timeout"`
+			RetryPolicy   *jsonRetryPolicy   `json:"retryPolicy"`
+			HedgingPolicy *jsonHedgingPolicy `json:"hedgingPolicy"`
+		} `json:"methodConfig"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	sc := &ServiceConfig{raw: string(data), hedging: make(map[string]hedgingPolicy)}
+	for i, mc := range doc.MethodConfig {
+		if len(mc.Name) == 0 {
+			return nil, fmt.Errorf("methodConfig %d has no name", i)
+		}
+		if mc.Timeout != "" {
+			if _, err := parseDuration(mc.Timeout); err != nil {
+				return nil, fmt.Errorf("methodConfig %d: timeout: %v", i, err)
+			}
+		}
+		if mc.RetryPolicy != nil && mc.HedgingPolicy != nil {
+			return nil, fmt.Errorf("methodConfig %d sets both retryPolicy and hedgingPolicy", i)
+		}
+		if rp := mc.RetryPolicy; rp != nil {
+			if rp.MaxAttempts < 2 {
+				return nil, fmt.Errorf("methodConfig %d: retryPolicy needs maxAttempts of at least 2", i)
+			}
+			if _, err := parseCodes(rp.RetryableStatusCodes); err != nil {
+				return nil, fmt.Errorf("methodConfig %d: retryableStatusCodes: %v", i, err)
+			}
+		}
+		if hp := mc.HedgingPolicy; hp != nil {
+			policy, err := hp.parse()
+			if err != nil {
+				return nil, fmt.Errorf("methodConfig %d: hedgingPolicy: %v", i, err)
+			}
+			for _, n := range mc.Name {
+				sc.hedging[n.key()] = policy
+			}
+		}
+	}
+	return sc, nil
+}
+
+func (p *jsonHedgingPolicy) parse() (hedgingPolicy, error) {
+	if p.MaxAttempts < 2 {
+		return hedgingPolicy{}, fmt.Errorf("maxAttempts must be at least 2")
+	}
+	policy := hedgingPolicy{maxAttempts: p.MaxAttempts}
+	// Like grpc does for retries, never send more than 5 attempts.
+	if policy.maxAttempts > 5 {
+		policy.maxAttempts = 5
+	}
+	if p.HedgingDelay != "" {
+		d, err := parseDuration(p.HedgingDelay)
+		if err != nil {
+			return hedgingPolicy{}, fmt.Errorf("hedgingDelay: %v", err)
+		}
+		policy.delay = d
+	}
+	nonFatal, err := parseCodes(p.NonFatalStatusCodes)
+	if err != nil {
+		return hedgingPolicy{}, fmt.Errorf("nonFatalStatusCodes: %v", err)
+	}
+	// A server rejecting calls because it is overloaded or rate limits the
+	// client would get even more of them, right away.
+	if nonFatal[codes.ResourceExhausted] {
+		return hedgingPolicy{}, fmt.Errorf("nonFatalStatusCodes: RESOURCE_EXHAUSTED must be fatal, hedging would add to the load")
+	}
+	policy.nonFatal = nonFatal
+	return policy, nil
+}
+
+// DialOptions returns the options installing the service config and the
+// hedging interceptor.
+func (sc *ServiceConfig) DialOptions() []grpc.DialOption {
+	opts := []grpc.DialOption{grpc.WithDefaultServiceConfig(sc.raw)}
+	if len(sc.hedging) > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(sc.hedgingInterceptor))
+	}
+	return opts
+}
+
+// hedgingPolicy returns the policy of the most specific method config
+// matching method.
+func (sc *ServiceConfig) hedgingPolicy(method string) (hedgingPolicy, bool) {
+	if p, ok := sc.hedging[method]; ok {
+		return p, true
+	}
+	if i := strings.LastIndex(method, "/"); i > 0 {
+		if p, ok := sc.hedging[method[:i+1]]; ok {
+			return p, true
+		}
+	}
+	p, ok := sc.hedging[""]
+	return p, ok
+}
+
+type attemptResult struct {
+	reply proto.Message
+	err   error
+}
+
+// hedgingInterceptor sends up to maxAttempts copies of a unary call, each
+// one hedgingDelay after the previous, or right away when the previous one
+// failed with a non fatal code. The first successful response wins and the
+// remaining attempts are cancelled.
+func (sc *ServiceConfig) hedgingInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	policy, ok := sc.hedgingPolicy(method)
+	out, isProto := reply.(proto.Message)
+	if !ok || !isProto {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan attemptResult, policy.maxAttempts)
+	attempt := func() {
+		r := out.ProtoReflect().New().Interface()
+		err := invoker(ctx, method, req, r, cc, opts...)
+		results <- attemptResult{reply: r, err: err}
+	}
+
+	started, finished := 1, 0
+	go attempt()
+	timer := time.NewTimer(policy.delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if started < policy.maxAttempts {
+				started++
+				go attempt()
+				timer.Reset(policy.delay)
+			}
+		case r := <-results:
+			finished++
+			if r.err == nil {
+				proto.Reset(out)
+				proto.Merge(out, r.reply)
+				return nil
+			}
+			if !policy.nonFatal[status.Code(r.err)] {
+				return r.err
+			}
+			if started < policy.maxAttempts {
+				started++
+				go attempt()
+				timer.Reset(policy.delay)
+			} else if finished == started {
+				return r.err
+			}
+		}
+	}
+}
+
+// parseDuration parses the "1.5s" form used by service configs.
+func parseDuration(s string) (time.Duration, error) {
+	if !strings.HasSuffix(s, "s") {
+		return 0, fmt.Errorf("duration %q must end in s", s)
+	}
+	seconds, err := strconv.ParseFloat(strings.TrimSuffix(s, "s"), 64)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("bad duration %q", s)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// parseCodes parses status codes given either by name ("UNAVAILABLE") or
+// by number.
+func parseCodes(list []interface{}) (map[codes.Code]bool, error) {
+	set := make(map[codes.Code]bool)
+	for _, v := range list {
+		switch v := v.(type) {
+		case float64:
+			if v < 0 || v > 16 || v != float64(int(v)) {
+				return nil, fmt.Errorf("bad status code %v", v)
+			}
+			set[codes.Code(v)] = true
+		case string:
+			var c codes.Code
+			if err := c.UnmarshalJSON([]byte(strconv.Quote(v))); err != nil {
+				return nil, err
+			}
+			set[c] = true
+		default:
+			return nil, fmt.Errorf("bad status code %v", v)
+		}
+	}
+	return set, nil
+}
@@ -0,0 +1,243 @@
+package config
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fault describes how the faulty server answers one call.
+type fault struct {
+	code  codes.Code
+	delay time.Duration
+}
+
+// faultyGreeter answers its n-th Greet call according to faults[n], and
+// successfully once the faults run out.
+type faultyGreeter struct {
+	greetpb.UnimplementedGreetServiceServer
+
+	mu     sync.Mutex
+	faults []fault
+	calls  int
+}
+
+func (g *faultyGreeter) Greet(ctx context.Context, req *greetpb.GreetRequest) (*greetpb.GreetResponse, error) {
+	g.mu.Lock()
+	var f fault
+	if g.calls < len(g.faults) {
+		f = g.faults[g.calls]
+	}
+	g.calls++
+	g.mu.Unlock()
+
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if f.code != codes.OK {
+		return nil, status.Error(f.code, "injected fault")
+	}
+	return &greetpb.GreetResponse{Result: "Hello, " + req.GetGreeting().GetFirstName()}, nil
+}
+
+func (g *faultyGreeter) Calls() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.calls
+}
+
+// dial starts g on an in-memory listener and returns a client using the
+// given service config.
+func dial(t *testing.T, g *faultyGreeter, serviceConfig string) greetpb.GreetServiceClient {
+	t.Helper()
+
+	sc, err := ParseServiceConfig([]byte(serviceConfig))
+	if err != nil {
+		t.Fatalf("ParseServiceConfig: %v", err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	greetpb.RegisterGreetServiceServer(s, g)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	opts := append([]grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+	}, sc.DialOptions()...)
+	conn, err := grpc.Dial("bufnet", opts...)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return greetpb.NewGreetServiceClient(conn)
+}
+
+func greet(c greetpb.GreetServiceClient) (*greetpb.GreetResponse, error) {
+	return c.Greet(context.Background(), &greetpb.GreetRequest{
+		Greeting: &greetpb.Greeting{FirstName: "Alan"},
+	})
+}
+
+const retryConfig = `{
+  "methodConfig": [{
+    "name": [{"service": "greet.v1.GreetService", "method": "Greet"}],
+    "retryPolicy": {
+      "maxAttempts": 3,
+      "initialBackoff": "0.01s",
+      "maxBackoff": "0.05s",
+      "backoffMultiplier": 2,
+      "retryableStatusCodes": ["UNAVAILABLE"]
+    }
+  }]
+}`
+
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		faults    []fault
+		wantCode  codes.Code
+		wantCalls int
+	}{
+		{
+			name:      "succeeds after transient failures",
+			faults:    []fault{{code: codes.Unavailable}, {code: codes.Unavailable}},
+			wantCode:  codes.OK,
+			wantCalls: 3,
+		},
+		{
+			name:      "gives up after max attempts",
+			faults:    []fault{{code: codes.Unavailable}, {code: codes.Unavailable}, {code: codes.Unavailable}, {code: codes.Unavailable}},
+			wantCode:  codes.Unavailable,
+			wantCalls: 3,
+		},
+		{
+			name:      "does not retry other codes",
+			faults:    []fault{{code: codes.Internal}},
+			wantCode:  codes.Internal,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &faultyGreeter{faults: tt.faults}
+			c := dial(t, g, retryConfig)
+
+			_, err := greet(c)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("Greet() code = %v, want %v (err %v)", got, tt.wantCode, err)
+			}
+			if got := g.Calls(); got != tt.wantCalls {
+				t.Errorf("server got %d calls, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	g := &faultyGreeter{faults: []fault{{delay: time.Second}}}
+	c := dial(t, g, `{
+  "methodConfig": [{
+    "name": [{"service": "greet.v1.GreetService"}],
+    "timeout": "0.05s"
+  }]
+}`)
+
+	start := time.Now()
+	_, err := greet(c)
+	if got := status.Code(err); got != codes.DeadlineExceeded {
+		t.Errorf("Greet() code = %v, want DeadlineExceeded", got)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Greet() took %v, the timeout was not applied", elapsed)
+	}
+}
+
+const hedgingConfig = `{
+  "methodConfig": [{
+    "name": [{"service": "greet.v1.GreetService", "method": "Greet"}],
+    "hedgingPolicy": {
+      "maxAttempts": 3,
+      "hedgingDelay": "0.05s",
+      "nonFatalStatusCodes": ["UNAVAILABLE"]
+    }
+  }]
+}`
+
+func TestHedgingSlowAttempt(t *testing.T) {
+	g := &faultyGreeter{faults: []fault{{delay: 5 * time.Second}}}
+	c := dial(t, g, hedgingConfig)
+
+	start := time.Now()
+	res, err := greet(c)
+	if err != nil {
+		t.Fatalf("Greet() = %v", err)
+	}
+	if res.GetResult() != "Hello, Alan" {
+		t.Errorf("Greet() = %q, want %q", res.GetResult(), "Hello, Alan")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Greet() took %v, the hedged attempt did not win", elapsed)
+	}
+	if got := g.Calls(); got != 2 {
+		t.Errorf("server got %d calls, want 2", got)
+	}
+}
+
+func TestHedgingNonFatalFailure(t *testing.T) {
+	g := &faultyGreeter{faults: []fault{{code: codes.Unavailable}, {code: codes.Unavailable}}}
+	c := dial(t, g, hedgingConfig)
+
+	if _, err := greet(c); err != nil {
+		t.Fatalf("Greet() = %v", err)
+	}
+	if got := g.Calls(); got != 3 {
+		t.Errorf("server got %d calls, want 3", got)
+	}
+}
+
+func TestHedgingFatalFailure(t *testing.T) {
+	g := &faultyGreeter{faults: []fault{{code: codes.InvalidArgument}}}
+	c := dial(t, g, hedgingConfig)
+
+	_, err := greet(c)
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Errorf("Greet() code = %v, want InvalidArgument", got)
+	}
+	if got := g.Calls(); got != 1 {
+		t.Errorf("server got %d calls, want 1", got)
+	}
+}
+
+func TestParseServiceConfigErrors(t *testing.T) {
+	tests := map[string]string{
+		"not json":          `{`,
+		"missing name":      `{"methodConfig": [{"timeout": "1s"}]}`,
+		"bad timeout":       `{"methodConfig": [{"name": [{}], "timeout": "1m"}]}`,
+		"retry and hedge":   `{"methodConfig": [{"name": [{}], "retryPolicy": {"maxAttempts": 2}, "hedgingPolicy": {"maxAttempts": 2}}]}`,
+		"single attempt":    `{"methodConfig": [{"name": [{}], "retryPolicy": {"maxAttempts": 1}}]}`,
+		"unknown code":      `{"methodConfig": [{"name": [{}], "retryPolicy": {"maxAttempts": 2, "retryableStatusCodes": ["SOMETIMES"]}}]}`,
+		"bad hedge delay":   `{"methodConfig": [{"name": [{}], "hedgingPolicy": {"maxAttempts": 2, "hedgingDelay": "soon"}}]}`,
+		"code out of range": `{"methodConfig": [{"name": [{}], "hedgingPolicy": {"maxAttempts": 2, "nonFatalStatusCodes": [42]}}]}`,
+		"hedge exhaustion":  `{"methodConfig": [{"name": [{}], "hedgingPolicy": {"maxAttempts": 2, "nonFatalStatusCodes": ["RESOURCE_EXHAUSTED"]}}]}`,
+	}
+	for name, config := range tests {
+		if _, err := ParseServiceConfig([]byte(config)); err == nil {
+			t.Errorf("%s: ParseServiceConfig succeeded, want error", name)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Command gateway serves GreetService and both versions of CalculatorService
+// as HTTP/JSON APIs, following the google.api.http annotations of their protos, and
+// proxies the calls to the gRPC servers.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/calculator/calculatorpb"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/AlanKev117/go-grpc/web"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// forwardHeader passes the Authorization and Accept-Language headers on to
+// the servers, besides the headers forwarded by default.
+func forwardHeader(key string) (string, bool) {
+	switch {
+	case strings.EqualFold(key, "Authorization"):
+		return "authorization", true
+	case strings.EqualFold(key, "Accept-Language"):
+		return "accept-language", true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func serveOpenAPI(spec []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(spec)
+	}
+}
+
+func main() {
+	address := flag.String("http-address", "0.0.0.0:8080", "address the HTTP/JSON API listens on")
+	greetTarget := flag.String("greet-target", "localhost:50051", "gRPC target of the greet server")
+	calculatorTarget := flag.String("calculator-target", "localhost:50052", "gRPC target of the calculator server")
+	corsOrigins := flag.String("cors-origins", "", "comma separated origins allowed to make cross-origin calls, * for any")
+	flag.Parse()
+
+	jsonOptions := runtime.JSONPb{
+		MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+		UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}
+	gw := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(forwardHeader),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &ndjsonMarshaler{jsonOptions}),
+		runtime.WithMarshalerOption("text/event-stream", &sseMarshaler{jsonOptions}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if err := greetpb.RegisterGreetServiceHandlerFromEndpoint(ctx, gw, *greetTarget, opts); err != nil {
+		log.Fatalf("Couldn't connect to greet server: %v", err)
+	}
+	if err := calculatorpb.RegisterCalculatorServiceHandlerFromEndpoint(ctx, gw, *calculatorTarget, opts); err != nil {
+		log.Fatalf("Couldn't connect to calculator server: %v", err)
+	}
+	if err := calculatorv2pb.RegisterCalculatorServiceHandlerFromEndpoint(ctx, gw, *calculatorTarget, opts); err != nil {
+		log.Fatalf("Couldn't connect to calculator server: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", gw)
+	mux.Handle("/v2/", gw)
+	mux.HandleFunc("/openapi/greet.json", serveOpenAPI(greetpb.OpenAPI))
+	mux.HandleFunc("/openapi/calculator.json", serveOpenAPI(calculatorpb.OpenAPI))
+	mux.HandleFunc("/openapi/calculator-v2.json", serveOpenAPI(calculatorv2pb.OpenAPI))
+
+	srv := &http.Server{
+		Addr:              *address,
+		Handler:           web.CORS(*corsOrigins, mux),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+		log.Println("Shutting down...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Serving HTTP/JSON API on %s", *address)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// ndjsonMarshaler is the default JSON marshaler, announcing server streams
+// as newline delimited JSON.
+type ndjsonMarshaler struct {
+	runtime.JSONPb
+}
+
+func (*ndjsonMarshaler) StreamContentType(interface{}) string {
+	return "application/x-ndjson"
+}
+
+// sseMarshaler writes responses as server-sent events, for clients asking
+// for text/event-stream. Each message of a server stream is one event.
+type sseMarshaler struct {
+	runtime.JSONPb
+}
+
+func (m *sseMarshaler) Marshal(v interface{}) ([]byte, error) {
+	data, err := m.JSONPb.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte("data: "), data...), nil
+}
+
+func (*sseMarshaler) ContentType(interface{}) string {
+	return "text/event-stream"
+}
+
+func (*sseMarshaler) Delimiter() []byte {
+	return []byte("\n\n")
+}
@@ -0,0 +1,6 @@
+#!/bin/bash
+# Generates the Go code, gateways and OpenAPI specs of every proto, as
+# configured in buf.gen.yaml.
+set -e
+cd "$(dirname "$0")"
+buf generate
@@ -1,2 +0,0 @@
-#!/bin/bash
-protoc greet/greetpb/greet.proto --go_out=plugins=grpc:.
\ No newline at end of file
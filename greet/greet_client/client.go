@@ -2,31 +2,44 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
+	"os"
+	"os/signal"
+	"strings"
 	"time"
 
+	"github.com/AlanKev117/go-grpc/auth"
+	"github.com/AlanKev117/go-grpc/config"
 	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/AlanKev117/go-grpc/validate"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
-func doUnary(c greetpb.GreetServiceClient) {
+func doUnary(c greetpb.GreetServiceClient, locale string, formality greetpb.Formality, templateID string) {
 	fmt.Println("Starting unary gRPC...")
 	req := &greetpb.GreetRequest{
 		Greeting: &greetpb.Greeting{
 			FirstName:  "Alan",
 			SecondName: "Kevin",
+			Locale:     locale,
+			Formality:  formality,
+			TemplateId: templateID,
 		},
 	}
 	res, err := c.Greet(context.Background(), req)
 	if err != nil {
 		log.Fatalf("Error while calling Greet from server: %v", err)
 	}
-	log.Printf("Response from server Greet: %v", res.Result)
+	log.Printf("Response from server Greet (%v): %v", res.Locale, res.Result)
 }
 
-func doServerStreaming(c greetpb.GreetServiceClient) {
+func doServerStreaming(c greetpb.GreetServiceClient, count uint, interval, jitter time.Duration, untilCancelled bool) {
 	fmt.Println("Starting server streaming gRPC...")
 
 	req := &greetpb.GreetManyTimesRequest{
@@ -34,9 +47,25 @@ func doServerStreaming(c greetpb.GreetServiceClient) {
 			FirstName:  "Alan",
 			SecondName: "Kevin",
 		},
+		Count:          uint32(count),
+		UntilCancelled: untilCancelled,
+	}
+	if interval > 0 {
+		req.Interval = durationpb.New(interval)
+	}
+	if jitter > 0 {
+		req.Jitter = durationpb.New(jitter)
 	}
 
-	resStream, err := c.GreetManyTimes(context.Background(), req)
+	// Interrupting the client ends greetings sent until cancelled, and
+	// moves on to the next call.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if untilCancelled {
+		fmt.Println("Press Ctrl+C to stop the greetings")
+	}
+
+	resStream, err := c.GreetManyTimes(ctx, req)
 
 	if err != nil {
 		log.Fatalf("Error while calling GreetManyTimes: %v", err)
@@ -47,6 +76,9 @@ func doServerStreaming(c greetpb.GreetServiceClient) {
 		if err == io.EOF {
 			break
 		}
+		if status.Code(err) == codes.Canceled && ctx.Err() != nil {
+			break
+		}
 		if err != nil {
 			log.Fatalf("Error while reading from stream: %v", err)
 		}
@@ -55,7 +87,7 @@ func doServerStreaming(c greetpb.GreetServiceClient) {
 	}
 }
 
-func doClientStreaming(c greetpb.GreetServiceClient) {
+func doClientStreaming(c greetpb.GreetServiceClient, opts *greetpb.LongGreetOptions) {
 	fmt.Println("Starting a client streaming gRPC operation...")
 
 	requests := []*greetpb.LongGreetRequest{
@@ -64,6 +96,7 @@ func doClientStreaming(c greetpb.GreetServiceClient) {
 				FirstName:  "Alan",
 				SecondName: "Kevin",
 			},
+			Options: opts,
 		},
 		&greetpb.LongGreetRequest{
 			Greeting: &greetpb.Greeting{
@@ -97,70 +130,49 @@ func doClientStreaming(c greetpb.GreetServiceClient) {
 	}
 	fmt.Println("Response from LongGreet: ")
 	fmt.Println(res.GetResult())
+	for _, p := range res.GetPeople() {
+		fmt.Printf("  %s (received %d times)\n", p.GetName(), p.GetCount())
+	}
 }
 
-func doBiDirectionalStreaming(c greetpb.GreetServiceClient) {
-	fmt.Println("Starting a client streaming gRPC operation...")
-
-	stream, err := c.GreetEveryone(context.Background())
+func main() {
+	clientConfig := config.DefaultClient()
+	clientConfig.RegisterFlags(flag.CommandLine)
+	var authFlags auth.ClientFlags
+	authFlags.RegisterFlags(flag.CommandLine)
+	reconnects := flag.Int("reconnects", 5, "how many times GreetEveryone reconnects after its connection drops")
+	locale := flag.String("locale", "", "BCP 47 tag of the language of the unary greeting, e.g. es-MX")
+	formal := flag.Bool("formal", false, "ask for a formal unary greeting")
+	templateID := flag.String("template", "", "ID of the template rendering the unary greeting; the server's default when empty")
+	count := flag.Uint("count", 0, "how many greetings GreetManyTimes sends; the server's default when 0")
+	interval := flag.Duration("interval", 0, "time between the greetings of GreetManyTimes; the server's default when 0")
+	jitter := flag.Duration("jitter", 0, "random delay up to this duration added to each greeting of GreetManyTimes")
+	untilCancelled := flag.Bool("until-cancelled", false, "keep GreetManyTimes greeting until interrupted, ignoring -count")
+	var groupOpts greetpb.LongGreetOptions
+	flag.BoolVar(&groupOpts.Dedupe, "dedupe", false, "greet people sent several times to LongGreet once")
+	flag.BoolVar(&groupOpts.Sort, "sort", false, "sort the people greeted by LongGreet by name")
+	flag.BoolVar(&groupOpts.GroupBySecondName, "group", false, "name the people greeted by LongGreet sharing a second name together")
+	flag.BoolVar(&groupOpts.ListJoin, "list-join", false, "join the names greeted by LongGreet the way the language writes lists")
+	maxNames := flag.Uint("max-names", 0, "most names LongGreet writes before summarizing the rest; all when 0")
+	room := flag.String("room", "", "join this room with GreetEveryone instead of greeting in a session")
+	name := flag.String("name", "Alan Kevin", "first and second name greeting the room")
+	validateRequests := flag.Bool("validate", true, "check requests against the proto validation rules before sending them")
+	flag.Parse()
+
+	if err := clientConfig.Validate(); err != nil {
+		log.Fatalf("Invalid client settings: %v", err)
+	}
 
+	authOpts, err := authFlags.DialOptions()
 	if err != nil {
-		log.Fatalf("Error while creating stream: %v", err)
+		log.Fatalf("Couldn't read token: %v", err)
 	}
 
-	requests := []*greetpb.GreetEveryoneRequest{
-		&greetpb.GreetEveryoneRequest{
-			Greeting: &greetpb.Greeting{
-				FirstName:  "Alan",
-				SecondName: "Kevin",
-			},
-		},
-		&greetpb.GreetEveryoneRequest{
-			Greeting: &greetpb.Greeting{
-				FirstName:  "Dani",
-				SecondName: "Elías",
-			},
-		},
-		&greetpb.GreetEveryoneRequest{
-			Greeting: &greetpb.Greeting{
-				FirstName:  "Esteban",
-				SecondName: "Damián",
-			},
-		},
+	opts := append([]grpc.DialOption{grpc.WithInsecure()}, authOpts...)
+	if *validateRequests {
+		opts = append(opts, validate.DialOptions()...)
 	}
-
-	waitc := make(chan struct{})
-
-	// Sending messages to the server
-	go func() {
-		for _, req := range requests {
-			log.Printf("Sending message: %v\n", req)
-			stream.Send(req)
-			time.Sleep(200 * time.Millisecond)
-		}
-		stream.CloseSend()
-	}()
-
-	// Receiving responses from the server
-	go func() {
-		defer close(waitc)
-		for {
-			res, err := stream.Recv()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				log.Fatalf("Error while receiving response from server: %v", err)
-				break
-			}
-			fmt.Printf("Greeting received: %v\n", res.GetResult())
-		}
-	}()
-	<-waitc
-}
-
-func main() {
-	conn, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
+	conn, err := clientConfig.Dial(opts...)
 	if err != nil {
 		log.Fatalf("Couldn't connect: %v", err)
 	}
@@ -168,8 +180,18 @@ func main() {
 
 	c := greetpb.NewGreetServiceClient(conn)
 
-	doUnary(c)
-	doServerStreaming(c)
-	doClientStreaming(c)
-	doBiDirectionalStreaming(c)
+	formality := greetpb.Formality_FORMALITY_INFORMAL
+	if *formal {
+		formality = greetpb.Formality_FORMALITY_FORMAL
+	}
+	doUnary(c, *locale, formality, *templateID)
+	doServerStreaming(c, *count, *interval, *jitter, *untilCancelled)
+	groupOpts.MaxNames = uint32(*maxNames)
+	doClientStreaming(c, &groupOpts)
+	if *room != "" {
+		firstName, secondName, _ := strings.Cut(*name, " ")
+		doRoom(c, *room, firstName, secondName)
+		return
+	}
+	doBiDirectionalStreaming(c, *reconnects)
 }
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/AlanKev117/go-grpc/session"
+)
+
+// greetingSession sends greetings to GreetEveryone within a session, so that
+// the stream can be resumed when the connection drops.
+type greetingSession struct {
+	c         greetpb.GreetServiceClient
+	id        string
+	greetings []*greetpb.Greeting
+
+	mu sync.Mutex
+	// answered is the sequence of the last greeting the server processed;
+	// received the sequence of the last response received. Greeting i is
+	// sent with sequence i+1.
+	answered uint64
+	received uint64
+}
+
+func doBiDirectionalStreaming(c greetpb.GreetServiceClient, reconnects int) {
+	fmt.Println("Starting a client streaming gRPC operation...")
+
+	s := &greetingSession{
+		c:  c,
+		id: session.NewID(),
+		greetings: []*greetpb.Greeting{
+			{
+				FirstName:  "Alan",
+				SecondName: "Kevin",
+			},
+			{
+				FirstName:  "Dani",
+				SecondName: "Elías",
+			},
+			{
+				FirstName:  "Esteban",
+				SecondName: "Damián",
+			},
+		},
+	}
+	if err := session.Reconnect(reconnects, time.Second, s.stream); err != nil {
+		log.Fatalf("Error while greeting everyone: %v", err)
+	}
+}
+
+// stream runs one GreetEveryone stream. It tells the server the last
+// greeting received, so that the server sends the ones missed, and sends the
+// greetings the server did not process yet.
+func (s *greetingSession) stream(bool) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := s.c.GreetEveryone(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	received := s.received
+	next := s.answered
+	if received > next {
+		next = received
+	}
+	s.mu.Unlock()
+
+	// Attach the stream to the session before sending any greeting.
+	err = stream.Send(&greetpb.GreetEveryoneRequest{
+		SessionId:            s.id,
+		LastReceivedSequence: received,
+	})
+	if err != nil {
+		_, err := stream.Recv()
+		return err
+	}
+
+	// Receiving responses from the server
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				errc <- nil
+				return
+			}
+			if err != nil {
+				errc <- err
+				return
+			}
+			if s.receive(res) {
+				fmt.Printf("Greeting received: %v\n", res.GetResult())
+			}
+		}
+	}()
+
+	// Sending messages to the server
+	for seq := next + 1; seq <= uint64(len(s.greetings)); seq++ {
+		req := &greetpb.GreetEveryoneRequest{
+			Greeting:  s.greetings[seq-1],
+			SessionId: s.id,
+			Sequence:  seq,
+		}
+		log.Printf("Sending message: %v\n", req)
+		if err := stream.Send(req); err != nil {
+			// The stream broke: the receiving side reports why.
+			return <-errc
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	stream.CloseSend()
+
+	return <-errc
+}
+
+// receive records res and reports whether it is a greeting not seen before.
+func (s *greetingSession) receive(res *greetpb.GreetEveryoneResponse) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if res.GetSequence() > s.answered {
+		s.answered = res.GetSequence()
+	}
+	if res.GetAckOnly() || res.GetSequence() <= s.received {
+		return false
+	}
+	s.received = res.GetSequence()
+	return true
+}
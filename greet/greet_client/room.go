@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// doRoom joins the room through GreetEveryone as the given person, greets
+// it a few times and prints the events of the room until interrupted.
+func doRoom(c greetpb.GreetServiceClient, room, firstName, secondName string) {
+	fmt.Printf("Joining room %s, press Ctrl+C to leave\n", room)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	stream, err := c.GreetEveryone(ctx)
+	if err != nil {
+		log.Fatalf("Error while joining room %s: %v", room, err)
+	}
+
+	greeting := &greetpb.Greeting{FirstName: firstName, SecondName: secondName}
+	go func() {
+		stream.Send(&greetpb.GreetEveryoneRequest{Greeting: greeting, RoomId: room})
+		for i := 0; i < 2; i++ {
+			select {
+			case <-time.After(time.Second):
+			case <-ctx.Done():
+				return
+			}
+			stream.Send(&greetpb.GreetEveryoneRequest{Greeting: greeting})
+		}
+	}()
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF || status.Code(err) == codes.Canceled && ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Fatalf("Error while receiving the events of room %s: %v", room, err)
+		}
+		e := res.GetEvent()
+		if e.GetDropped() > 0 {
+			log.Printf("Missed %d events", e.GetDropped())
+		}
+		switch e.GetType() {
+		case greetpb.RoomEventType_ROOM_EVENT_TYPE_JOINED:
+			log.Printf("%s (member %s) joined", e.GetName(), e.GetMemberId())
+		case greetpb.RoomEventType_ROOM_EVENT_TYPE_LEFT:
+			log.Printf("%s (member %s) left", e.GetName(), e.GetMemberId())
+		case greetpb.RoomEventType_ROOM_EVENT_TYPE_GREETING:
+			log.Printf("Member %s: %s", e.GetMemberId(), res.GetResult())
+		}
+	}
+}
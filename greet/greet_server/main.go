@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/auth"
+	"github.com/AlanKev117/go-grpc/config"
+	"github.com/AlanKev117/go-grpc/greet/greeter"
+	"github.com/AlanKev117/go-grpc/greet/greeterserver"
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/AlanKev117/go-grpc/greet/history"
+	"github.com/AlanKev117/go-grpc/greet/rooms"
+	"github.com/AlanKev117/go-grpc/greet/templates"
+	"github.com/AlanKev117/go-grpc/ratelimit"
+	"github.com/AlanKev117/go-grpc/validate"
+	"github.com/AlanKev117/go-grpc/web"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func main() {
+	serverConfig := config.DefaultServer()
+	serverConfig.RegisterFlags(flag.CommandLine)
+	var authFlags auth.ServerFlags
+	authFlags.RegisterFlags(flag.CommandLine)
+	var limitFlags ratelimit.Flags
+	limitFlags.RegisterFlags(flag.CommandLine)
+	sessionTTL := flag.Duration("session-ttl", 5*time.Minute, "how long a broken GreetEveryone session can be resumed")
+	maxCount := flag.Int("max-greet-count", greeter.DefaultLimits.MaxCount, "most greetings a GreetManyTimes call can ask for")
+	maxInterval := flag.Duration("max-greet-interval", greeter.DefaultLimits.MaxInterval, "longest interval between the greetings of GreetManyTimes")
+	roomBuffer := flag.Int("room-buffer", 64, "how many room events are buffered for each GreetEveryone stream")
+	slowConsumer := flag.String("room-slow-consumer", "drop", "what happens to room events for a stream whose buffer is full: drop or disconnect")
+	templateDir := flag.String("templates", "", "directory of the greeting templates, one <id>.tmpl file each; in memory when empty")
+	historyFlags := history.Flags{Limit: 10000}
+	historyFlags.RegisterFlags(flag.CommandLine)
+	var webFlags web.Flags
+	webFlags.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := serverConfig.Validate(); err != nil {
+		log.Fatalf("Invalid server settings: %v", err)
+	}
+	opts := serverConfig.ServerOptions()
+
+	accessOpts, err := authFlags.ServerOptions()
+	if err != nil {
+		log.Fatalf("Failed to load access control settings: %v", err)
+	}
+	opts = append(opts, accessOpts...)
+	opts = append(opts, limitFlags.ServerOptions()...)
+	opts = append(opts, validate.ServerOptions()...)
+
+	if *maxCount < 1 {
+		log.Fatalf("Invalid -max-greet-count %d: must be positive", *maxCount)
+	}
+	if *roomBuffer < 1 {
+		log.Fatalf("Invalid -room-buffer %d: must be positive", *roomBuffer)
+	}
+	policy, err := rooms.ParsePolicy(*slowConsumer)
+	if err != nil {
+		log.Fatalf("Invalid -room-slow-consumer: %v", err)
+	}
+
+	greetings, err := historyFlags.Open()
+	if err != nil {
+		log.Fatalf("Failed to open the greeting history: %v", err)
+	}
+	defer greetings.Close()
+
+	registry := templates.NewRegistry()
+	if *templateDir != "" {
+		registry, err = templates.Load(*templateDir)
+		if err != nil {
+			log.Fatalf("Failed to load greeting templates: %v", err)
+		}
+	}
+
+	lis, err := net.Listen("tcp", serverConfig.Address)
+
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+
+	s := grpc.NewServer(opts...)
+	srv := greeterserver.New(greeterserver.Options{
+		Templates:  registry,
+		History:    greetings,
+		Rooms:      rooms.NewHub(*roomBuffer, policy),
+		SessionTTL: *sessionTTL,
+		Limits:     greeter.Limits{MaxCount: *maxCount, MaxInterval: *maxInterval},
+		// Whoever changes the templates changes the greetings of everyone,
+		// so changes are only accepted while the policy restricts them.
+		TemplateChanges: func() bool {
+			return authFlags.Restricts(greetpb.GreetService_CreateTemplate_FullMethodName) &&
+				authFlags.Restricts(greetpb.GreetService_UpdateTemplate_FullMethodName)
+		},
+	})
+	srv.Register(s)
+
+	// Every name the service is registered under reports its health.
+	healthServer := health.NewServer()
+	for name := range s.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(s, healthServer)
+
+	webServer, err := webFlags.Serve(s, web.GreetService)
+	if err != nil {
+		log.Fatalf("Failed to serve gRPC-Web and Connect: %v", err)
+	}
+
+	// On shutdown, report NOT_SERVING first so that balancing clients stop
+	// sending new calls before the server goes away.
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+		log.Println("Shutting down...")
+		healthServer.Shutdown()
+		time.Sleep(serverConfig.DrainDelay)
+		srv.Stop()
+		if webServer != nil {
+			webServer.Shutdown(context.Background())
+		}
+		s.GracefulStop()
+	}()
+
+	if err := s.Serve(lis); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+// Package greeter writes the greetings of GreetService, independently of
+// how they are requested: a greeting for one person, the greeting of a
+// group, and greetings repeated on a schedule. The gRPC service in
+// greeterserver is a thin layer over it, and other programs can use it to
+// greet the same way.
+package greeter
+
+import (
+	"fmt"
+
+	"github.com/AlanKev117/go-grpc/greet/i18n"
+	"github.com/AlanKev117/go-grpc/greet/templates"
+)
+
+// Greeting asks for a person to be greeted.
+type Greeting struct {
+	FirstName  string
+	SecondName string
+	// Languages lists the preferences of the caller, most preferred first,
+	// as BCP 47 tags or Accept-Language header values.
+	Languages []string
+	Formal    bool
+	// TemplateID names the template rendering the greeting; the default
+	// template when empty.
+	TemplateID string
+}
+
+// Locale returns the language g is greeted in.
+func (g Greeting) Locale() *i18n.Locale {
+	return i18n.Match(g.Languages...)
+}
+
+// Greeter greets people with the templates of a registry.
+type Greeter struct {
+	templates *templates.Registry
+}
+
+// New returns a Greeter rendering the templates of registry.
+func New(registry *templates.Registry) *Greeter {
+	return &Greeter{templates: registry}
+}
+
+// Greet greets g. number is the template's .Number, 0 but for repeated
+// greetings. It also returns the locale used. The error wraps
+// templates.ErrNotFound when the template of g does not exist.
+func (gr *Greeter) Greet(g Greeting, number int) (string, *i18n.Locale, error) {
+	locale := g.Locale()
+	name := locale.Name(g.FirstName, g.SecondName)
+	result, err := gr.render(g, locale, templates.Data{
+		Name:   name,
+		Names:  []string{name},
+		Number: number,
+	})
+	return result, locale, err
+}
+
+// render renders d with the template of g, greeting d.Name in locale with
+// the formality of g.
+func (gr *Greeter) render(g Greeting, locale *i18n.Locale, d templates.Data) (string, error) {
+	t, err := gr.templates.Get(g.TemplateID)
+	if err != nil {
+		return "", err
+	}
+
+	d.Greeting = locale.GreetName(d.Name, g.Formal)
+	d.FirstName = g.FirstName
+	d.SecondName = g.SecondName
+	d.Locale = locale.String()
+	result, err := t.Render(d)
+	if err != nil {
+		return "", fmt.Errorf("rendering template %q: %w", t.ID, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,158 @@
+package greeter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/greet/i18n"
+	"github.com/AlanKev117/go-grpc/greet/templates"
+)
+
+func TestGreet(t *testing.T) {
+	gr := New(templates.NewRegistry())
+	tests := []struct {
+		name       string
+		greeting   Greeting
+		want       string
+		wantLocale string
+	}{
+		{"default", Greeting{FirstName: "Alan", SecondName: "Kevin"}, "Hello, Alan Kevin", "en"},
+		{"formal", Greeting{FirstName: "Alan", Formal: true}, "Good day, Alan", "en"},
+		{"first supported language", Greeting{FirstName: "Alan", Languages: []string{"", "xx", "es-MX"}}, "Hola, Alan", "es"},
+		{"family name first", Greeting{FirstName: "Anna", SecondName: "Nagy", Languages: []string{"hu"}}, "Szia, Nagy Anna!", "hu"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, locale, err := gr.Greet(tt.greeting, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want || locale.String() != tt.wantLocale {
+				t.Errorf("Greet = %q in %v, want %q in %s", got, locale, tt.want, tt.wantLocale)
+			}
+		})
+	}
+
+	if got, _, err := gr.Greet(Greeting{FirstName: "Alan"}, 2); err != nil || got != "Hello, Alan (2)" {
+		t.Errorf("Greet number 2 = %q, %v", got, err)
+	}
+	if _, _, err := gr.Greet(Greeting{FirstName: "Alan", TemplateID: "missing"}, 0); !errors.Is(err, templates.ErrNotFound) {
+		t.Errorf("Greet with a missing template = %v, want templates.ErrNotFound", err)
+	}
+}
+
+func TestGreetGroup(t *testing.T) {
+	gr := New(templates.NewRegistry())
+	people := []Greeting{
+		{FirstName: "Luis", SecondName: "García"},
+		{FirstName: "Alan", SecondName: "Turing"},
+		{FirstName: "Ana", SecondName: "García"},
+		{FirstName: "Luis", SecondName: "García"},
+	}
+	tests := []struct {
+		name       string
+		opts       GroupOptions
+		want       string
+		wantPeople int
+		wantOthers int
+	}{
+		{"as sent", GroupOptions{}, "Hello, Luis García, Alan Turing, Ana García, Luis García", 4, 0},
+		{"dedupe", GroupOptions{Dedupe: true}, "Hello, Luis García, Alan Turing, Ana García", 3, 0},
+		{"sort", GroupOptions{Dedupe: true, Sort: true}, "Hello, Ana García, Luis García, Alan Turing", 3, 0},
+		{"group", GroupOptions{Dedupe: true, GroupBySecondName: true, ListJoin: true}, "Hello, Luis and Ana García and Alan Turing", 3, 0},
+		{"max names", GroupOptions{MaxNames: 1, ListJoin: true}, "Hello, Luis García and 3 others", 4, 3},
+		{"max names of groups", GroupOptions{GroupBySecondName: true, MaxNames: 1}, "Hello, Luis, Ana and Luis García and 1 other", 4, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := gr.GreetGroup(people, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if g.Result != tt.want || len(g.People) != tt.wantPeople || g.Others != tt.wantOthers || g.Locale != i18n.English {
+				t.Errorf("GreetGroup = %q with %d people and %d others in %v, want %q with %d people and %d others",
+					g.Result, len(g.People), g.Others, g.Locale, tt.want, tt.wantPeople, tt.wantOthers)
+			}
+		})
+	}
+
+	g, err := gr.GreetGroup(people, GroupOptions{Dedupe: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := g.People[0]; p.Name != "Luis García" || p.Count != 2 {
+		t.Errorf("first person = %+v, want Luis García greeted twice", p)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	limits := Limits{MaxCount: 10, MaxInterval: time.Second}
+	tests := []struct {
+		schedule Schedule
+		valid    bool
+	}{
+		{DefaultSchedule, true},
+		{Schedule{Count: 10, Interval: time.Second, Jitter: time.Second}, true},
+		{Schedule{Forever: true, Interval: time.Millisecond}, true},
+		{Schedule{Count: 11, Interval: time.Second}, false},
+		{Schedule{Count: 0, Interval: time.Second}, false},
+		{Schedule{Count: 1}, false},
+		{Schedule{Count: 1, Interval: 2 * time.Second}, false},
+		{Schedule{Count: 1, Interval: time.Millisecond, Jitter: -time.Millisecond}, false},
+		{Schedule{Count: 1, Interval: time.Millisecond, Jitter: 2 * time.Millisecond}, false},
+	}
+	for _, tt := range tests {
+		err := limits.Check(tt.schedule)
+		if tt.valid && err != nil || !tt.valid && !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("Check(%+v) = %v", tt.schedule, err)
+		}
+	}
+}
+
+func TestRepeat(t *testing.T) {
+	gr := New(templates.NewRegistry())
+	alan := Greeting{FirstName: "Alan"}
+
+	var got []string
+	err := gr.Repeat(context.Background(), nil, alan, Schedule{Count: 3, Interval: time.Millisecond}, func(i int, result string, _ *i18n.Locale) error {
+		got = append(got, fmt.Sprint(i, ": ", result))
+		return nil
+	})
+	if err != nil || fmt.Sprint(got) != "[1: Hello, Alan (1) 2: Hello, Alan (2) 3: Hello, Alan (3)]" {
+		t.Errorf("Repeat = %q, %v", got, err)
+	}
+
+	// Forever ends when stopped, when the context ends, or when sending
+	// fails.
+	forever := Schedule{Forever: true, Interval: time.Millisecond, Jitter: time.Millisecond}
+	stop := make(chan struct{})
+	err = gr.Repeat(context.Background(), stop, alan, forever, func(i int, _ string, _ *i18n.Locale) error {
+		if i == 20 {
+			close(stop)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Repeat after stopping = %v, want nil", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = gr.Repeat(ctx, nil, alan, forever, func(i int, _ string, _ *i18n.Locale) error {
+		if i == 20 {
+			cancel()
+		}
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("Repeat after cancelling = %v, want context.Canceled", err)
+	}
+
+	errSend := errors.New("send failed")
+	err = gr.Repeat(context.Background(), nil, alan, forever, func(int, string, *i18n.Locale) error { return errSend })
+	if err != errSend {
+		t.Errorf("Repeat when sending fails = %v, want %v", err, errSend)
+	}
+}
@@ -0,0 +1,159 @@
+package greeter
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AlanKev117/go-grpc/greet/i18n"
+	"github.com/AlanKev117/go-grpc/greet/templates"
+)
+
+// GroupOptions tells how the greeting of a group names everyone.
+type GroupOptions struct {
+	// Dedupe names people greeted several times once.
+	Dedupe bool
+	// Sort sorts people by second name, then first name, in the order of
+	// their language.
+	Sort bool
+	// GroupBySecondName names people sharing a second name together, e.g.
+	// "Ana and Luis García".
+	GroupBySecondName bool
+	// ListJoin joins the names as the language writes lists, instead of
+	// with commas.
+	ListJoin bool
+	// MaxNames is how many names are written before the others are only
+	// counted. 0 writes every name.
+	MaxNames int
+}
+
+// Person is someone greeted in a group.
+type Person struct {
+	FirstName  string
+	SecondName string
+	// Name is the full name in the language of the greeting.
+	Name string
+	// Count is how many times the person was greeted; more than 1 only
+	// when deduplicating.
+	Count int
+}
+
+// Group is the greeting of a group.
+type Group struct {
+	Result string
+	People []*Person
+	// Others counts the people left out of the greeting by MaxNames.
+	Others int
+	Locale *i18n.Locale
+}
+
+// entry is a name in the greeting of a group: one person, or several
+// sharing a second name.
+type entry struct {
+	name string
+	size int
+}
+
+// GreetGroup greets everyone in greetings at once, as opts asks, with the
+// template, languages and formality of the first greeting, which must
+// exist.
+func (gr *Greeter) GreetGroup(greetings []Greeting, opts GroupOptions) (*Group, error) {
+	first := greetings[0]
+	locale := first.Locale()
+
+	people := make([]*Person, 0, len(greetings))
+	seen := make(map[[2]string]*Person)
+	for _, g := range greetings {
+		key := [2]string{g.FirstName, g.SecondName}
+		if p, ok := seen[key]; ok && opts.Dedupe {
+			p.Count++
+			continue
+		}
+		p := &Person{
+			FirstName:  g.FirstName,
+			SecondName: g.SecondName,
+			Name:       locale.Name(g.FirstName, g.SecondName),
+			Count:      1,
+		}
+		seen[key] = p
+		people = append(people, p)
+	}
+
+	if opts.Sort {
+		coll := locale.Collator()
+		sort.SliceStable(people, func(i, j int) bool {
+			if c := coll.CompareString(people[i].SecondName, people[j].SecondName); c != 0 {
+				return c < 0
+			}
+			return coll.CompareString(people[i].FirstName, people[j].FirstName) < 0
+		})
+	}
+
+	// Groups take the place of their first member.
+	var entries []entry
+	if opts.GroupBySecondName {
+		members := make(map[string][]*Person)
+		var families []string
+		for i, p := range people {
+			family := p.SecondName
+			if family == "" {
+				// Without a second name, everyone is on their own.
+				family = "\x00" + strconv.Itoa(i)
+			}
+			if _, ok := members[family]; !ok {
+				families = append(families, family)
+			}
+			members[family] = append(members[family], p)
+		}
+		for _, family := range families {
+			group := members[family]
+			if len(group) == 1 {
+				entries = append(entries, entry{name: group[0].Name, size: 1})
+				continue
+			}
+			given := make([]string, len(group))
+			for i, p := range group {
+				given[i] = p.FirstName
+			}
+			name := locale.Name(locale.List(given, 0), group[0].SecondName)
+			entries = append(entries, entry{name: name, size: len(group)})
+		}
+	} else {
+		for _, p := range people {
+			entries = append(entries, entry{name: p.Name, size: 1})
+		}
+	}
+
+	others := 0
+	if max := opts.MaxNames; max > 0 && len(entries) > max {
+		for _, e := range entries[max:] {
+			others += e.size
+		}
+		entries = entries[:max]
+	}
+	shown := make([]string, len(entries))
+	for i, e := range entries {
+		shown[i] = e.name
+	}
+	var name string
+	if opts.ListJoin {
+		name = locale.List(shown, others)
+	} else {
+		name = strings.Join(shown, ", ") + locale.Others(others)
+	}
+
+	names := make([]string, len(people))
+	for i, p := range people {
+		names[i] = p.Name
+	}
+	result, err := gr.render(first, locale, templates.Data{Name: name, Names: names})
+	if err != nil {
+		return nil, err
+	}
+	return &Group{
+		Result: result,
+		People: people,
+		Others: others,
+		Locale: locale,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package greeter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/greet/i18n"
+)
+
+// ErrInvalidSchedule is returned for a schedule outside of the limits.
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Schedule tells how often a greeting is repeated.
+type Schedule struct {
+	// Count is how many times the greeting is sent.
+	Count int
+	// Interval is the average time between two greetings.
+	Interval time.Duration
+	// Jitter is the most each greeting is randomly delayed by.
+	Jitter time.Duration
+	// Forever repeats the greeting until stopped, whatever Count.
+	Forever bool
+}
+
+// DefaultSchedule sends 10 greetings 200ms apart.
+var DefaultSchedule = Schedule{Count: 10, Interval: 200 * time.Millisecond}
+
+// Limits bound the schedules a caller can ask for.
+type Limits struct {
+	MaxCount    int
+	MaxInterval time.Duration
+}
+
+// DefaultLimits allow 1000 greetings up to a minute apart.
+var DefaultLimits = Limits{MaxCount: 1000, MaxInterval: time.Minute}
+
+// Check returns an error wrapping ErrInvalidSchedule when s is not within
+// the limits.
+func (l Limits) Check(s Schedule) error {
+	switch {
+	case s.Count > l.MaxCount:
+		return fmt.Errorf("%w: count %d is above the maximum of %d", ErrInvalidSchedule, s.Count, l.MaxCount)
+	case s.Count < 1 && !s.Forever:
+		return fmt.Errorf("%w: count %d must be positive", ErrInvalidSchedule, s.Count)
+	case s.Interval <= 0 || s.Interval > l.MaxInterval:
+		return fmt.Errorf("%w: interval %v must be positive and at most %v", ErrInvalidSchedule, s.Interval, l.MaxInterval)
+	case s.Jitter < 0 || s.Jitter > s.Interval:
+		return fmt.Errorf("%w: jitter %v must not be negative nor exceed the interval of %v", ErrInvalidSchedule, s.Jitter, s.Interval)
+	}
+	return nil
+}
+
+// Repeat greets g as s schedules, passing every greeting to send with its
+// number, from 1. It stops early, returning nil, when stop is closed, and
+// returns the error of ctx when it ends or the first error of send.
+func (gr *Greeter) Repeat(ctx context.Context, stop <-chan struct{}, g Greeting, s Schedule, send func(number int, result string, locale *i18n.Locale) error) error {
+	// wait blocks until c fires. It fails when ctx ends and returns false
+	// when stopped.
+	wait := func(c <-chan time.Time) (bool, error) {
+		select {
+		case <-c:
+			return true, nil
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-stop:
+			return false, nil
+		}
+	}
+
+	// The ticker keeps the greetings interval apart on average, however
+	// long sending and the jitter take.
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for i := 1; s.Forever || i <= s.Count; i++ {
+		if i > 1 {
+			if ok, err := wait(ticker.C); !ok {
+				return err
+			}
+		}
+		if s.Jitter > 0 {
+			if ok, err := wait(time.After(rand.N(s.Jitter))); !ok {
+				return err
+			}
+		}
+
+		result, locale, err := gr.Greet(g, i)
+		if err != nil {
+			return err
+		}
+		if err := send(i, result, locale); err != nil {
+			return err
+		}
+	}
+	return nil
+}
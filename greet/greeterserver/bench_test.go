@@ -0,0 +1,124 @@
+package greeterserver
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// The benchmarks call every handler through startServer, so they include
+// the validation interceptors and the bufconn transport.
+
+var benchGreeting = &greetpb.Greeting{FirstName: "Alan", SecondName: "Kevin"}
+
+func BenchmarkGreet(b *testing.B) {
+	c := startServer(b, newTestServer())
+	ctx := context.Background()
+	req := &greetpb.GreetRequest{Greeting: benchGreeting}
+	for b.Loop() {
+		if _, err := c.Greet(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGreetManyTimes(b *testing.B) {
+	c := startServer(b, newTestServer())
+	ctx := context.Background()
+	// The shortest interval measures the handler rather than the schedule.
+	req := &greetpb.GreetManyTimesRequest{Greeting: benchGreeting, Count: 10, Interval: durationpb.New(time.Nanosecond)}
+	for b.Loop() {
+		stream, err := c.GreetManyTimes(ctx, req)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for {
+			_, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkLongGreet(b *testing.B) {
+	c := startServer(b, newTestServer())
+	ctx := context.Background()
+	opts := &greetpb.LongGreetOptions{Dedupe: true, Sort: true, GroupBySecondName: true, ListJoin: true}
+	for b.Loop() {
+		stream, err := c.LongGreet(ctx)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for i := range 10 {
+			req := &greetpb.LongGreetRequest{Greeting: benchGreeting}
+			if i == 0 {
+				req.Options = opts
+			}
+			if err := stream.Send(req); err != nil {
+				b.Fatal(err)
+			}
+		}
+		if _, err := stream.CloseAndRecv(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGreetEveryone(b *testing.B) {
+	c := startServer(b, newTestServer())
+	ctx := context.Background()
+	req := &greetpb.GreetEveryoneRequest{Greeting: benchGreeting}
+	for b.Loop() {
+		stream, err := c.GreetEveryone(ctx)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for range 10 {
+			if err := stream.Send(req); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := stream.Recv(); err != nil {
+				b.Fatal(err)
+			}
+		}
+		stream.CloseSend()
+		if _, err := stream.Recv(); err != io.EOF {
+			b.Fatalf("Recv after CloseSend = %v, want io.EOF", err)
+		}
+	}
+}
+
+func BenchmarkListTemplates(b *testing.B) {
+	c := startServer(b, newTestServer())
+	ctx := context.Background()
+	for b.Loop() {
+		if _, err := c.ListTemplates(ctx, &greetpb.ListTemplatesRequest{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkListGreetings(b *testing.B) {
+	c := startServer(b, newTestServer())
+	ctx := context.Background()
+	// Fill the history of the test server.
+	for range 100 {
+		if _, err := c.Greet(ctx, &greetpb.GreetRequest{Greeting: benchGreeting}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	req := &greetpb.ListGreetingsRequest{Name: "alan", PageSize: 50}
+	for b.Loop() {
+		if _, err := c.ListGreetings(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
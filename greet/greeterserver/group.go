@@ -0,0 +1,43 @@
+package greeterserver
+
+import (
+	"context"
+
+	"github.com/AlanKev117/go-grpc/greet/greeter"
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+)
+
+// greetGroup greets everyone in greetings at once, as opts asks, and
+// records the greeting for each of them.
+func (s *Server) greetGroup(ctx context.Context, greetings []*greetpb.Greeting, opts *greetpb.LongGreetOptions) (*greetpb.LongGreetResponse, error) {
+	group := make([]greeter.Greeting, len(greetings))
+	for i, g := range greetings {
+		group[i] = greeting(ctx, g)
+	}
+	greeted, err := s.greeter.GreetGroup(group, greeter.GroupOptions{
+		Dedupe:            opts.GetDedupe(),
+		Sort:              opts.GetSort(),
+		GroupBySecondName: opts.GetGroupBySecondName(),
+		ListJoin:          opts.GetListJoin(),
+		MaxNames:          int(opts.GetMaxNames()),
+	})
+	if err != nil {
+		return nil, greetError(err)
+	}
+
+	res := &greetpb.LongGreetResponse{
+		Result: greeted.Result,
+		Others: uint32(greeted.Others),
+		Locale: greeted.Locale.String(),
+	}
+	for _, p := range greeted.People {
+		s.record(ctx, p.FirstName, p.SecondName, greeted.Result, greeted.Locale)
+		res.People = append(res.People, &greetpb.Person{
+			FirstName:  p.FirstName,
+			SecondName: p.SecondName,
+			Name:       p.Name,
+			Count:      uint32(p.Count),
+		})
+	}
+	return res, nil
+}
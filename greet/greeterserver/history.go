@@ -0,0 +1,113 @@
+package greeterserver
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/auth"
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/AlanKev117/go-grpc/greet/history"
+	"github.com/AlanKev117/go-grpc/greet/i18n"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Page sizes of ListGreetings.
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
+)
+
+// record adds a greeting sent to the person named firstName and secondName
+// to the history. Failing to record it does not fail the call.
+func (s *Server) record(ctx context.Context, firstName, secondName, result string, locale *i18n.Locale) {
+	method, _ := grpc.Method(ctx)
+	e := &history.Entry{
+		Time:       time.Now(),
+		Method:     method,
+		Caller:     caller(ctx),
+		FirstName:  firstName,
+		SecondName: secondName,
+		Result:     result,
+		Locale:     locale.String(),
+	}
+	if err := s.history.Record(ctx, e); err != nil {
+		log.Printf("Failed to record greeting: %v", err)
+	}
+}
+
+// caller returns the authenticated principal of the call, else the address
+// of the client.
+func caller(ctx context.Context) string {
+	if p, ok := auth.FromContext(ctx); ok {
+		return p.Name
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+func (s *Server) ListGreetings(ctx context.Context, req *greetpb.ListGreetingsRequest) (*greetpb.ListGreetingsResponse, error) {
+	q := history.Query{
+		Name:   req.GetName(),
+		Limit:  int(req.GetPageSize()),
+		Cursor: req.GetPageToken(),
+	}
+	switch {
+	case q.Limit < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "page_size %d must not be negative", q.Limit)
+	case q.Limit == 0:
+		q.Limit = defaultPageSize
+	case q.Limit > maxPageSize:
+		q.Limit = maxPageSize
+	}
+	var err error
+	if q.Start, err = timeOf("start_time", req.GetStartTime()); err != nil {
+		return nil, err
+	}
+	if q.End, err = timeOf("end_time", req.GetEndTime()); err != nil {
+		return nil, err
+	}
+
+	entries, next, err := s.history.List(ctx, q)
+	if errors.Is(err, history.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "listing greetings: %v", err)
+	}
+
+	res := &greetpb.ListGreetingsResponse{NextPageToken: next}
+	for _, e := range entries {
+		res.Greetings = append(res.Greetings, &greetpb.GreetingRecord{
+			Id:         strconv.FormatInt(e.ID, 10),
+			Time:       timestamppb.New(e.Time),
+			Method:     e.Method,
+			Caller:     e.Caller,
+			FirstName:  e.FirstName,
+			SecondName: e.SecondName,
+			Result:     e.Result,
+			Locale:     e.Locale,
+		})
+	}
+	return res, nil
+}
+
+// timeOf returns the time of the timestamp field, or the zero time when it
+// is unset.
+func timeOf(field string, ts *timestamppb.Timestamp) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s: %v", field, err)
+	}
+	return ts.AsTime(), nil
+}
@@ -0,0 +1,91 @@
+package greeterserver
+
+import (
+	"io"
+	"log"
+
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/AlanKev117/go-grpc/greet/rooms"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// roomEventTypes maps the events of rooms to their proto type.
+var roomEventTypes = map[rooms.EventType]greetpb.RoomEventType{
+	rooms.Joined:   greetpb.RoomEventType_ROOM_EVENT_TYPE_JOINED,
+	rooms.Left:     greetpb.RoomEventType_ROOM_EVENT_TYPE_LEFT,
+	rooms.Greeting: greetpb.RoomEventType_ROOM_EVENT_TYPE_GREETING,
+}
+
+// greetEveryoneRoom serves a GreetEveryone stream whose first message req
+// joined a room. The greetings of the stream are published to the room and
+// the events of the room are sent back, until the client closes the stream.
+func (s *Server) greetEveryoneRoom(stream greetpb.GreetService_GreetEveryoneServer, req *greetpb.GreetEveryoneRequest) error {
+	if req.GetSessionId() != "" {
+		return status.Error(codes.InvalidArgument, "rooms cannot be used with sessions")
+	}
+
+	ctx := stream.Context()
+	name := greeting(ctx, req.GetGreeting()).Locale().Name(req.GetGreeting().GetFirstName(), req.GetGreeting().GetSecondName())
+	member := s.rooms.Join(req.GetRoomId(), name)
+	defer member.Leave()
+	log.Printf("Member %s joined room %s", member.ID, req.GetRoomId())
+
+	// Requests are read, greeted and published in the background, while
+	// this goroutine sends the events of the room.
+	recvErr := make(chan error, 1)
+	go func() {
+		// Only the message joining the room can come without a greeting.
+		for joining := true; ; joining = false {
+			if req.GetGreeting() == nil && !joining {
+				recvErr <- errNoGreeting
+				return
+			}
+			if req.GetGreeting() != nil {
+				result, locale, err := s.greet(ctx, req.GetGreeting())
+				if err != nil {
+					recvErr <- err
+					return
+				}
+				member.Publish(result)
+				s.record(ctx, req.GetGreeting().GetFirstName(), req.GetGreeting().GetSecondName(), result, locale)
+			}
+
+			var err error
+			req, err = stream.Recv()
+			if err == io.EOF {
+				recvErr <- nil
+				return
+			}
+			if err != nil {
+				recvErr <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case e, ok := <-member.Events():
+			if !ok {
+				return status.Error(codes.ResourceExhausted, member.Err().Error())
+			}
+			res := &greetpb.GreetEveryoneResponse{
+				Result: e.Result,
+				Event: &greetpb.RoomEvent{
+					Type:     roomEventTypes[e.Type],
+					MemberId: e.MemberID,
+					Name:     e.Name,
+					Dropped:  e.Dropped,
+				},
+			}
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+		case err := <-recvErr:
+			return err
+		case <-s.stopping:
+			return nil
+		}
+	}
+}
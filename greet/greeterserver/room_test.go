@@ -0,0 +1,200 @@
+package greeterserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/AlanKev117/go-grpc/greet/rooms"
+	"github.com/AlanKev117/go-grpc/grpctest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// startRoomServer serves GreetService in memory with rooms buffering buffer
+// events per member. Requests are not validated, so that greetings can be
+// large enough to fill the transport buffers quickly.
+func startRoomServer(t *testing.T, buffer int, policy rooms.Policy) (greetpb.GreetServiceClient, *Server) {
+	t.Helper()
+	srv := New(Options{Rooms: rooms.NewHub(buffer, policy)})
+	return grpctest.Greet(t, srv, grpctest.Options{}), srv
+}
+
+func TestRoomFanOut(t *testing.T) {
+	const clients, greetings = 20, 10
+	c, srv := startRoomServer(t, 4*clients*greetings, rooms.Drop)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	streams := make([]greetpb.GreetService_GreetEveryoneClient, clients)
+	for i := range streams {
+		stream, err := c.GreetEveryone(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := stream.Send(&greetpb.GreetEveryoneRequest{RoomId: "lobby"}); err != nil {
+			t.Fatal(err)
+		}
+		streams[i] = stream
+	}
+	for srv.rooms.Size("lobby") < clients {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, clients)
+	for i, stream := range streams {
+		wg.Add(1)
+		go func(i int, stream greetpb.GreetService_GreetEveryoneClient) {
+			defer wg.Done()
+			errs <- roomClient(i, stream, clients, greetings)
+		}(i, stream)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	for srv.rooms.Size("lobby") > 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// roomClient sends greetings as client i of a room, and checks it receives
+// the greetings of every client before leaving.
+func roomClient(i int, stream greetpb.GreetService_GreetEveryoneClient, clients, greetings int) error {
+	for j := 0; j < greetings; j++ {
+		err := stream.Send(&greetpb.GreetEveryoneRequest{
+			Greeting: &greetpb.Greeting{FirstName: fmt.Sprint("client", i), SecondName: fmt.Sprint(j)},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	received := make(map[string]bool)
+	for len(received) < clients*greetings {
+		res, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("client %d after %d greetings: %v", i, len(received), err)
+		}
+		e := res.GetEvent()
+		if e.GetDropped() > 0 {
+			return fmt.Errorf("client %d missed %d events", i, e.GetDropped())
+		}
+		if e.GetType() == greetpb.RoomEventType_ROOM_EVENT_TYPE_GREETING {
+			if received[res.GetResult()] {
+				return fmt.Errorf("client %d got %q twice", i, res.GetResult())
+			}
+			received[res.GetResult()] = true
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+func TestRoomSlowConsumer(t *testing.T) {
+	c, srv := startRoomServer(t, 4, rooms.Disconnect)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	slow, err := c.GreetEveryone(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slow.Send(&greetpb.GreetEveryoneRequest{RoomId: "lobby"})
+	for srv.rooms.Size("lobby") < 1 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The slow client never reads, so its stream stops taking events once
+	// the transport buffers are full, and then its room buffer fills up.
+	// The fast client reads the echo of each greeting before sending the
+	// next one, so it keeps up.
+	fast, err := c.GreetEveryone(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fast.Send(&greetpb.GreetEveryoneRequest{RoomId: "lobby"})
+	for srv.rooms.Size("lobby") < 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	name := strings.Repeat("x", 10000)
+	for srv.rooms.Size("lobby") != 1 {
+		err := fast.Send(&greetpb.GreetEveryoneRequest{
+			Greeting: &greetpb.Greeting{FirstName: name},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for {
+			res, err := fast.Recv()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.GetEvent().GetType() == greetpb.RoomEventType_ROOM_EVENT_TYPE_GREETING {
+				break
+			}
+		}
+	}
+
+	for {
+		_, err := slow.Recv()
+		if err == nil {
+			continue
+		}
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("slow client ended with %v, want ResourceExhausted", err)
+		}
+		break
+	}
+	fast.CloseSend()
+}
+
+func TestRoomWithSession(t *testing.T) {
+	c, _ := startRoomServer(t, 4, rooms.Drop)
+	stream, err := c.GreetEveryone(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Send(&greetpb.GreetEveryoneRequest{RoomId: "lobby", SessionId: "s1"})
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("joining a room with a session = %v, want InvalidArgument", err)
+	}
+}
+
+func TestRoomWithoutGreeting(t *testing.T) {
+	c, _ := startRoomServer(t, 4, rooms.Drop)
+	stream, err := c.GreetEveryone(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Joining needs no greeting, but the messages after it do.
+	stream.Send(&greetpb.GreetEveryoneRequest{RoomId: "lobby"})
+	stream.Send(&greetpb.GreetEveryoneRequest{})
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("greeting a room without a greeting = %v, want InvalidArgument", err)
+			}
+			return
+		}
+	}
+}
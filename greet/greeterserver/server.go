@@ -0,0 +1,297 @@
+// Package greeterserver serves GreetService over gRPC. Its handlers turn
+// requests into calls of greeter, and record the greetings they send in a
+// history, so programs can serve the service next to their own.
+package greeterserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/greet/greeter"
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/AlanKev117/go-grpc/greet/history"
+	"github.com/AlanKev117/go-grpc/greet/i18n"
+	"github.com/AlanKev117/go-grpc/greet/rooms"
+	"github.com/AlanKev117/go-grpc/greet/templates"
+	"github.com/AlanKev117/go-grpc/session"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Options configures a Server. Zero fields get the defaults of
+// greet_server.
+type Options struct {
+	// Templates renders the greetings of every method; a registry holding
+	// the default template when nil.
+	Templates *templates.Registry
+	// History records every greeting sent; the last 10000 are kept in
+	// memory when nil.
+	History history.Store
+	// Rooms fans out the greetings of GreetEveryone streams joining a
+	// room; 64 events are buffered per member, and dropped past that, when
+	// nil.
+	Rooms *rooms.Hub
+	// SessionTTL is how long a broken GreetEveryone session can be
+	// resumed; 5 minutes when 0.
+	SessionTTL time.Duration
+	// Limits bound the greetings a GreetManyTimes call can ask for;
+	// greeter.DefaultLimits when zero.
+	Limits greeter.Limits
+	// TemplateChanges reports whether CreateTemplate and UpdateTemplate may
+	// change the templates, typically while an authorization policy
+	// restricts who calls them. They fail with FailedPrecondition when it
+	// is nil or returns false.
+	TemplateChanges func() bool
+}
+
+// Server implements GreetService.
+type Server struct {
+	greetpb.UnimplementedGreetServiceServer
+
+	greeter *greeter.Greeter
+	// templates is the registry of greeter, managed by the template
+	// methods.
+	templates *templates.Registry
+	limits    greeter.Limits
+	// sessions holds the state of resumable GreetEveryone streams.
+	sessions *session.Manager
+	// history records every greeting sent.
+	history history.Store
+	// rooms fans out the greetings of GreetEveryone streams joining a room.
+	rooms *rooms.Hub
+	// templateChanges reports whether the templates may be changed.
+	templateChanges func() bool
+	// stopping is closed by Stop, ending the GreetManyTimes calls that
+	// greet until cancelled and the streams of rooms.
+	stopping chan struct{}
+	stopOnce sync.Once
+}
+
+// New returns a Server configured by opts.
+func New(opts Options) *Server {
+	if opts.Templates == nil {
+		opts.Templates = templates.NewRegistry()
+	}
+	if opts.History == nil {
+		opts.History = history.NewMemory(10000)
+	}
+	if opts.Rooms == nil {
+		opts.Rooms = rooms.NewHub(64, rooms.Drop)
+	}
+	if opts.SessionTTL == 0 {
+		opts.SessionTTL = 5 * time.Minute
+	}
+	if opts.Limits == (greeter.Limits{}) {
+		opts.Limits = greeter.DefaultLimits
+	}
+	if opts.TemplateChanges == nil {
+		opts.TemplateChanges = func() bool { return false }
+	}
+	return &Server{
+		greeter:   greeter.New(opts.Templates),
+		templates: opts.Templates,
+		limits:    opts.Limits,
+		sessions:  session.NewManager(opts.SessionTTL),
+		history:   opts.History,
+		rooms:     opts.Rooms,
+		stopping:  make(chan struct{}),
+
+		templateChanges: opts.TemplateChanges,
+	}
+}
+
+// Register registers the service on gs, under its current and legacy
+// names.
+func (s *Server) Register(gs *grpc.Server) {
+	greetpb.RegisterGreetServiceServer(gs, s)
+	gs.RegisterService(&greetpb.LegacyGreetService_ServiceDesc, s)
+}
+
+// Stop ends the GreetManyTimes calls greeting until cancelled and the
+// streams of rooms successfully, so that a graceful stop of the gRPC server
+// does not wait for them forever.
+func (s *Server) Stop() {
+	s.stopOnce.Do(func() { close(s.stopping) })
+}
+
+// Greet returns a response that includes the names provided by the request req.
+// It needs a context as the first argument to work.
+// In case of error, the second value returned will be different to nil.
+func (s *Server) Greet(ctx context.Context, req *greetpb.GreetRequest) (*greetpb.GreetResponse, error) {
+
+	fmt.Printf("Greet called with %v\n", req)
+
+	resultString, locale, err := s.greet(ctx, req.GetGreeting())
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, req.GetGreeting().GetFirstName(), req.GetGreeting().GetSecondName(), resultString, locale)
+	result := &greetpb.GreetResponse{
+		Result: resultString,
+		Locale: locale.String(),
+	}
+
+	return result, nil
+}
+
+// greet greets g in the language asked by the caller. It also returns the
+// locale used.
+func (s *Server) greet(ctx context.Context, g *greetpb.Greeting) (string, *i18n.Locale, error) {
+	result, locale, err := s.greeter.Greet(greeting(ctx, g), 0)
+	return result, locale, greetError(err)
+}
+
+// greeting returns what g asks for, in the languages asked by the caller:
+// the locale of g, else the accept-language metadata of the call.
+func greeting(ctx context.Context, g *greetpb.Greeting) greeter.Greeting {
+	return greeter.Greeting{
+		FirstName:  g.GetFirstName(),
+		SecondName: g.GetSecondName(),
+		Languages:  append([]string{g.GetLocale()}, metadata.ValueFromIncomingContext(ctx, "accept-language")...),
+		Formal:     g.GetFormality() == greetpb.Formality_FORMALITY_FORMAL,
+		TemplateID: g.GetTemplateId(),
+	}
+}
+
+// greetError maps the errors of greeter to statuses. Statuses, like the
+// errors of streams, are returned as is.
+func greetError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.Is(err, templates.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, templates.ErrTooLong), errors.Is(err, greeter.ErrInvalidSchedule):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (s *Server) GreetManyTimes(req *greetpb.GreetManyTimesRequest, stream greetpb.GreetService_GreetManyTimesServer) error {
+
+	fmt.Printf("GreetManyTimes called with: %v\n", req)
+
+	schedule, err := s.schedule(req)
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	g := req.GetGreeting()
+	err = s.greeter.Repeat(ctx, s.stopping, greeting(ctx, g), schedule, func(_ int, result string, locale *i18n.Locale) error {
+		s.record(ctx, g.GetFirstName(), g.GetSecondName(), result, locale)
+		return stream.Send(&greetpb.GreetManyTimesResponse{
+			Result: result,
+		})
+	})
+	return greetError(err)
+}
+
+// schedule returns the schedule req asks for, the default one for the
+// fields it leaves unset.
+func (s *Server) schedule(req *greetpb.GreetManyTimesRequest) (greeter.Schedule, error) {
+	schedule := greeter.DefaultSchedule
+	schedule.Forever = req.GetUntilCancelled()
+	if req.GetCount() > 0 {
+		schedule.Count = int(req.GetCount())
+	}
+	if req.Interval != nil {
+		if err := req.GetInterval().CheckValid(); err != nil {
+			return schedule, status.Errorf(codes.InvalidArgument, "interval: %v", err)
+		}
+		schedule.Interval = req.GetInterval().AsDuration()
+	}
+	if req.Jitter != nil {
+		if err := req.GetJitter().CheckValid(); err != nil {
+			return schedule, status.Errorf(codes.InvalidArgument, "jitter: %v", err)
+		}
+		schedule.Jitter = req.GetJitter().AsDuration()
+	}
+	return schedule, greetError(s.limits.Check(schedule))
+}
+
+func (s *Server) LongGreet(stream greetpb.GreetService_LongGreetServer) error {
+	fmt.Println("LongGreet called with client streaming request...")
+	greetings := []*greetpb.Greeting{}
+	var opts *greetpb.LongGreetOptions
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			if len(greetings) == 0 {
+				return status.Error(codes.InvalidArgument, "no greetings received")
+			}
+			res, err := s.greetGroup(stream.Context(), greetings, opts)
+			if err != nil {
+				return err
+			}
+			return stream.SendAndClose(res)
+		}
+		if err != nil {
+			log.Printf("error while reading from client stream: %v", err)
+			return err
+		}
+		if len(greetings) == 0 {
+			opts = req.GetOptions()
+		}
+		greetings = append(greetings, req.GetGreeting())
+	}
+}
+
+// errNoGreeting is returned for GreetEveryone messages without a greeting
+// that neither join a room nor attach a session.
+var errNoGreeting = status.Error(codes.InvalidArgument, "greeting is required")
+
+func (s *Server) GreetEveryone(stream greetpb.GreetService_GreetEveryoneServer) error {
+	fmt.Println("GreetEveryone called with client streaming request...")
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			log.Printf("Error while reading client stream during bidi streaming: %v", err)
+			return err
+		}
+
+		// Clients joining a room or opening a session hand the stream
+		// over to the matching implementation.
+		if req.GetRoomId() != "" {
+			return s.greetEveryoneRoom(stream, req)
+		}
+		if req.GetSessionId() != "" {
+			return s.greetEveryoneSession(stream, req)
+		}
+
+		if req.GetGreeting() == nil {
+			return errNoGreeting
+		}
+		result, locale, err := s.greet(stream.Context(), req.GetGreeting())
+		if err != nil {
+			return err
+		}
+		s.record(stream.Context(), req.GetGreeting().GetFirstName(), req.GetGreeting().GetSecondName(), result, locale)
+
+		err = stream.Send(&greetpb.GreetEveryoneResponse{
+			Result: result,
+		})
+
+		if err != nil {
+			log.Printf("Error while sending greeting to client: %v", err)
+			return err
+		}
+	}
+}
@@ -0,0 +1,729 @@
+package greeterserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/auth"
+	"github.com/AlanKev117/go-grpc/greet/greeter"
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/AlanKev117/go-grpc/greet/history"
+	"github.com/AlanKev117/go-grpc/greet/rooms"
+	"github.com/AlanKev117/go-grpc/greet/templates"
+	"github.com/AlanKev117/go-grpc/grpctest"
+	"github.com/AlanKev117/go-grpc/validate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newTestServer returns a server keeping everything in memory, with the
+// limits of GreetManyTimes lowered to keep tests fast.
+func newTestServer() *Server {
+	return New(Options{
+		History: history.NewMemory(100),
+		Rooms:   rooms.NewHub(16, rooms.Drop),
+		Limits:  greeter.Limits{MaxCount: 100, MaxInterval: time.Second},
+
+		TemplateChanges: func() bool { return true },
+	})
+}
+
+// startServer serves srv in memory behind the validation interceptors of
+// the real server, and returns a client of it.
+func startServer(t testing.TB, srv *Server) greetpb.GreetServiceClient {
+	t.Helper()
+	return grpctest.Greet(t, srv, grpctest.Options{ServerOptions: validate.ServerOptions()})
+}
+
+// testContext returns a context ending with the test.
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestGreet(t *testing.T) {
+	c := startServer(t, newTestServer())
+	tests := []struct {
+		name       string
+		greeting   *greetpb.Greeting
+		language   string
+		want       string
+		wantLocale string
+	}{
+		{"default", &greetpb.Greeting{FirstName: "Alan", SecondName: "Kevin"}, "", "Hello, Alan Kevin", "en"},
+		{"first name only", &greetpb.Greeting{FirstName: "Alan"}, "", "Hello, Alan", "en"},
+		{"formal", &greetpb.Greeting{FirstName: "Alan", SecondName: "Kevin", Formality: greetpb.Formality_FORMALITY_FORMAL}, "", "Good day, Alan Kevin", "en"},
+		{"locale", &greetpb.Greeting{FirstName: "Alan", Locale: "es"}, "", "Hola, Alan", "es"},
+		{"accept-language", &greetpb.Greeting{FirstName: "Alan"}, "es-MX", "Hola, Alan", "es"},
+		{"locale before accept-language", &greetpb.Greeting{FirstName: "Alan", Locale: "en"}, "es", "Hello, Alan", "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testContext(t)
+			if tt.language != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "accept-language", tt.language)
+			}
+			res, err := c.Greet(ctx, &greetpb.GreetRequest{Greeting: tt.greeting})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.GetResult() != tt.want || res.GetLocale() != tt.wantLocale {
+				t.Errorf("Greet = %q in %q, want %q in %q", res.GetResult(), res.GetLocale(), tt.want, tt.wantLocale)
+			}
+		})
+	}
+}
+
+func TestGreetErrors(t *testing.T) {
+	c := startServer(t, newTestServer())
+	tests := []struct {
+		name string
+		req  *greetpb.GreetRequest
+		want codes.Code
+	}{
+		{"no greeting", &greetpb.GreetRequest{}, codes.InvalidArgument},
+		{"no first name", &greetpb.GreetRequest{Greeting: &greetpb.Greeting{SecondName: "Kevin"}}, codes.InvalidArgument},
+		{"undefined formality", &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "Alan", Formality: 7}}, codes.InvalidArgument},
+		{"invalid template ID", &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "Alan", TemplateId: "Bad ID"}}, codes.InvalidArgument},
+		{"unknown template", &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "Alan", TemplateId: "missing"}}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.Greet(testContext(t), tt.req)
+			if status.Code(err) != tt.want {
+				t.Errorf("Greet = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// receiveAll returns the results of a GreetManyTimes stream, and the error
+// ending it, nil at the end of the stream.
+func receiveAll(stream greetpb.GreetService_GreetManyTimesClient) ([]string, error) {
+	var results []string
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return results, err
+		}
+		results = append(results, res.GetResult())
+	}
+}
+
+// TestLegacyFullMethod checks that interceptors see the name clients
+// called, under the legacy package or the new one, for unary calls and
+// streams alike.
+func TestLegacyFullMethod(t *testing.T) {
+	var called []string
+	record := grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, h grpc.UnaryHandler) (any, error) {
+		called = append(called, info.FullMethod)
+		return h(ctx, req)
+	})
+	recordStream := grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, h grpc.StreamHandler) error {
+		called = append(called, info.FullMethod)
+		return h(srv, ss)
+	})
+	conn := grpctest.Serve(t, newTestServer().Register, grpctest.Options{ServerOptions: []grpc.ServerOption{record, recordStream}})
+	ctx := testContext(t)
+
+	greet := &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "Alan"}}
+	for _, method := range []string{"/greet.GreetService/Greet", greetpb.GreetService_Greet_FullMethodName} {
+		if err := conn.Invoke(ctx, method, greet, new(greetpb.GreetResponse)); err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+	}
+	stream, err := conn.NewStream(ctx, &greetpb.LegacyGreetService_ServiceDesc.Streams[0], "/greet.GreetService/GreetManyTimes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.SendMsg(&greetpb.GreetManyTimesRequest{Greeting: greet.Greeting, Count: 1}); err != nil {
+		t.Fatal(err)
+	}
+	stream.CloseSend()
+	if err := stream.RecvMsg(new(greetpb.GreetManyTimesResponse)); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.RecvMsg(new(greetpb.GreetManyTimesResponse)); err != io.EOF {
+		t.Fatalf("RecvMsg after the last greeting = %v, want io.EOF", err)
+	}
+
+	want := []string{"/greet.GreetService/Greet", greetpb.GreetService_Greet_FullMethodName, "/greet.GreetService/GreetManyTimes"}
+	if fmt.Sprint(called) != fmt.Sprint(want) {
+		t.Errorf("interceptors saw %q, want %q", called, want)
+	}
+
+	// Methods added after the move to greet.v1 have no legacy name.
+	create := &greetpb.CreateTemplateRequest{Template: &greetpb.Template{Id: "legacy", Text: "{{.Greeting}}"}}
+	if err := conn.Invoke(ctx, "/greet.GreetService/CreateTemplate", create, new(greetpb.Template)); status.Code(err) != codes.Unimplemented {
+		t.Errorf("legacy CreateTemplate = %v, want Unimplemented", err)
+	}
+}
+
+func TestGreetManyTimes(t *testing.T) {
+	c := startServer(t, newTestServer())
+	stream, err := c.GreetManyTimes(testContext(t), &greetpb.GreetManyTimesRequest{
+		Greeting: &greetpb.Greeting{FirstName: "Alan"},
+		Count:    3,
+		Interval: durationpb.New(time.Millisecond),
+		Jitter:   durationpb.New(time.Millisecond),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := receiveAll(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Hello, Alan (1)", "Hello, Alan (2)", "Hello, Alan (3)"}
+	if fmt.Sprint(results) != fmt.Sprint(want) {
+		t.Errorf("GreetManyTimes = %q, want %q", results, want)
+	}
+}
+
+func TestGreetManyTimesDefaults(t *testing.T) {
+	if testing.Short() {
+		t.Skip("sends greetings for two seconds")
+	}
+	c := startServer(t, newTestServer())
+	stream, err := c.GreetManyTimes(testContext(t), &greetpb.GreetManyTimesRequest{
+		Greeting: &greetpb.Greeting{FirstName: "Alan"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	results, err := receiveAll(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != greeter.DefaultSchedule.Count {
+		t.Errorf("GreetManyTimes sent %d greetings, want %d", len(results), greeter.DefaultSchedule.Count)
+	}
+	if elapsed, want := time.Since(start), time.Duration(greeter.DefaultSchedule.Count-1)*greeter.DefaultSchedule.Interval; elapsed < want {
+		t.Errorf("GreetManyTimes took %v, want at least %v", elapsed, want)
+	}
+}
+
+func TestGreetManyTimesErrors(t *testing.T) {
+	c := startServer(t, newTestServer())
+	greeting := &greetpb.Greeting{FirstName: "Alan"}
+	tests := []struct {
+		name string
+		req  *greetpb.GreetManyTimesRequest
+		want codes.Code
+	}{
+		{"no greeting", &greetpb.GreetManyTimesRequest{}, codes.InvalidArgument},
+		{"count above the maximum", &greetpb.GreetManyTimesRequest{Greeting: greeting, Count: 101}, codes.InvalidArgument},
+		{"zero interval", &greetpb.GreetManyTimesRequest{Greeting: greeting, Interval: durationpb.New(0)}, codes.InvalidArgument},
+		{"interval above the maximum", &greetpb.GreetManyTimesRequest{Greeting: greeting, Interval: durationpb.New(2 * time.Second)}, codes.InvalidArgument},
+		{"jitter above the interval", &greetpb.GreetManyTimesRequest{Greeting: greeting, Interval: durationpb.New(time.Millisecond), Jitter: durationpb.New(time.Second)}, codes.InvalidArgument},
+		{"negative jitter", &greetpb.GreetManyTimesRequest{Greeting: greeting, Jitter: durationpb.New(-time.Millisecond)}, codes.InvalidArgument},
+		{"unknown template", &greetpb.GreetManyTimesRequest{Greeting: &greetpb.Greeting{FirstName: "Alan", TemplateId: "missing"}}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := c.GreetManyTimes(testContext(t), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			results, err := receiveAll(stream)
+			if status.Code(err) != tt.want || len(results) > 0 {
+				t.Errorf("GreetManyTimes = %q, %v, want %v", results, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGreetManyTimesUntilCancelled(t *testing.T) {
+	srv := newTestServer()
+	c := startServer(t, srv)
+	req := &greetpb.GreetManyTimesRequest{
+		Greeting:       &greetpb.Greeting{FirstName: "Alan"},
+		Interval:       durationpb.New(time.Millisecond),
+		UntilCancelled: true,
+	}
+
+	t.Run("client cancels", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(testContext(t))
+		stream, err := c.GreetManyTimes(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// More greetings than the default count arrive before cancelling.
+		for i := 0; i < 2*greeter.DefaultSchedule.Count; i++ {
+			if _, err := stream.Recv(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		cancel()
+		if _, err := receiveAll(stream); status.Code(err) != codes.Canceled {
+			t.Errorf("GreetManyTimes after cancelling = %v, want Canceled", err)
+		}
+	})
+
+	t.Run("server stops", func(t *testing.T) {
+		stream, err := c.GreetManyTimes(testContext(t), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stream.Recv(); err != nil {
+			t.Fatal(err)
+		}
+		srv.Stop()
+		if _, err := receiveAll(stream); err != nil {
+			t.Errorf("GreetManyTimes after stopping = %v, want the end of the stream", err)
+		}
+	})
+}
+
+// longGreet sends greetings on a LongGreet stream, the first with opts.
+func longGreet(ctx context.Context, c greetpb.GreetServiceClient, opts *greetpb.LongGreetOptions, greetings ...*greetpb.Greeting) (*greetpb.LongGreetResponse, error) {
+	stream, err := c.LongGreet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i, g := range greetings {
+		req := &greetpb.LongGreetRequest{Greeting: g}
+		if i == 0 {
+			req.Options = opts
+		}
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+func TestLongGreet(t *testing.T) {
+	c := startServer(t, newTestServer())
+	people := []*greetpb.Greeting{
+		{FirstName: "Luis", SecondName: "García"},
+		{FirstName: "Alan", SecondName: "Turing"},
+		{FirstName: "Ana", SecondName: "García"},
+		{FirstName: "Luis", SecondName: "García"},
+		{FirstName: "Émile", SecondName: "Zola"},
+	}
+	tests := []struct {
+		name       string
+		opts       *greetpb.LongGreetOptions
+		want       string
+		wantPeople int
+		wantOthers uint32
+	}{
+		{"as sent", nil, "Hello, Luis García, Alan Turing, Ana García, Luis García, Émile Zola", 5, 0},
+		{"dedupe", &greetpb.LongGreetOptions{Dedupe: true}, "Hello, Luis García, Alan Turing, Ana García, Émile Zola", 4, 0},
+		{"sort", &greetpb.LongGreetOptions{Dedupe: true, Sort: true}, "Hello, Ana García, Luis García, Alan Turing, Émile Zola", 4, 0},
+		{"group", &greetpb.LongGreetOptions{Dedupe: true, Sort: true, GroupBySecondName: true, ListJoin: true}, "Hello, Ana and Luis García, Alan Turing and Émile Zola", 4, 0},
+		{"max names", &greetpb.LongGreetOptions{Dedupe: true, MaxNames: 2}, "Hello, Luis García, Alan Turing and 2 others", 4, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := longGreet(testContext(t), c, tt.opts, people...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.GetResult() != tt.want || len(res.GetPeople()) != tt.wantPeople || res.GetOthers() != tt.wantOthers || res.GetLocale() != "en" {
+				t.Errorf("LongGreet = %q with %d people, %d others in %q, want %q with %d people, %d others in en",
+					res.GetResult(), len(res.GetPeople()), res.GetOthers(), res.GetLocale(), tt.want, tt.wantPeople, tt.wantOthers)
+			}
+		})
+	}
+}
+
+func TestLongGreetErrors(t *testing.T) {
+	c := startServer(t, newTestServer())
+
+	t.Run("empty stream", func(t *testing.T) {
+		if _, err := longGreet(testContext(t), c, nil); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("LongGreet of no greetings = %v, want InvalidArgument", err)
+		}
+	})
+	t.Run("invalid greeting", func(t *testing.T) {
+		_, err := longGreet(testContext(t), c, nil, &greetpb.Greeting{FirstName: "Alan"}, &greetpb.Greeting{})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("LongGreet with an invalid greeting = %v, want InvalidArgument", err)
+		}
+	})
+}
+
+func TestGreetEveryone(t *testing.T) {
+	c := startServer(t, newTestServer())
+	stream, err := c.GreetEveryone(testContext(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Alan", "Ana"} {
+		if err := stream.Send(&greetpb.GreetEveryoneRequest{Greeting: &greetpb.Greeting{FirstName: name}}); err != nil {
+			t.Fatal(err)
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "Hello, " + name; res.GetResult() != want {
+			t.Errorf("GreetEveryone = %q, want %q", res.GetResult(), want)
+		}
+	}
+	stream.CloseSend()
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("GreetEveryone after closing = %v, want the end of the stream", err)
+	}
+}
+
+func TestGreetEveryoneHalfClose(t *testing.T) {
+	c := startServer(t, newTestServer())
+
+	t.Run("before sending", func(t *testing.T) {
+		stream, err := c.GreetEveryone(testContext(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream.CloseSend()
+		if _, err := stream.Recv(); err != io.EOF {
+			t.Errorf("GreetEveryone = %v, want the end of the stream", err)
+		}
+	})
+
+	// Greetings sent before closing are all answered.
+	t.Run("before receiving", func(t *testing.T) {
+		stream, err := c.GreetEveryone(testContext(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 3; i++ {
+			if err := stream.Send(&greetpb.GreetEveryoneRequest{Greeting: &greetpb.Greeting{FirstName: fmt.Sprint("n", i)}}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		stream.CloseSend()
+		for i := 0; i < 3; i++ {
+			res, err := stream.Recv()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := fmt.Sprint("Hello, n", i); res.GetResult() != want {
+				t.Errorf("greeting %d = %q, want %q", i, res.GetResult(), want)
+			}
+		}
+		if _, err := stream.Recv(); err != io.EOF {
+			t.Errorf("GreetEveryone = %v, want the end of the stream", err)
+		}
+	})
+}
+
+func TestGreetEveryoneErrors(t *testing.T) {
+	c := startServer(t, newTestServer())
+	tests := []struct {
+		name string
+		req  *greetpb.GreetEveryoneRequest
+		want codes.Code
+	}{
+		{"no greeting", &greetpb.GreetEveryoneRequest{}, codes.InvalidArgument},
+		{"invalid greeting", &greetpb.GreetEveryoneRequest{Greeting: &greetpb.Greeting{}}, codes.InvalidArgument},
+		{"session greeting without greeting", &greetpb.GreetEveryoneRequest{SessionId: "s1", Sequence: 1}, codes.InvalidArgument},
+		{"invalid room ID", &greetpb.GreetEveryoneRequest{RoomId: "a room"}, codes.InvalidArgument},
+		{"unknown template", &greetpb.GreetEveryoneRequest{Greeting: &greetpb.Greeting{FirstName: "Alan", TemplateId: "missing"}}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := c.GreetEveryone(testContext(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			stream.Send(tt.req)
+			if _, err := stream.Recv(); status.Code(err) != tt.want {
+				t.Errorf("GreetEveryone = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGreetEveryoneSession(t *testing.T) {
+	c := startServer(t, newTestServer())
+	greet := func(name string, sequence uint64) *greetpb.GreetEveryoneRequest {
+		return &greetpb.GreetEveryoneRequest{
+			Greeting:  &greetpb.Greeting{FirstName: name},
+			SessionId: "s1",
+			Sequence:  sequence,
+		}
+	}
+
+	// The first stream sends three greetings but only reads the first
+	// answer before breaking.
+	ctx, cancel := context.WithCancel(testContext(t))
+	stream, err := c.GreetEveryone(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, name := range []string{"Alan", "Ana", "Luis"} {
+		if err := stream.Send(greet(name, uint64(i+1))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetResult() != "Hello, Alan" || res.GetSequence() != 1 || res.GetSessionId() != "s1" {
+		t.Fatalf("first answer = %v", res)
+	}
+	// The server answered every greeting once the others arrive.
+	for i := 2; i <= 3; i++ {
+		if res, err := stream.Recv(); err != nil || res.GetSequence() != uint64(i) {
+			t.Fatalf("answer %d = %v, %v", i, res, err)
+		}
+	}
+	cancel()
+
+	// Resuming after sequence 1 replays the greetings of 2 and 3, then
+	// acknowledges 3; resending 3 is not answered again.
+	stream, err = c.GreetEveryone(testContext(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resume := greet("Luis", 3)
+	resume.LastReceivedSequence = 1
+	if err := stream.Send(resume); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.GetAckOnly() {
+			if res.GetSequence() != 3 {
+				t.Errorf("acknowledged sequence %d, want 3", res.GetSequence())
+			}
+			break
+		}
+		got = append(got, res.GetResult())
+	}
+	if want := []string{"Hello, Ana", "Hello, Luis"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("replayed %q, want %q", got, want)
+	}
+
+	if err := stream.Send(greet("Émile", 4)); err != nil {
+		t.Fatal(err)
+	}
+	if res, err := stream.Recv(); err != nil || res.GetResult() != "Hello, Émile" || res.GetSequence() != 4 {
+		t.Errorf("answer after resuming = %v, %v", res, err)
+	}
+	stream.CloseSend()
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("GreetEveryone = %v, want the end of the stream", err)
+	}
+}
+
+// nameVerifier authenticates the callers whose token is their name.
+type nameVerifier struct{}
+
+func (nameVerifier) Verify(token string) (*auth.Principal, error) {
+	return &auth.Principal{Name: token, Source: "test"}, nil
+}
+
+func TestGreetEveryoneSessionOwner(t *testing.T) {
+	a := auth.NewAuthenticator(nameVerifier{})
+	c := grpctest.Greet(t, newTestServer(), grpctest.Options{ServerOptions: []grpc.ServerOption{
+		grpc.ChainStreamInterceptor(a.StreamServerInterceptor()),
+	}})
+	as := func(name string) context.Context {
+		return metadata.AppendToOutgoingContext(testContext(t), "authorization", "Bearer "+name)
+	}
+	open := func(name string) greetpb.GreetService_GreetEveryoneClient {
+		t.Helper()
+		stream, err := c.GreetEveryone(as(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := &greetpb.GreetEveryoneRequest{Greeting: &greetpb.Greeting{FirstName: name}, SessionId: "s1", Sequence: 1}
+		if err := stream.Send(req); err != nil {
+			t.Fatal(err)
+		}
+		return stream
+	}
+
+	alice := open("alice")
+	if _, err := alice.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := open("bob").Recv(); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("GreetEveryone of another caller's session = %v, want PermissionDenied", err)
+	}
+	// The session of alice was not superseded.
+	alice.CloseSend()
+	if _, err := alice.Recv(); err != io.EOF {
+		t.Errorf("GreetEveryone of the session creator = %v, want the end of the stream", err)
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	c := startServer(t, newTestServer())
+	ctx := testContext(t)
+
+	list, err := c.ListTemplates(ctx, &greetpb.ListTemplatesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.GetTemplates()) != 1 || list.GetTemplates()[0].GetId() != templates.DefaultID {
+		t.Errorf("ListTemplates = %v, want the default template", list.GetTemplates())
+	}
+
+	created, err := c.CreateTemplate(ctx, &greetpb.CreateTemplateRequest{
+		Template: &greetpb.Template{Id: "shout", Text: "{{.Greeting}}!"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.GetId() != "shout" || created.GetUpdateTime() == nil {
+		t.Errorf("CreateTemplate = %v", created)
+	}
+	greet := func() string {
+		t.Helper()
+		res, err := c.Greet(ctx, &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "Alan", TemplateId: "shout"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.GetResult()
+	}
+	if got := greet(); got != "Hello, Alan!" {
+		t.Errorf("Greet with the new template = %q", got)
+	}
+
+	if _, err := c.UpdateTemplate(ctx, &greetpb.UpdateTemplateRequest{
+		Template: &greetpb.Template{Id: "shout", Text: "{{.Greeting}}!!!"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := greet(); got != "Hello, Alan!!!" {
+		t.Errorf("Greet with the updated template = %q", got)
+	}
+
+	list, err = c.ListTemplates(ctx, &greetpb.ListTemplatesRequest{})
+	if err != nil || len(list.GetTemplates()) != 2 {
+		t.Errorf("ListTemplates = %v, %v, want 2 templates", list.GetTemplates(), err)
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	c := startServer(t, newTestServer())
+	ctx := testContext(t)
+
+	create := func(id, text string) error {
+		_, err := c.CreateTemplate(ctx, &greetpb.CreateTemplateRequest{Template: &greetpb.Template{Id: id, Text: text}})
+		return err
+	}
+	update := func(id, text string) error {
+		_, err := c.UpdateTemplate(ctx, &greetpb.UpdateTemplateRequest{Template: &greetpb.Template{Id: id, Text: text}})
+		return err
+	}
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"create existing", create(templates.DefaultID, "{{.Greeting}}"), codes.AlreadyExists},
+		{"create with an invalid ID", create("Bad ID", "{{.Greeting}}"), codes.InvalidArgument},
+		{"create without text", create("empty", ""), codes.InvalidArgument},
+		{"create unparsable", create("broken", "{{.Greeting"), codes.InvalidArgument},
+		{"update missing", update("missing", "{{.Greeting}}"), codes.NotFound},
+		{"update unparsable", update(templates.DefaultID, "{{end}}"), codes.InvalidArgument},
+		{"create calling itself", create("loop", `{{template "loop" .}}`), codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		if status.Code(tt.err) != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.err, tt.want)
+		}
+	}
+}
+
+func TestTemplateChangesDisabled(t *testing.T) {
+	c := startServer(t, New(Options{}))
+	ctx := testContext(t)
+
+	_, err := c.CreateTemplate(ctx, &greetpb.CreateTemplateRequest{Template: &greetpb.Template{Id: "shout", Text: "{{.Greeting}}!"}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CreateTemplate = %v, want FailedPrecondition", err)
+	}
+	_, err = c.UpdateTemplate(ctx, &greetpb.UpdateTemplateRequest{Template: &greetpb.Template{Id: templates.DefaultID, Text: "{{.Greeting}}!"}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("UpdateTemplate = %v, want FailedPrecondition", err)
+	}
+	if _, err := c.ListTemplates(ctx, &greetpb.ListTemplatesRequest{}); err != nil {
+		t.Errorf("ListTemplates = %v", err)
+	}
+}
+
+func TestListGreetings(t *testing.T) {
+	c := startServer(t, newTestServer())
+	ctx := testContext(t)
+
+	start := time.Now()
+	for _, name := range []string{"Alan", "Ana", "Luis"} {
+		if _, err := c.Greet(ctx, &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: name, SecondName: "García"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := longGreet(ctx, c, nil, &greetpb.Greeting{FirstName: "Émile", SecondName: "Zola"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	req := &greetpb.ListGreetingsRequest{Name: "garcía", PageSize: 2, StartTime: timestamppb.New(start)}
+	for {
+		res, err := c.ListGreetings(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, g := range res.GetGreetings() {
+			if g.GetMethod() != "/greet.v1.GreetService/Greet" || g.GetCaller() == "" || g.GetLocale() != "en" {
+				t.Errorf("record = %v", g)
+			}
+			got = append(got, g.GetResult())
+		}
+		if res.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = res.GetNextPageToken()
+	}
+	want := []string{"Hello, Luis García", "Hello, Ana García", "Hello, Alan García"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ListGreetings = %q, want %q", got, want)
+	}
+
+	res, err := c.ListGreetings(ctx, &greetpb.ListGreetingsRequest{Name: "Zola"})
+	if err != nil || len(res.GetGreetings()) != 1 || res.GetGreetings()[0].GetMethod() != "/greet.v1.GreetService/LongGreet" {
+		t.Errorf("ListGreetings of LongGreet = %v, %v", res.GetGreetings(), err)
+	}
+}
+
+func TestListGreetingsErrors(t *testing.T) {
+	c := startServer(t, newTestServer())
+	tests := []struct {
+		name string
+		req  *greetpb.ListGreetingsRequest
+	}{
+		{"negative page size", &greetpb.ListGreetingsRequest{PageSize: -1}},
+		{"invalid page token", &greetpb.ListGreetingsRequest{PageToken: "not a token"}},
+		{"invalid start time", &greetpb.ListGreetingsRequest{StartTime: &timestamppb.Timestamp{Nanos: -1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.ListGreetings(testContext(t), tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("ListGreetings = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package greeterserver
+
+import (
+	"io"
+	"log"
+
+	"github.com/AlanKev117/go-grpc/auth"
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/AlanKev117/go-grpc/greet/i18n"
+	"github.com/AlanKev117/go-grpc/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// replayLimit is how many greetings a session keeps for clients that
+// reconnect before receiving them.
+const replayLimit = 64
+
+// greetingState is the session state of a GreetEveryone stream.
+type greetingState struct {
+	// sent holds the last greetings sent, oldest first, so they can be
+	// sent again after a reconnection.
+	sent []*greetpb.GreetEveryoneResponse
+}
+
+// greetEveryoneSession serves a GreetEveryone stream whose first message req
+// opened or resumed a session. Every greeting carries the sequence of the
+// request it answers; a client that lost its connection reconnects with the
+// same session ID and the last sequence it received, gets the greetings it
+// missed and resends the requests that were not answered.
+func (s *Server) greetEveryoneSession(stream greetpb.GreetService_GreetEveryoneServer, req *greetpb.GreetEveryoneRequest) error {
+	sess, resumed, err := s.sessions.Attach(req.GetSessionId(), auth.Owner(stream.Context()))
+	if err == session.ErrNotOwner {
+		return status.Errorf(codes.PermissionDenied, "session %s: %v", req.GetSessionId(), err)
+	}
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if resumed {
+		log.Printf("Resuming GreetEveryone session %s", sess.ID())
+		var replay []*greetpb.GreetEveryoneResponse
+		sess.Do(func(st *session.State) {
+			if g, ok := st.Value.(*greetingState); ok {
+				for _, res := range g.sent {
+					if res.GetSequence() > req.GetLastReceivedSequence() {
+						replay = append(replay, res)
+					}
+				}
+			}
+			// Tell the client which requests were processed, so it only
+			// resends the others.
+			replay = append(replay, &greetpb.GreetEveryoneResponse{
+				SessionId: sess.ID(),
+				Sequence:  st.LastSequence,
+				AckOnly:   true,
+			})
+		})
+		for _, res := range replay {
+			if err := stream.Send(res); err != nil {
+				sess.Detach()
+				return err
+			}
+		}
+	}
+
+	for {
+		if req.GetSequence() != 0 && req.GetGreeting() == nil {
+			sess.Detach()
+			return errNoGreeting
+		}
+		var res *greetpb.GreetEveryoneResponse
+		var locale *i18n.Locale
+		var greetErr error
+		err = sess.Do(func(st *session.State) {
+			// Sequence 0 only attaches the stream, and requests sent again
+			// after a reconnection were already answered.
+			if req.GetSequence() == 0 || req.GetSequence() <= st.LastSequence {
+				return
+			}
+			var result string
+			result, locale, greetErr = s.greet(stream.Context(), req.GetGreeting())
+			if greetErr != nil {
+				return
+			}
+			g, ok := st.Value.(*greetingState)
+			if !ok {
+				g = &greetingState{}
+				st.Value = g
+			}
+			st.LastSequence = req.GetSequence()
+
+			res = &greetpb.GreetEveryoneResponse{
+				Result:    result,
+				SessionId: sess.ID(),
+				Sequence:  req.GetSequence(),
+			}
+			g.sent = append(g.sent, res)
+			if len(g.sent) > replayLimit {
+				g.sent = g.sent[len(g.sent)-replayLimit:]
+			}
+		})
+		if err != nil {
+			return status.Error(codes.Aborted, err.Error())
+		}
+		if greetErr != nil {
+			sess.Detach()
+			return greetErr
+		}
+
+		if res != nil {
+			s.record(stream.Context(), req.GetGreeting().GetFirstName(), req.GetGreeting().GetSecondName(), res.GetResult(), locale)
+			if err := stream.Send(res); err != nil {
+				log.Printf("Error while sending greeting to client: %v", err)
+				sess.Detach()
+				return err
+			}
+		}
+
+		req, err = stream.Recv()
+		if err == io.EOF {
+			sess.Close()
+			return nil
+		}
+		if err != nil {
+			log.Printf("Session %s interrupted: %v", sess.ID(), err)
+			sess.Detach()
+			return err
+		}
+	}
+}
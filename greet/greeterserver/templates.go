@@ -0,0 +1,68 @@
+package greeterserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/AlanKev117/go-grpc/greet/templates"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) ListTemplates(ctx context.Context, req *greetpb.ListTemplatesRequest) (*greetpb.ListTemplatesResponse, error) {
+	res := &greetpb.ListTemplatesResponse{}
+	for _, t := range s.templates.List() {
+		res.Templates = append(res.Templates, templateProto(t))
+	}
+	return res, nil
+}
+
+// errTemplateChanges is returned by CreateTemplate and UpdateTemplate while
+// template changes are disabled.
+var errTemplateChanges = status.Error(codes.FailedPrecondition,
+	"template changes are disabled until an authorization policy restricts CreateTemplate and UpdateTemplate")
+
+func (s *Server) CreateTemplate(ctx context.Context, req *greetpb.CreateTemplateRequest) (*greetpb.Template, error) {
+	if !s.templateChanges() {
+		return nil, errTemplateChanges
+	}
+	t, err := s.templates.Create(req.GetTemplate().GetId(), req.GetTemplate().GetText())
+	if err != nil {
+		return nil, templateError(err)
+	}
+	return templateProto(t), nil
+}
+
+func (s *Server) UpdateTemplate(ctx context.Context, req *greetpb.UpdateTemplateRequest) (*greetpb.Template, error) {
+	if !s.templateChanges() {
+		return nil, errTemplateChanges
+	}
+	t, err := s.templates.Update(req.GetTemplate().GetId(), req.GetTemplate().GetText())
+	if err != nil {
+		return nil, templateError(err)
+	}
+	return templateProto(t), nil
+}
+
+func templateProto(t *templates.Template) *greetpb.Template {
+	return &greetpb.Template{
+		Id:         t.ID,
+		Text:       t.Text,
+		UpdateTime: timestamppb.New(t.UpdateTime),
+	}
+}
+
+// templateError maps the errors of the template registry to statuses.
+func templateError(err error) error {
+	switch {
+	case errors.Is(err, templates.ErrInvalid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, templates.ErrExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, templates.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Errorf(codes.Internal, "saving template: %v", err)
+}
@@ -1,20 +1,21 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.26.0
-// 	protoc        v3.15.8
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
 // source: greet/greetpb/greet.proto
 
 package greetpb
 
 import (
-	context "context"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -24,22 +25,129 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type Greeting struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+type Formality int32
+
+const (
+	// Greets informally.
+	Formality_FORMALITY_UNSPECIFIED Formality = 0
+	Formality_FORMALITY_INFORMAL    Formality = 1
+	Formality_FORMALITY_FORMAL      Formality = 2
+)
+
+// Enum value maps for Formality.
+var (
+	Formality_name = map[int32]string{
+		0: "FORMALITY_UNSPECIFIED",
+		1: "FORMALITY_INFORMAL",
+		2: "FORMALITY_FORMAL",
+	}
+	Formality_value = map[string]int32{
+		"FORMALITY_UNSPECIFIED": 0,
+		"FORMALITY_INFORMAL":    1,
+		"FORMALITY_FORMAL":      2,
+	}
+)
+
+func (x Formality) Enum() *Formality {
+	p := new(Formality)
+	*p = x
+	return p
+}
+
+func (x Formality) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Formality) Descriptor() protoreflect.EnumDescriptor {
+	return file_greet_greetpb_greet_proto_enumTypes[0].Descriptor()
+}
+
+func (Formality) Type() protoreflect.EnumType {
+	return &file_greet_greetpb_greet_proto_enumTypes[0]
+}
+
+func (x Formality) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Formality.Descriptor instead.
+func (Formality) EnumDescriptor() ([]byte, []int) {
+	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{0}
+}
+
+type RoomEventType int32
+
+const (
+	RoomEventType_ROOM_EVENT_TYPE_UNSPECIFIED RoomEventType = 0
+	RoomEventType_ROOM_EVENT_TYPE_JOINED      RoomEventType = 1
+	RoomEventType_ROOM_EVENT_TYPE_LEFT        RoomEventType = 2
+	RoomEventType_ROOM_EVENT_TYPE_GREETING    RoomEventType = 3
+)
+
+// Enum value maps for RoomEventType.
+var (
+	RoomEventType_name = map[int32]string{
+		0: "ROOM_EVENT_TYPE_UNSPECIFIED",
+		1: "ROOM_EVENT_TYPE_JOINED",
+		2: "ROOM_EVENT_TYPE_LEFT",
+		3: "ROOM_EVENT_TYPE_GREETING",
+	}
+	RoomEventType_value = map[string]int32{
+		"ROOM_EVENT_TYPE_UNSPECIFIED": 0,
+		"ROOM_EVENT_TYPE_JOINED":      1,
+		"ROOM_EVENT_TYPE_LEFT":        2,
+		"ROOM_EVENT_TYPE_GREETING":    3,
+	}
+)
+
+func (x RoomEventType) Enum() *RoomEventType {
+	p := new(RoomEventType)
+	*p = x
+	return p
+}
+
+func (x RoomEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoomEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_greet_greetpb_greet_proto_enumTypes[1].Descriptor()
+}
+
+func (RoomEventType) Type() protoreflect.EnumType {
+	return &file_greet_greetpb_greet_proto_enumTypes[1]
+}
+
+func (x RoomEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoomEventType.Descriptor instead.
+func (RoomEventType) EnumDescriptor() ([]byte, []int) {
+	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{1}
+}
 
-	FirstName  string `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+type Greeting struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	FirstName string                 `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	// The family name, for locales that write it first.
 	SecondName string `protobuf:"bytes,2,opt,name=second_name,json=secondName,proto3" json:"second_name,omitempty"`
+	// BCP 47 tag of the language to greet in, e.g. "es-MX". When empty, the
+	// accept-language metadata of the call is used, then English.
+	Locale    string    `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	Formality Formality `protobuf:"varint,4,opt,name=formality,proto3,enum=greet.v1.Formality" json:"formality,omitempty"`
+	// ID of the template rendering the greeting. When empty, the "default"
+	// template is used.
+	TemplateId    string `protobuf:"bytes,5,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Greeting) Reset() {
 	*x = Greeting{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_greet_greetpb_greet_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Greeting) String() string {
@@ -50,7 +158,7 @@ func (*Greeting) ProtoMessage() {}
 
 func (x *Greeting) ProtoReflect() protoreflect.Message {
 	mi := &file_greet_greetpb_greet_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -79,21 +187,39 @@ func (x *Greeting) GetSecondName() string {
 	return ""
 }
 
+func (x *Greeting) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *Greeting) GetFormality() Formality {
+	if x != nil {
+		return x.Formality
+	}
+	return Formality_FORMALITY_UNSPECIFIED
+}
+
+func (x *Greeting) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
 type GreetRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Greeting      *Greeting              `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Greeting *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GreetRequest) Reset() {
 	*x = GreetRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_greet_greetpb_greet_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GreetRequest) String() string {
@@ -104,7 +230,7 @@ func (*GreetRequest) ProtoMessage() {}
 
 func (x *GreetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_greet_greetpb_greet_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -127,20 +253,19 @@ func (x *GreetRequest) GetGreeting() *Greeting {
 }
 
 type GreetResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Result string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// BCP 47 tag of the language of the result.
+	Locale        string `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GreetResponse) Reset() {
 	*x = GreetResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_greet_greetpb_greet_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GreetResponse) String() string {
@@ -151,7 +276,7 @@ func (*GreetResponse) ProtoMessage() {}
 
 func (x *GreetResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_greet_greetpb_greet_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -173,21 +298,36 @@ func (x *GreetResponse) GetResult() string {
 	return ""
 }
 
-type GreetManyTimesRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *GreetResponse) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
 
-	Greeting *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
+type GreetManyTimesRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Greeting *Greeting              `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
+	// How many greetings to send, 10 when 0. The server rejects counts above
+	// its maximum.
+	Count uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Time between greetings, 200ms when unset. The server rejects intervals
+	// above its maximum.
+	Interval *durationpb.Duration `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Delays each greeting by a random duration up to jitter, which must not
+	// exceed the interval.
+	Jitter *durationpb.Duration `protobuf:"bytes,4,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	// Keeps greeting until the call is cancelled, ignoring count.
+	UntilCancelled bool `protobuf:"varint,5,opt,name=until_cancelled,json=untilCancelled,proto3" json:"until_cancelled,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GreetManyTimesRequest) Reset() {
 	*x = GreetManyTimesRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_greet_greetpb_greet_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GreetManyTimesRequest) String() string {
@@ -198,7 +338,7 @@ func (*GreetManyTimesRequest) ProtoMessage() {}
 
 func (x *GreetManyTimesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_greet_greetpb_greet_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -220,21 +360,46 @@ func (x *GreetManyTimesRequest) GetGreeting() *Greeting {
 	return nil
 }
 
+func (x *GreetManyTimesRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GreetManyTimesRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *GreetManyTimesRequest) GetJitter() *durationpb.Duration {
+	if x != nil {
+		return x.Jitter
+	}
+	return nil
+}
+
+func (x *GreetManyTimesRequest) GetUntilCancelled() bool {
+	if x != nil {
+		return x.UntilCancelled
+	}
+	return false
+}
+
 type GreetManyTimesResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GreetManyTimesResponse) Reset() {
 	*x = GreetManyTimesResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_greet_greetpb_greet_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GreetManyTimesResponse) String() string {
@@ -245,7 +410,7 @@ func (*GreetManyTimesResponse) ProtoMessage() {}
 
 func (x *GreetManyTimesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_greet_greetpb_greet_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -268,20 +433,20 @@ func (x *GreetManyTimesResponse) GetResult() string {
 }
 
 type LongGreetRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Greeting *Greeting              `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
+	// How to greet everyone. Only the options of the first request are
+	// used.
+	Options       *LongGreetOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Greeting *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LongGreetRequest) Reset() {
 	*x = LongGreetRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_greet_greetpb_greet_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *LongGreetRequest) String() string {
@@ -292,7 +457,7 @@ func (*LongGreetRequest) ProtoMessage() {}
 
 func (x *LongGreetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_greet_greetpb_greet_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -314,32 +479,50 @@ func (x *LongGreetRequest) GetGreeting() *Greeting {
 	return nil
 }
 
-type LongGreetResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *LongGreetRequest) GetOptions() *LongGreetOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
 
-	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+type LongGreetOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Greets people with the same first and second names once.
+	Dedupe bool `protobuf:"varint,1,opt,name=dedupe,proto3" json:"dedupe,omitempty"`
+	// Sorts people by second name, then first name, in the collation of the
+	// language of the greeting. Otherwise they keep the order they were
+	// received in.
+	Sort bool `protobuf:"varint,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Names people sharing a second name together, e.g. "Ana and Luis
+	// García".
+	GroupBySecondName bool `protobuf:"varint,3,opt,name=group_by_second_name,json=groupBySecondName,proto3" json:"group_by_second_name,omitempty"`
+	// Joins names the way the language writes lists, e.g. "A, B and C",
+	// instead of with commas.
+	ListJoin bool `protobuf:"varint,4,opt,name=list_join,json=listJoin,proto3" json:"list_join,omitempty"`
+	// Names at most this many people, or groups, and summarizes the rest,
+	// e.g. "A, B and 3 others". 0 names everyone.
+	MaxNames      uint32 `protobuf:"varint,5,opt,name=max_names,json=maxNames,proto3" json:"max_names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LongGreetResponse) Reset() {
-	*x = LongGreetResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_greet_greetpb_greet_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *LongGreetOptions) Reset() {
+	*x = LongGreetOptions{}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *LongGreetResponse) String() string {
+func (x *LongGreetOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LongGreetResponse) ProtoMessage() {}
+func (*LongGreetOptions) ProtoMessage() {}
 
-func (x *LongGreetResponse) ProtoReflect() protoreflect.Message {
+func (x *LongGreetOptions) ProtoReflect() protoreflect.Message {
 	mi := &file_greet_greetpb_greet_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -349,44 +532,75 @@ func (x *LongGreetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LongGreetResponse.ProtoReflect.Descriptor instead.
-func (*LongGreetResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use LongGreetOptions.ProtoReflect.Descriptor instead.
+func (*LongGreetOptions) Descriptor() ([]byte, []int) {
 	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *LongGreetResponse) GetResult() string {
+func (x *LongGreetOptions) GetDedupe() bool {
 	if x != nil {
-		return x.Result
+		return x.Dedupe
 	}
-	return ""
+	return false
 }
 
-type GreetEveryoneRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *LongGreetOptions) GetSort() bool {
+	if x != nil {
+		return x.Sort
+	}
+	return false
+}
 
-	Greeting *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
+func (x *LongGreetOptions) GetGroupBySecondName() bool {
+	if x != nil {
+		return x.GroupBySecondName
+	}
+	return false
 }
 
-func (x *GreetEveryoneRequest) Reset() {
-	*x = GreetEveryoneRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_greet_greetpb_greet_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *LongGreetOptions) GetListJoin() bool {
+	if x != nil {
+		return x.ListJoin
 	}
+	return false
 }
 
-func (x *GreetEveryoneRequest) String() string {
+func (x *LongGreetOptions) GetMaxNames() uint32 {
+	if x != nil {
+		return x.MaxNames
+	}
+	return 0
+}
+
+type LongGreetResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Result string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// Everyone greeted, after deduplication and sorting.
+	People []*Person `protobuf:"bytes,2,rep,name=people,proto3" json:"people,omitempty"`
+	// How many of the people were summarized instead of named in result.
+	Others uint32 `protobuf:"varint,3,opt,name=others,proto3" json:"others,omitempty"`
+	// BCP 47 tag of the language used.
+	Locale        string `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LongGreetResponse) Reset() {
+	*x = LongGreetResponse{}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LongGreetResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GreetEveryoneRequest) ProtoMessage() {}
+func (*LongGreetResponse) ProtoMessage() {}
 
-func (x *GreetEveryoneRequest) ProtoReflect() protoreflect.Message {
+func (x *LongGreetResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_greet_greetpb_greet_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -396,44 +610,67 @@ func (x *GreetEveryoneRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GreetEveryoneRequest.ProtoReflect.Descriptor instead.
-func (*GreetEveryoneRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LongGreetResponse.ProtoReflect.Descriptor instead.
+func (*LongGreetResponse) Descriptor() ([]byte, []int) {
 	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GreetEveryoneRequest) GetGreeting() *Greeting {
+func (x *LongGreetResponse) GetResult() string {
 	if x != nil {
-		return x.Greeting
+		return x.Result
 	}
-	return nil
+	return ""
 }
 
-type GreetEveryoneResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *LongGreetResponse) GetPeople() []*Person {
+	if x != nil {
+		return x.People
+	}
+	return nil
+}
 
-	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+func (x *LongGreetResponse) GetOthers() uint32 {
+	if x != nil {
+		return x.Others
+	}
+	return 0
 }
 
-func (x *GreetEveryoneResponse) Reset() {
-	*x = GreetEveryoneResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_greet_greetpb_greet_proto_msgTypes[8]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *LongGreetResponse) GetLocale() string {
+	if x != nil {
+		return x.Locale
 	}
+	return ""
 }
 
-func (x *GreetEveryoneResponse) String() string {
+type Person struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	FirstName  string                 `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	SecondName string                 `protobuf:"bytes,2,opt,name=second_name,json=secondName,proto3" json:"second_name,omitempty"`
+	// The full name as written in the language of the greeting.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// How many times the person was received.
+	Count         uint32 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Person) Reset() {
+	*x = Person{}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Person) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GreetEveryoneResponse) ProtoMessage() {}
+func (*Person) ProtoMessage() {}
 
-func (x *GreetEveryoneResponse) ProtoReflect() protoreflect.Message {
+func (x *Person) ProtoReflect() protoreflect.Message {
 	mi := &file_greet_greetpb_greet_proto_msgTypes[8]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -443,544 +680,990 @@ func (x *GreetEveryoneResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GreetEveryoneResponse.ProtoReflect.Descriptor instead.
-func (*GreetEveryoneResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Person.ProtoReflect.Descriptor instead.
+func (*Person) Descriptor() ([]byte, []int) {
 	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GreetEveryoneResponse) GetResult() string {
+func (x *Person) GetFirstName() string {
 	if x != nil {
-		return x.Result
+		return x.FirstName
 	}
 	return ""
 }
 
-var File_greet_greetpb_greet_proto protoreflect.FileDescriptor
+func (x *Person) GetSecondName() string {
+	if x != nil {
+		return x.SecondName
+	}
+	return ""
+}
 
-var file_greet_greetpb_greet_proto_rawDesc = []byte{
-	0x0a, 0x19, 0x67, 0x72, 0x65, 0x65, 0x74, 0x2f, 0x67, 0x72, 0x65, 0x65, 0x74, 0x70, 0x62, 0x2f,
-	0x67, 0x72, 0x65, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x67, 0x72, 0x65,
-	0x65, 0x74, 0x22, 0x4a, 0x0a, 0x08, 0x47, 0x72, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x1d,
-	0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a,
-	0x0b, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x3b,
-	0x0a, 0x0c, 0x47, 0x72, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b,
-	0x0a, 0x08, 0x67, 0x72, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x67, 0x72, 0x65, 0x65, 0x74, 0x2e, 0x47, 0x72, 0x65, 0x65, 0x74, 0x69, 0x6e,
-	0x67, 0x52, 0x08, 0x67, 0x72, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x27, 0x0a, 0x0d, 0x47,
-	0x72, 0x65, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x22, 0x44, 0x0a, 0x15, 0x47, 0x72, 0x65, 0x65, 0x74, 0x4d, 0x61, 0x6e,
-	0x79, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a,
-	0x08, 0x67, 0x72, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0f, 0x2e, 0x67, 0x72, 0x65, 0x65, 0x74, 0x2e, 0x47, 0x72, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67,
-	0x52, 0x08, 0x67, 0x72, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x30, 0x0a, 0x16, 0x47, 0x72,
-	0x65, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x3f, 0x0a, 0x10,
-	0x4c, 0x6f, 0x6e, 0x67, 0x47, 0x72, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x2b, 0x0a, 0x08, 0x67, 0x72, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x72, 0x65, 0x65, 0x74, 0x2e, 0x47, 0x72, 0x65, 0x65, 0x74,
-	0x69, 0x6e, 0x67, 0x52, 0x08, 0x67, 0x72, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x2b, 0x0a,
-	0x11, 0x4c, 0x6f, 0x6e, 0x67, 0x47, 0x72, 0x65, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x43, 0x0a, 0x14, 0x47, 0x72,
-	0x65, 0x65, 0x74, 0x45, 0x76, 0x65, 0x72, 0x79, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x2b, 0x0a, 0x08, 0x67, 0x72, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x72, 0x65, 0x65, 0x74, 0x2e, 0x47, 0x72, 0x65,
-	0x65, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x67, 0x72, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x22,
-	0x2f, 0x0a, 0x15, 0x47, 0x72, 0x65, 0x65, 0x74, 0x45, 0x76, 0x65, 0x72, 0x79, 0x6f, 0x6e, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x32, 0xad, 0x02, 0x0a, 0x0c, 0x47, 0x72, 0x65, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x34, 0x0a, 0x05, 0x47, 0x72, 0x65, 0x65, 0x74, 0x12, 0x13, 0x2e, 0x67, 0x72, 0x65,
-	0x65, 0x74, 0x2e, 0x47, 0x72, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x14, 0x2e, 0x67, 0x72, 0x65, 0x65, 0x74, 0x2e, 0x47, 0x72, 0x65, 0x65, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0e, 0x47, 0x72, 0x65, 0x65, 0x74,
-	0x4d, 0x61, 0x6e, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x72, 0x65, 0x65,
-	0x74, 0x2e, 0x47, 0x72, 0x65, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x72, 0x65, 0x65, 0x74, 0x2e,
-	0x47, 0x72, 0x65, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x09, 0x4c, 0x6f,
-	0x6e, 0x67, 0x47, 0x72, 0x65, 0x65, 0x74, 0x12, 0x17, 0x2e, 0x67, 0x72, 0x65, 0x65, 0x74, 0x2e,
-	0x4c, 0x6f, 0x6e, 0x67, 0x47, 0x72, 0x65, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x18, 0x2e, 0x67, 0x72, 0x65, 0x65, 0x74, 0x2e, 0x4c, 0x6f, 0x6e, 0x67, 0x47, 0x72, 0x65,
-	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x50,
-	0x0a, 0x0d, 0x47, 0x72, 0x65, 0x65, 0x74, 0x45, 0x76, 0x65, 0x72, 0x79, 0x6f, 0x6e, 0x65, 0x12,
-	0x1b, 0x2e, 0x67, 0x72, 0x65, 0x65, 0x74, 0x2e, 0x47, 0x72, 0x65, 0x65, 0x74, 0x45, 0x76, 0x65,
-	0x72, 0x79, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x67,
-	0x72, 0x65, 0x65, 0x74, 0x2e, 0x47, 0x72, 0x65, 0x65, 0x74, 0x45, 0x76, 0x65, 0x72, 0x79, 0x6f,
-	0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01,
-	0x42, 0x11, 0x5a, 0x0f, 0x2e, 0x2f, 0x67, 0x72, 0x65, 0x65, 0x74, 0x2f, 0x67, 0x72, 0x65, 0x65,
-	0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *Person) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-var (
-	file_greet_greetpb_greet_proto_rawDescOnce sync.Once
-	file_greet_greetpb_greet_proto_rawDescData = file_greet_greetpb_greet_proto_rawDesc
-)
+func (x *Person) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
 
-func file_greet_greetpb_greet_proto_rawDescGZIP() []byte {
-	file_greet_greetpb_greet_proto_rawDescOnce.Do(func() {
-		file_greet_greetpb_greet_proto_rawDescData = protoimpl.X.CompressGZIP(file_greet_greetpb_greet_proto_rawDescData)
-	})
-	return file_greet_greetpb_greet_proto_rawDescData
+type GreetEveryoneRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required, except on the message joining a room and on messages with
+	// sequence 0 attaching a session.
+	Greeting *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
+	// Identifies a resumable session. Clients set it on the first message
+	// of a stream, and again when reconnecting to resume the session.
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Position of the greeting in the session, starting at 1. A message with
+	// sequence 0 carries no greeting and only attaches the stream to the
+	// session. Greetings already processed are ignored when resent.
+	Sequence uint64 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// When resuming, the sequence of the last response the client received.
+	// The server replays the responses that came after it.
+	LastReceivedSequence uint64 `protobuf:"varint,4,opt,name=last_received_sequence,json=lastReceivedSequence,proto3" json:"last_received_sequence,omitempty"`
+	// Joins a room, on the first message of a stream. Greetings sent
+	// afterwards go to every member of the room, and the stream receives
+	// the events of the room until it is closed. The greeting of the first
+	// message, if any, names the member. Rooms cannot be used with
+	// sessions.
+	RoomId        string `protobuf:"bytes,5,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-var file_greet_greetpb_greet_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
-var file_greet_greetpb_greet_proto_goTypes = []interface{}{
-	(*Greeting)(nil),               // 0: greet.Greeting
-	(*GreetRequest)(nil),           // 1: greet.GreetRequest
-	(*GreetResponse)(nil),          // 2: greet.GreetResponse
-	(*GreetManyTimesRequest)(nil),  // 3: greet.GreetManyTimesRequest
-	(*GreetManyTimesResponse)(nil), // 4: greet.GreetManyTimesResponse
-	(*LongGreetRequest)(nil),       // 5: greet.LongGreetRequest
-	(*LongGreetResponse)(nil),      // 6: greet.LongGreetResponse
-	(*GreetEveryoneRequest)(nil),   // 7: greet.GreetEveryoneRequest
-	(*GreetEveryoneResponse)(nil),  // 8: greet.GreetEveryoneResponse
+func (x *GreetEveryoneRequest) Reset() {
+	*x = GreetEveryoneRequest{}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
-var file_greet_greetpb_greet_proto_depIdxs = []int32{
-	0, // 0: greet.GreetRequest.greeting:type_name -> greet.Greeting
-	0, // 1: greet.GreetManyTimesRequest.greeting:type_name -> greet.Greeting
-	0, // 2: greet.LongGreetRequest.greeting:type_name -> greet.Greeting
-	0, // 3: greet.GreetEveryoneRequest.greeting:type_name -> greet.Greeting
-	1, // 4: greet.GreetService.Greet:input_type -> greet.GreetRequest
-	3, // 5: greet.GreetService.GreetManyTimes:input_type -> greet.GreetManyTimesRequest
-	5, // 6: greet.GreetService.LongGreet:input_type -> greet.LongGreetRequest
-	7, // 7: greet.GreetService.GreetEveryone:input_type -> greet.GreetEveryoneRequest
-	2, // 8: greet.GreetService.Greet:output_type -> greet.GreetResponse
-	4, // 9: greet.GreetService.GreetManyTimes:output_type -> greet.GreetManyTimesResponse
-	6, // 10: greet.GreetService.LongGreet:output_type -> greet.LongGreetResponse
-	8, // 11: greet.GreetService.GreetEveryone:output_type -> greet.GreetEveryoneResponse
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+
+func (x *GreetEveryoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_greet_greetpb_greet_proto_init() }
-func file_greet_greetpb_greet_proto_init() {
-	if File_greet_greetpb_greet_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_greet_greetpb_greet_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Greeting); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_greet_greetpb_greet_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GreetRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_greet_greetpb_greet_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GreetResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_greet_greetpb_greet_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GreetManyTimesRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_greet_greetpb_greet_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GreetManyTimesResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_greet_greetpb_greet_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LongGreetRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_greet_greetpb_greet_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LongGreetResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+func (*GreetEveryoneRequest) ProtoMessage() {}
+
+func (x *GreetEveryoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_greet_greetpb_greet_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_greet_greetpb_greet_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GreetEveryoneRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GreetEveryoneRequest.ProtoReflect.Descriptor instead.
+func (*GreetEveryoneRequest) Descriptor() ([]byte, []int) {
+	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GreetEveryoneRequest) GetGreeting() *Greeting {
+	if x != nil {
+		return x.Greeting
+	}
+	return nil
+}
+
+func (x *GreetEveryoneRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *GreetEveryoneRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *GreetEveryoneRequest) GetLastReceivedSequence() uint64 {
+	if x != nil {
+		return x.LastReceivedSequence
+	}
+	return 0
+}
+
+func (x *GreetEveryoneRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type GreetEveryoneResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Result string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// Echoes the session of the request.
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Sequence of the greeting this response answers. It also acknowledges
+	// every greeting up to it.
+	Sequence uint64 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Set when the response only reports the state of a resumed session.
+	AckOnly bool `protobuf:"varint,4,opt,name=ack_only,json=ackOnly,proto3" json:"ack_only,omitempty"`
+	// Set on the responses of a room, with result holding the greeting of
+	// ROOM_EVENT_TYPE_GREETING events.
+	Event         *RoomEvent `protobuf:"bytes,5,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GreetEveryoneResponse) Reset() {
+	*x = GreetEveryoneResponse{}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GreetEveryoneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GreetEveryoneResponse) ProtoMessage() {}
+
+func (x *GreetEveryoneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_greet_greetpb_greet_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_greet_greetpb_greet_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GreetEveryoneResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GreetEveryoneResponse.ProtoReflect.Descriptor instead.
+func (*GreetEveryoneResponse) Descriptor() ([]byte, []int) {
+	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GreetEveryoneResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *GreetEveryoneResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *GreetEveryoneResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *GreetEveryoneResponse) GetAckOnly() bool {
+	if x != nil {
+		return x.AckOnly
+	}
+	return false
+}
+
+func (x *GreetEveryoneResponse) GetEvent() *RoomEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type RoomEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  RoomEventType          `protobuf:"varint,1,opt,name=type,proto3,enum=greet.v1.RoomEventType" json:"type,omitempty"`
+	// The member who joined, left or greeted.
+	MemberId string `protobuf:"bytes,2,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	Name     string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// How many events the receiving client missed before this one because
+	// it did not read them fast enough.
+	Dropped       uint64 `protobuf:"varint,4,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomEvent) Reset() {
+	*x = RoomEvent{}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomEvent) ProtoMessage() {}
+
+func (x *RoomEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_greet_greetpb_greet_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_greet_greetpb_greet_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   9,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_greet_greetpb_greet_proto_goTypes,
-		DependencyIndexes: file_greet_greetpb_greet_proto_depIdxs,
-		MessageInfos:      file_greet_greetpb_greet_proto_msgTypes,
-	}.Build()
-	File_greet_greetpb_greet_proto = out.File
-	file_greet_greetpb_greet_proto_rawDesc = nil
-	file_greet_greetpb_greet_proto_goTypes = nil
-	file_greet_greetpb_greet_proto_depIdxs = nil
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomEvent.ProtoReflect.Descriptor instead.
+func (*RoomEvent) Descriptor() ([]byte, []int) {
+	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{11}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+func (x *RoomEvent) GetType() RoomEventType {
+	if x != nil {
+		return x.Type
+	}
+	return RoomEventType_ROOM_EVENT_TYPE_UNSPECIFIED
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func (x *RoomEvent) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
 
-// GreetServiceClient is the client API for GreetService service.
+func (x *RoomEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RoomEvent) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+// Template is a Go text/template rendering greetings. It is executed with:
 //
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type GreetServiceClient interface {
-	// Unary GRPC
-	Greet(ctx context.Context, in *GreetRequest, opts ...grpc.CallOption) (*GreetResponse, error)
-	// Server streaming
-	GreetManyTimes(ctx context.Context, in *GreetManyTimesRequest, opts ...grpc.CallOption) (GreetService_GreetManyTimesClient, error)
-	// Client streaming
-	LongGreet(ctx context.Context, opts ...grpc.CallOption) (GreetService_LongGreetClient, error)
-	// Bi-directional streaming
-	GreetEveryone(ctx context.Context, opts ...grpc.CallOption) (GreetService_GreetEveryoneClient, error)
+//	.Greeting    the greeting in the language of the caller, e.g. "Hello, Alan Kevin"
+//	.Name        the full name of the person greeted, or the names of
+//	             everyone greeted by LongGreet, joined as its options ask
+//	.Names       the full name of everyone greeted
+//	.FirstName   the first name of the (first) person greeted
+//	.SecondName  their second name
+//	.Locale      the BCP 47 tag of the language of the greeting
+//	.Number      the number of the greeting in GreetManyTimes, from 1, or 0
+type Template struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text  string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// Set by the server.
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type greetServiceClient struct {
-	cc grpc.ClientConnInterface
+func (x *Template) Reset() {
+	*x = Template{}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func NewGreetServiceClient(cc grpc.ClientConnInterface) GreetServiceClient {
-	return &greetServiceClient{cc}
+func (x *Template) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (c *greetServiceClient) Greet(ctx context.Context, in *GreetRequest, opts ...grpc.CallOption) (*GreetResponse, error) {
-	out := new(GreetResponse)
-	err := c.cc.Invoke(ctx, "/greet.GreetService/Greet", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (*Template) ProtoMessage() {}
+
+func (x *Template) ProtoReflect() protoreflect.Message {
+	mi := &file_greet_greetpb_greet_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return out, nil
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Template.ProtoReflect.Descriptor instead.
+func (*Template) Descriptor() ([]byte, []int) {
+	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{12}
 }
 
-func (c *greetServiceClient) GreetManyTimes(ctx context.Context, in *GreetManyTimesRequest, opts ...grpc.CallOption) (GreetService_GreetManyTimesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_GreetService_serviceDesc.Streams[0], "/greet.GreetService/GreetManyTimes", opts...)
-	if err != nil {
-		return nil, err
+func (x *Template) GetId() string {
+	if x != nil {
+		return x.Id
 	}
-	x := &greetServiceGreetManyTimesClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	return ""
+}
+
+func (x *Template) GetText() string {
+	if x != nil {
+		return x.Text
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return ""
+}
+
+func (x *Template) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
 	}
-	return x, nil
+	return nil
 }
 
-type GreetService_GreetManyTimesClient interface {
-	Recv() (*GreetManyTimesResponse, error)
-	grpc.ClientStream
+type ListTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type greetServiceGreetManyTimesClient struct {
-	grpc.ClientStream
+func (x *ListTemplatesRequest) Reset() {
+	*x = ListTemplatesRequest{}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *greetServiceGreetManyTimesClient) Recv() (*GreetManyTimesResponse, error) {
-	m := new(GreetManyTimesResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (x *ListTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (c *greetServiceClient) LongGreet(ctx context.Context, opts ...grpc.CallOption) (GreetService_LongGreetClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_GreetService_serviceDesc.Streams[1], "/greet.GreetService/LongGreet", opts...)
-	if err != nil {
-		return nil, err
+func (*ListTemplatesRequest) ProtoMessage() {}
+
+func (x *ListTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_greet_greetpb_greet_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	x := &greetServiceLongGreetClient{stream}
-	return x, nil
+	return mi.MessageOf(x)
 }
 
-type GreetService_LongGreetClient interface {
-	Send(*LongGreetRequest) error
-	CloseAndRecv() (*LongGreetResponse, error)
-	grpc.ClientStream
+// Deprecated: Use ListTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{13}
 }
 
-type greetServiceLongGreetClient struct {
-	grpc.ClientStream
+type ListTemplatesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Every template, sorted by ID.
+	Templates     []*Template `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *greetServiceLongGreetClient) Send(m *LongGreetRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (x *ListTemplatesResponse) Reset() {
+	*x = ListTemplatesResponse{}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *greetServiceLongGreetClient) CloseAndRecv() (*LongGreetResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (x *ListTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesResponse) ProtoMessage() {}
+
+func (x *ListTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_greet_greetpb_greet_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListTemplatesResponse) GetTemplates() []*Template {
+	if x != nil {
+		return x.Templates
 	}
-	m := new(LongGreetResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	return nil
+}
+
+type CreateTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *Template              `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTemplateRequest) Reset() {
+	*x = CreateTemplateRequest{}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTemplateRequest) ProtoMessage() {}
+
+func (x *CreateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_greet_greetpb_greet_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return m, nil
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{15}
 }
 
-func (c *greetServiceClient) GreetEveryone(ctx context.Context, opts ...grpc.CallOption) (GreetService_GreetEveryoneClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_GreetService_serviceDesc.Streams[2], "/greet.GreetService/GreetEveryone", opts...)
-	if err != nil {
-		return nil, err
+func (x *CreateTemplateRequest) GetTemplate() *Template {
+	if x != nil {
+		return x.Template
 	}
-	x := &greetServiceGreetEveryoneClient{stream}
-	return x, nil
+	return nil
 }
 
-type GreetService_GreetEveryoneClient interface {
-	Send(*GreetEveryoneRequest) error
-	Recv() (*GreetEveryoneResponse, error)
-	grpc.ClientStream
+type UpdateTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *Template              `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type greetServiceGreetEveryoneClient struct {
-	grpc.ClientStream
+func (x *UpdateTemplateRequest) Reset() {
+	*x = UpdateTemplateRequest{}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *greetServiceGreetEveryoneClient) Send(m *GreetEveryoneRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (x *UpdateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *greetServiceGreetEveryoneClient) Recv() (*GreetEveryoneResponse, error) {
-	m := new(GreetEveryoneResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (*UpdateTemplateRequest) ProtoMessage() {}
+
+func (x *UpdateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_greet_greetpb_greet_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return m, nil
+	return mi.MessageOf(x)
 }
 
-// GreetServiceServer is the server API for GreetService service.
-type GreetServiceServer interface {
-	// Unary GRPC
-	Greet(context.Context, *GreetRequest) (*GreetResponse, error)
-	// Server streaming
-	GreetManyTimes(*GreetManyTimesRequest, GreetService_GreetManyTimesServer) error
-	// Client streaming
-	LongGreet(GreetService_LongGreetServer) error
-	// Bi-directional streaming
-	GreetEveryone(GreetService_GreetEveryoneServer) error
+// Deprecated: Use UpdateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{16}
 }
 
-// UnimplementedGreetServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedGreetServiceServer struct {
+func (x *UpdateTemplateRequest) GetTemplate() *Template {
+	if x != nil {
+		return x.Template
+	}
+	return nil
 }
 
-func (*UnimplementedGreetServiceServer) Greet(context.Context, *GreetRequest) (*GreetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Greet not implemented")
+// GreetingRecord is a greeting the server sent.
+type GreetingRecord struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// Full name of the method sending the greeting, e.g.
+	// /greet.v1.GreetService/Greet.
+	Method string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	// The authenticated principal calling the method, else the address of
+	// the client.
+	Caller     string `protobuf:"bytes,4,opt,name=caller,proto3" json:"caller,omitempty"`
+	FirstName  string `protobuf:"bytes,5,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	SecondName string `protobuf:"bytes,6,opt,name=second_name,json=secondName,proto3" json:"second_name,omitempty"`
+	Result     string `protobuf:"bytes,7,opt,name=result,proto3" json:"result,omitempty"`
+	// BCP 47 tag of the language of the greeting.
+	Locale        string `protobuf:"bytes,8,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
-func (*UnimplementedGreetServiceServer) GreetManyTimes(*GreetManyTimesRequest, GreetService_GreetManyTimesServer) error {
-	return status.Errorf(codes.Unimplemented, "method GreetManyTimes not implemented")
+
+func (x *GreetingRecord) Reset() {
+	*x = GreetingRecord{}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
-func (*UnimplementedGreetServiceServer) LongGreet(GreetService_LongGreetServer) error {
-	return status.Errorf(codes.Unimplemented, "method LongGreet not implemented")
+
+func (x *GreetingRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (*UnimplementedGreetServiceServer) GreetEveryone(GreetService_GreetEveryoneServer) error {
-	return status.Errorf(codes.Unimplemented, "method GreetEveryone not implemented")
+
+func (*GreetingRecord) ProtoMessage() {}
+
+func (x *GreetingRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_greet_greetpb_greet_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func RegisterGreetServiceServer(s *grpc.Server, srv GreetServiceServer) {
-	s.RegisterService(&_GreetService_serviceDesc, srv)
+// Deprecated: Use GreetingRecord.ProtoReflect.Descriptor instead.
+func (*GreetingRecord) Descriptor() ([]byte, []int) {
+	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{17}
 }
 
-func _GreetService_Greet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GreetRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *GreetingRecord) GetId() string {
+	if x != nil {
+		return x.Id
 	}
-	if interceptor == nil {
-		return srv.(GreetServiceServer).Greet(ctx, in)
+	return ""
+}
+
+func (x *GreetingRecord) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *GreetingRecord) GetMethod() string {
+	if x != nil {
+		return x.Method
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/greet.GreetService/Greet",
+	return ""
+}
+
+func (x *GreetingRecord) GetCaller() string {
+	if x != nil {
+		return x.Caller
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GreetServiceServer).Greet(ctx, req.(*GreetRequest))
+	return ""
+}
+
+func (x *GreetingRecord) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *GreetingRecord) GetSecondName() string {
+	if x != nil {
+		return x.SecondName
+	}
+	return ""
+}
+
+func (x *GreetingRecord) GetResult() string {
+	if x != nil {
+		return x.Result
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _GreetService_GreetManyTimes_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GreetManyTimesRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (x *GreetingRecord) GetLocale() string {
+	if x != nil {
+		return x.Locale
 	}
-	return srv.(GreetServiceServer).GreetManyTimes(m, &greetServiceGreetManyTimesServer{stream})
+	return ""
+}
+
+type ListGreetingsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only lists the greetings of people with this first name, second name
+	// or full name ("first second"), ignoring case.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Only lists the greetings sent at or after start_time and before
+	// end_time.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// How many greetings to return at most, 50 when 0 and 1000 at most.
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of the previous page, to list the greetings after
+	// it. The other fields must not change from page to page.
+	PageToken     string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type GreetService_GreetManyTimesServer interface {
-	Send(*GreetManyTimesResponse) error
-	grpc.ServerStream
+func (x *ListGreetingsRequest) Reset() {
+	*x = ListGreetingsRequest{}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type greetServiceGreetManyTimesServer struct {
-	grpc.ServerStream
+func (x *ListGreetingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *greetServiceGreetManyTimesServer) Send(m *GreetManyTimesResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (*ListGreetingsRequest) ProtoMessage() {}
+
+func (x *ListGreetingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_greet_greetpb_greet_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func _GreetService_LongGreet_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(GreetServiceServer).LongGreet(&greetServiceLongGreetServer{stream})
+// Deprecated: Use ListGreetingsRequest.ProtoReflect.Descriptor instead.
+func (*ListGreetingsRequest) Descriptor() ([]byte, []int) {
+	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{18}
 }
 
-type GreetService_LongGreetServer interface {
-	SendAndClose(*LongGreetResponse) error
-	Recv() (*LongGreetRequest, error)
-	grpc.ServerStream
+func (x *ListGreetingsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-type greetServiceLongGreetServer struct {
-	grpc.ServerStream
+func (x *ListGreetingsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
 }
 
-func (x *greetServiceLongGreetServer) SendAndClose(m *LongGreetResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (x *ListGreetingsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
 }
 
-func (x *greetServiceLongGreetServer) Recv() (*LongGreetRequest, error) {
-	m := new(LongGreetRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+func (x *ListGreetingsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
 	}
-	return m, nil
+	return 0
 }
 
-func _GreetService_GreetEveryone_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(GreetServiceServer).GreetEveryone(&greetServiceGreetEveryoneServer{stream})
+func (x *ListGreetingsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
 }
 
-type GreetService_GreetEveryoneServer interface {
-	Send(*GreetEveryoneResponse) error
-	Recv() (*GreetEveryoneRequest, error)
-	grpc.ServerStream
+type ListGreetingsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The greetings, newest first.
+	Greetings []*GreetingRecord `protobuf:"bytes,1,rep,name=greetings,proto3" json:"greetings,omitempty"`
+	// Lists the next page when set.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type greetServiceGreetEveryoneServer struct {
-	grpc.ServerStream
+func (x *ListGreetingsResponse) Reset() {
+	*x = ListGreetingsResponse{}
+	mi := &file_greet_greetpb_greet_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *greetServiceGreetEveryoneServer) Send(m *GreetEveryoneResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (x *ListGreetingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *greetServiceGreetEveryoneServer) Recv() (*GreetEveryoneRequest, error) {
-	m := new(GreetEveryoneRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+func (*ListGreetingsResponse) ProtoMessage() {}
+
+func (x *ListGreetingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_greet_greetpb_greet_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return m, nil
+	return mi.MessageOf(x)
 }
 
-var _GreetService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "greet.GreetService",
-	HandlerType: (*GreetServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Greet",
-			Handler:    _GreetService_Greet_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "GreetManyTimes",
-			Handler:       _GreetService_GreetManyTimes_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "LongGreet",
-			Handler:       _GreetService_LongGreet_Handler,
-			ClientStreams: true,
-		},
-		{
-			StreamName:    "GreetEveryone",
-			Handler:       _GreetService_GreetEveryone_Handler,
-			ServerStreams: true,
-			ClientStreams: true,
+// Deprecated: Use ListGreetingsResponse.ProtoReflect.Descriptor instead.
+func (*ListGreetingsResponse) Descriptor() ([]byte, []int) {
+	return file_greet_greetpb_greet_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListGreetingsResponse) GetGreetings() []*GreetingRecord {
+	if x != nil {
+		return x.Greetings
+	}
+	return nil
+}
+
+func (x *ListGreetingsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_greet_greetpb_greet_proto protoreflect.FileDescriptor
+
+const file_greet_greetpb_greet_proto_rawDesc = "" +
+	"\n" +
+	"\x19greet/greetpb/greet.proto\x12\bgreet.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x81\x02\n" +
+	"\bGreeting\x12)\n" +
+	"\n" +
+	"first_name\x18\x01 \x01(\tB\n" +
+	"\xbaH\a\xc8\x01\x01r\x02\x18@R\tfirstName\x12(\n" +
+	"\vsecond_name\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x18@R\n" +
+	"secondName\x12\x1f\n" +
+	"\x06locale\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x18#R\x06locale\x12;\n" +
+	"\tformality\x18\x04 \x01(\x0e2\x13.greet.v1.FormalityB\b\xbaH\x05\x82\x01\x02\x10\x01R\tformality\x12B\n" +
+	"\vtemplate_id\x18\x05 \x01(\tB!\xbaH\x1er\x1c\x18@2\x18^([a-z0-9][a-z0-9_-]*)?$R\n" +
+	"templateId\"F\n" +
+	"\fGreetRequest\x126\n" +
+	"\bgreeting\x18\x01 \x01(\v2\x12.greet.v1.GreetingB\x06\xbaH\x03\xc8\x01\x01R\bgreeting\"?\n" +
+	"\rGreetResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"\xf8\x01\n" +
+	"\x15GreetManyTimesRequest\x126\n" +
+	"\bgreeting\x18\x01 \x01(\v2\x12.greet.v1.GreetingB\x06\xbaH\x03\xc8\x01\x01R\bgreeting\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\rR\x05count\x125\n" +
+	"\binterval\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\binterval\x121\n" +
+	"\x06jitter\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x06jitter\x12'\n" +
+	"\x0funtil_cancelled\x18\x05 \x01(\bR\x0euntilCancelled\"0\n" +
+	"\x16GreetManyTimesResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\"\x80\x01\n" +
+	"\x10LongGreetRequest\x126\n" +
+	"\bgreeting\x18\x01 \x01(\v2\x12.greet.v1.GreetingB\x06\xbaH\x03\xc8\x01\x01R\bgreeting\x124\n" +
+	"\aoptions\x18\x02 \x01(\v2\x1a.greet.v1.LongGreetOptionsR\aoptions\"\xa9\x01\n" +
+	"\x10LongGreetOptions\x12\x16\n" +
+	"\x06dedupe\x18\x01 \x01(\bR\x06dedupe\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\bR\x04sort\x12/\n" +
+	"\x14group_by_second_name\x18\x03 \x01(\bR\x11groupBySecondName\x12\x1b\n" +
+	"\tlist_join\x18\x04 \x01(\bR\blistJoin\x12\x1b\n" +
+	"\tmax_names\x18\x05 \x01(\rR\bmaxNames\"\x85\x01\n" +
+	"\x11LongGreetResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\x12(\n" +
+	"\x06people\x18\x02 \x03(\v2\x10.greet.v1.PersonR\x06people\x12\x16\n" +
+	"\x06others\x18\x03 \x01(\rR\x06others\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\"r\n" +
+	"\x06Person\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1f\n" +
+	"\vsecond_name\x18\x02 \x01(\tR\n" +
+	"secondName\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\rR\x05count\"\x84\x02\n" +
+	"\x14GreetEveryoneRequest\x12.\n" +
+	"\bgreeting\x18\x01 \x01(\v2\x12.greet.v1.GreetingR\bgreeting\x12'\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\tsessionId\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x04R\bsequence\x124\n" +
+	"\x16last_received_sequence\x18\x04 \x01(\x04R\x14lastReceivedSequence\x12A\n" +
+	"\aroom_id\x18\x05 \x01(\tB(\xbaH%r#\x18@2\x1f^([A-Za-z0-9][A-Za-z0-9_.-]*)?$R\x06roomId\"\xb0\x01\n" +
+	"\x15GreetEveryoneResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x04R\bsequence\x12\x19\n" +
+	"\back_only\x18\x04 \x01(\bR\aackOnly\x12)\n" +
+	"\x05event\x18\x05 \x01(\v2\x13.greet.v1.RoomEventR\x05event\"\x83\x01\n" +
+	"\tRoomEvent\x12+\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x17.greet.v1.RoomEventTypeR\x04type\x12\x1b\n" +
+	"\tmember_id\x18\x02 \x01(\tR\bmemberId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\adropped\x18\x04 \x01(\x04R\adropped\"\x9b\x01\n" +
+	"\bTemplate\x121\n" +
+	"\x02id\x18\x01 \x01(\tB!\xbaH\x1e\xc8\x01\x01r\x19\x18@2\x15^[a-z0-9][a-z0-9_-]*$R\x02id\x12\x1f\n" +
+	"\x04text\x18\x02 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\x18\x80\bR\x04text\x12;\n" +
+	"\vupdate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\"\x16\n" +
+	"\x14ListTemplatesRequest\"I\n" +
+	"\x15ListTemplatesResponse\x120\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x12.greet.v1.TemplateR\ttemplates\"O\n" +
+	"\x15CreateTemplateRequest\x126\n" +
+	"\btemplate\x18\x01 \x01(\v2\x12.greet.v1.TemplateB\x06\xbaH\x03\xc8\x01\x01R\btemplate\"O\n" +
+	"\x15UpdateTemplateRequest\x126\n" +
+	"\btemplate\x18\x01 \x01(\v2\x12.greet.v1.TemplateB\x06\xbaH\x03\xc8\x01\x01R\btemplate\"\xf0\x01\n" +
+	"\x0eGreetingRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x16\n" +
+	"\x06caller\x18\x04 \x01(\tR\x06caller\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x05 \x01(\tR\tfirstName\x12\x1f\n" +
+	"\vsecond_name\x18\x06 \x01(\tR\n" +
+	"secondName\x12\x16\n" +
+	"\x06result\x18\a \x01(\tR\x06result\x12\x16\n" +
+	"\x06locale\x18\b \x01(\tR\x06locale\"\xec\x01\n" +
+	"\x14ListGreetingsRequest\x12\x1c\n" +
+	"\x04name\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x04R\x04name\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12'\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x02R\tpageToken\"w\n" +
+	"\x15ListGreetingsResponse\x126\n" +
+	"\tgreetings\x18\x01 \x03(\v2\x18.greet.v1.GreetingRecordR\tgreetings\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*T\n" +
+	"\tFormality\x12\x19\n" +
+	"\x15FORMALITY_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12FORMALITY_INFORMAL\x10\x01\x12\x14\n" +
+	"\x10FORMALITY_FORMAL\x10\x02*\x84\x01\n" +
+	"\rRoomEventType\x12\x1f\n" +
+	"\x1bROOM_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16ROOM_EVENT_TYPE_JOINED\x10\x01\x12\x18\n" +
+	"\x14ROOM_EVENT_TYPE_LEFT\x10\x02\x12\x1c\n" +
+	"\x18ROOM_EVENT_TYPE_GREETING\x10\x032\xa2\x06\n" +
+	"\fGreetService\x12N\n" +
+	"\x05Greet\x12\x16.greet.v1.GreetRequest\x1a\x17.greet.v1.GreetResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/greet\x12p\n" +
+	"\x0eGreetManyTimes\x12\x1f.greet.v1.GreetManyTimesRequest\x1a .greet.v1.GreetManyTimesResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/greet/many0\x01\x12H\n" +
+	"\tLongGreet\x12\x1a.greet.v1.LongGreetRequest\x1a\x1b.greet.v1.LongGreetResponse\"\x00(\x01\x12V\n" +
+	"\rGreetEveryone\x12\x1e.greet.v1.GreetEveryoneRequest\x1a\x1f.greet.v1.GreetEveryoneResponse\"\x00(\x010\x01\x12g\n" +
+	"\rListTemplates\x12\x1e.greet.v1.ListTemplatesRequest\x1a\x1f.greet.v1.ListTemplatesResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/templates\x12f\n" +
+	"\x0eCreateTemplate\x12\x1f.greet.v1.CreateTemplateRequest\x1a\x12.greet.v1.Template\"\x1f\x82\xd3\xe4\x93\x02\x19:\btemplate\"\r/v1/templates\x12t\n" +
+	"\x0eUpdateTemplate\x12\x1f.greet.v1.UpdateTemplateRequest\x1a\x12.greet.v1.Template\"-\x82\xd3\xe4\x93\x02':\btemplate\x1a\x1b/v1/templates/{template.id}\x12g\n" +
+	"\rListGreetings\x12\x1e.greet.v1.ListGreetingsRequest\x1a\x1f.greet.v1.ListGreetingsResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/greetingsB-Z+github.com/AlanKev117/go-grpc/greet/greetpbb\x06proto3"
+
+var (
+	file_greet_greetpb_greet_proto_rawDescOnce sync.Once
+	file_greet_greetpb_greet_proto_rawDescData []byte
+)
+
+func file_greet_greetpb_greet_proto_rawDescGZIP() []byte {
+	file_greet_greetpb_greet_proto_rawDescOnce.Do(func() {
+		file_greet_greetpb_greet_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_greet_greetpb_greet_proto_rawDesc), len(file_greet_greetpb_greet_proto_rawDesc)))
+	})
+	return file_greet_greetpb_greet_proto_rawDescData
+}
+
+var file_greet_greetpb_greet_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_greet_greetpb_greet_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_greet_greetpb_greet_proto_goTypes = []any{
+	(Formality)(0),                 // 0: greet.v1.Formality
+	(RoomEventType)(0),             // 1: greet.v1.RoomEventType
+	(*Greeting)(nil),               // 2: greet.v1.Greeting
+	(*GreetRequest)(nil),           // 3: greet.v1.GreetRequest
+	(*GreetResponse)(nil),          // 4: greet.v1.GreetResponse
+	(*GreetManyTimesRequest)(nil),  // 5: greet.v1.GreetManyTimesRequest
+	(*GreetManyTimesResponse)(nil), // 6: greet.v1.GreetManyTimesResponse
+	(*LongGreetRequest)(nil),       // 7: greet.v1.LongGreetRequest
+	(*LongGreetOptions)(nil),       // 8: greet.v1.LongGreetOptions
+	(*LongGreetResponse)(nil),      // 9: greet.v1.LongGreetResponse
+	(*Person)(nil),                 // 10: greet.v1.Person
+	(*GreetEveryoneRequest)(nil),   // 11: greet.v1.GreetEveryoneRequest
+	(*GreetEveryoneResponse)(nil),  // 12: greet.v1.GreetEveryoneResponse
+	(*RoomEvent)(nil),              // 13: greet.v1.RoomEvent
+	(*Template)(nil),               // 14: greet.v1.Template
+	(*ListTemplatesRequest)(nil),   // 15: greet.v1.ListTemplatesRequest
+	(*ListTemplatesResponse)(nil),  // 16: greet.v1.ListTemplatesResponse
+	(*CreateTemplateRequest)(nil),  // 17: greet.v1.CreateTemplateRequest
+	(*UpdateTemplateRequest)(nil),  // 18: greet.v1.UpdateTemplateRequest
+	(*GreetingRecord)(nil),         // 19: greet.v1.GreetingRecord
+	(*ListGreetingsRequest)(nil),   // 20: greet.v1.ListGreetingsRequest
+	(*ListGreetingsResponse)(nil),  // 21: greet.v1.ListGreetingsResponse
+	(*durationpb.Duration)(nil),    // 22: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),  // 23: google.protobuf.Timestamp
+}
+var file_greet_greetpb_greet_proto_depIdxs = []int32{
+	0,  // 0: greet.v1.Greeting.formality:type_name -> greet.v1.Formality
+	2,  // 1: greet.v1.GreetRequest.greeting:type_name -> greet.v1.Greeting
+	2,  // 2: greet.v1.GreetManyTimesRequest.greeting:type_name -> greet.v1.Greeting
+	22, // 3: greet.v1.GreetManyTimesRequest.interval:type_name -> google.protobuf.Duration
+	22, // 4: greet.v1.GreetManyTimesRequest.jitter:type_name -> google.protobuf.Duration
+	2,  // 5: greet.v1.LongGreetRequest.greeting:type_name -> greet.v1.Greeting
+	8,  // 6: greet.v1.LongGreetRequest.options:type_name -> greet.v1.LongGreetOptions
+	10, // 7: greet.v1.LongGreetResponse.people:type_name -> greet.v1.Person
+	2,  // 8: greet.v1.GreetEveryoneRequest.greeting:type_name -> greet.v1.Greeting
+	13, // 9: greet.v1.GreetEveryoneResponse.event:type_name -> greet.v1.RoomEvent
+	1,  // 10: greet.v1.RoomEvent.type:type_name -> greet.v1.RoomEventType
+	23, // 11: greet.v1.Template.update_time:type_name -> google.protobuf.Timestamp
+	14, // 12: greet.v1.ListTemplatesResponse.templates:type_name -> greet.v1.Template
+	14, // 13: greet.v1.CreateTemplateRequest.template:type_name -> greet.v1.Template
+	14, // 14: greet.v1.UpdateTemplateRequest.template:type_name -> greet.v1.Template
+	23, // 15: greet.v1.GreetingRecord.time:type_name -> google.protobuf.Timestamp
+	23, // 16: greet.v1.ListGreetingsRequest.start_time:type_name -> google.protobuf.Timestamp
+	23, // 17: greet.v1.ListGreetingsRequest.end_time:type_name -> google.protobuf.Timestamp
+	19, // 18: greet.v1.ListGreetingsResponse.greetings:type_name -> greet.v1.GreetingRecord
+	3,  // 19: greet.v1.GreetService.Greet:input_type -> greet.v1.GreetRequest
+	5,  // 20: greet.v1.GreetService.GreetManyTimes:input_type -> greet.v1.GreetManyTimesRequest
+	7,  // 21: greet.v1.GreetService.LongGreet:input_type -> greet.v1.LongGreetRequest
+	11, // 22: greet.v1.GreetService.GreetEveryone:input_type -> greet.v1.GreetEveryoneRequest
+	15, // 23: greet.v1.GreetService.ListTemplates:input_type -> greet.v1.ListTemplatesRequest
+	17, // 24: greet.v1.GreetService.CreateTemplate:input_type -> greet.v1.CreateTemplateRequest
+	18, // 25: greet.v1.GreetService.UpdateTemplate:input_type -> greet.v1.UpdateTemplateRequest
+	20, // 26: greet.v1.GreetService.ListGreetings:input_type -> greet.v1.ListGreetingsRequest
+	4,  // 27: greet.v1.GreetService.Greet:output_type -> greet.v1.GreetResponse
+	6,  // 28: greet.v1.GreetService.GreetManyTimes:output_type -> greet.v1.GreetManyTimesResponse
+	9,  // 29: greet.v1.GreetService.LongGreet:output_type -> greet.v1.LongGreetResponse
+	12, // 30: greet.v1.GreetService.GreetEveryone:output_type -> greet.v1.GreetEveryoneResponse
+	16, // 31: greet.v1.GreetService.ListTemplates:output_type -> greet.v1.ListTemplatesResponse
+	14, // 32: greet.v1.GreetService.CreateTemplate:output_type -> greet.v1.Template
+	14, // 33: greet.v1.GreetService.UpdateTemplate:output_type -> greet.v1.Template
+	21, // 34: greet.v1.GreetService.ListGreetings:output_type -> greet.v1.ListGreetingsResponse
+	27, // [27:35] is the sub-list for method output_type
+	19, // [19:27] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_greet_greetpb_greet_proto_init() }
+func file_greet_greetpb_greet_proto_init() {
+	if File_greet_greetpb_greet_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_greet_greetpb_greet_proto_rawDesc), len(file_greet_greetpb_greet_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
 		},
-	},
-	Metadata: "greet/greetpb/greet.proto",
+		GoTypes:           file_greet_greetpb_greet_proto_goTypes,
+		DependencyIndexes: file_greet_greetpb_greet_proto_depIdxs,
+		EnumInfos:         file_greet_greetpb_greet_proto_enumTypes,
+		MessageInfos:      file_greet_greetpb_greet_proto_msgTypes,
+	}.Build()
+	File_greet_greetpb_greet_proto = out.File
+	file_greet_greetpb_greet_proto_goTypes = nil
+	file_greet_greetpb_greet_proto_depIdxs = nil
 }
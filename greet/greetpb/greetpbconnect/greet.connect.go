@@ -0,0 +1,326 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: greet/greetpb/greet.proto
+
+package greetpbconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	greetpb "github.com/AlanKev117/go-grpc/greet/greetpb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// GreetServiceName is the fully-qualified name of the GreetService service.
+	GreetServiceName = "greet.v1.GreetService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// GreetServiceGreetProcedure is the fully-qualified name of the GreetService's Greet RPC.
+	GreetServiceGreetProcedure = "/greet.v1.GreetService/Greet"
+	// GreetServiceGreetManyTimesProcedure is the fully-qualified name of the GreetService's
+	// GreetManyTimes RPC.
+	GreetServiceGreetManyTimesProcedure = "/greet.v1.GreetService/GreetManyTimes"
+	// GreetServiceLongGreetProcedure is the fully-qualified name of the GreetService's LongGreet RPC.
+	GreetServiceLongGreetProcedure = "/greet.v1.GreetService/LongGreet"
+	// GreetServiceGreetEveryoneProcedure is the fully-qualified name of the GreetService's
+	// GreetEveryone RPC.
+	GreetServiceGreetEveryoneProcedure = "/greet.v1.GreetService/GreetEveryone"
+	// GreetServiceListTemplatesProcedure is the fully-qualified name of the GreetService's
+	// ListTemplates RPC.
+	GreetServiceListTemplatesProcedure = "/greet.v1.GreetService/ListTemplates"
+	// GreetServiceCreateTemplateProcedure is the fully-qualified name of the GreetService's
+	// CreateTemplate RPC.
+	GreetServiceCreateTemplateProcedure = "/greet.v1.GreetService/CreateTemplate"
+	// GreetServiceUpdateTemplateProcedure is the fully-qualified name of the GreetService's
+	// UpdateTemplate RPC.
+	GreetServiceUpdateTemplateProcedure = "/greet.v1.GreetService/UpdateTemplate"
+	// GreetServiceListGreetingsProcedure is the fully-qualified name of the GreetService's
+	// ListGreetings RPC.
+	GreetServiceListGreetingsProcedure = "/greet.v1.GreetService/ListGreetings"
+)
+
+// GreetServiceClient is a client for the greet.v1.GreetService service.
+type GreetServiceClient interface {
+	// Unary GRPC
+	Greet(context.Context, *connect.Request[greetpb.GreetRequest]) (*connect.Response[greetpb.GreetResponse], error)
+	// Server streaming
+	GreetManyTimes(context.Context, *connect.Request[greetpb.GreetManyTimesRequest]) (*connect.ServerStreamForClient[greetpb.GreetManyTimesResponse], error)
+	// Client streaming
+	LongGreet(context.Context) *connect.ClientStreamForClient[greetpb.LongGreetRequest, greetpb.LongGreetResponse]
+	// Bi-directional streaming
+	GreetEveryone(context.Context) *connect.BidiStreamForClient[greetpb.GreetEveryoneRequest, greetpb.GreetEveryoneResponse]
+	// Lists the greeting templates
+	ListTemplates(context.Context, *connect.Request[greetpb.ListTemplatesRequest]) (*connect.Response[greetpb.ListTemplatesResponse], error)
+	// Adds a greeting template
+	CreateTemplate(context.Context, *connect.Request[greetpb.CreateTemplateRequest]) (*connect.Response[greetpb.Template], error)
+	// Replaces the text of a greeting template
+	UpdateTemplate(context.Context, *connect.Request[greetpb.UpdateTemplateRequest]) (*connect.Response[greetpb.Template], error)
+	// Lists the greetings sent by every method
+	ListGreetings(context.Context, *connect.Request[greetpb.ListGreetingsRequest]) (*connect.Response[greetpb.ListGreetingsResponse], error)
+}
+
+// NewGreetServiceClient constructs a client for the greet.v1.GreetService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewGreetServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) GreetServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	greetServiceMethods := greetpb.File_greet_greetpb_greet_proto.Services().ByName("GreetService").Methods()
+	return &greetServiceClient{
+		greet: connect.NewClient[greetpb.GreetRequest, greetpb.GreetResponse](
+			httpClient,
+			baseURL+GreetServiceGreetProcedure,
+			connect.WithSchema(greetServiceMethods.ByName("Greet")),
+			connect.WithClientOptions(opts...),
+		),
+		greetManyTimes: connect.NewClient[greetpb.GreetManyTimesRequest, greetpb.GreetManyTimesResponse](
+			httpClient,
+			baseURL+GreetServiceGreetManyTimesProcedure,
+			connect.WithSchema(greetServiceMethods.ByName("GreetManyTimes")),
+			connect.WithClientOptions(opts...),
+		),
+		longGreet: connect.NewClient[greetpb.LongGreetRequest, greetpb.LongGreetResponse](
+			httpClient,
+			baseURL+GreetServiceLongGreetProcedure,
+			connect.WithSchema(greetServiceMethods.ByName("LongGreet")),
+			connect.WithClientOptions(opts...),
+		),
+		greetEveryone: connect.NewClient[greetpb.GreetEveryoneRequest, greetpb.GreetEveryoneResponse](
+			httpClient,
+			baseURL+GreetServiceGreetEveryoneProcedure,
+			connect.WithSchema(greetServiceMethods.ByName("GreetEveryone")),
+			connect.WithClientOptions(opts...),
+		),
+		listTemplates: connect.NewClient[greetpb.ListTemplatesRequest, greetpb.ListTemplatesResponse](
+			httpClient,
+			baseURL+GreetServiceListTemplatesProcedure,
+			connect.WithSchema(greetServiceMethods.ByName("ListTemplates")),
+			connect.WithClientOptions(opts...),
+		),
+		createTemplate: connect.NewClient[greetpb.CreateTemplateRequest, greetpb.Template](
+			httpClient,
+			baseURL+GreetServiceCreateTemplateProcedure,
+			connect.WithSchema(greetServiceMethods.ByName("CreateTemplate")),
+			connect.WithClientOptions(opts...),
+		),
+		updateTemplate: connect.NewClient[greetpb.UpdateTemplateRequest, greetpb.Template](
+			httpClient,
+			baseURL+GreetServiceUpdateTemplateProcedure,
+			connect.WithSchema(greetServiceMethods.ByName("UpdateTemplate")),
+			connect.WithClientOptions(opts...),
+		),
+		listGreetings: connect.NewClient[greetpb.ListGreetingsRequest, greetpb.ListGreetingsResponse](
+			httpClient,
+			baseURL+GreetServiceListGreetingsProcedure,
+			connect.WithSchema(greetServiceMethods.ByName("ListGreetings")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// greetServiceClient implements GreetServiceClient.
+type greetServiceClient struct {
+	greet          *connect.Client[greetpb.GreetRequest, greetpb.GreetResponse]
+	greetManyTimes *connect.Client[greetpb.GreetManyTimesRequest, greetpb.GreetManyTimesResponse]
+	longGreet      *connect.Client[greetpb.LongGreetRequest, greetpb.LongGreetResponse]
+	greetEveryone  *connect.Client[greetpb.GreetEveryoneRequest, greetpb.GreetEveryoneResponse]
+	listTemplates  *connect.Client[greetpb.ListTemplatesRequest, greetpb.ListTemplatesResponse]
+	createTemplate *connect.Client[greetpb.CreateTemplateRequest, greetpb.Template]
+	updateTemplate *connect.Client[greetpb.UpdateTemplateRequest, greetpb.Template]
+	listGreetings  *connect.Client[greetpb.ListGreetingsRequest, greetpb.ListGreetingsResponse]
+}
+
+// Greet calls greet.v1.GreetService.Greet.
+func (c *greetServiceClient) Greet(ctx context.Context, req *connect.Request[greetpb.GreetRequest]) (*connect.Response[greetpb.GreetResponse], error) {
+	return c.greet.CallUnary(ctx, req)
+}
+
+// GreetManyTimes calls greet.v1.GreetService.GreetManyTimes.
+func (c *greetServiceClient) GreetManyTimes(ctx context.Context, req *connect.Request[greetpb.GreetManyTimesRequest]) (*connect.ServerStreamForClient[greetpb.GreetManyTimesResponse], error) {
+	return c.greetManyTimes.CallServerStream(ctx, req)
+}
+
+// LongGreet calls greet.v1.GreetService.LongGreet.
+func (c *greetServiceClient) LongGreet(ctx context.Context) *connect.ClientStreamForClient[greetpb.LongGreetRequest, greetpb.LongGreetResponse] {
+	return c.longGreet.CallClientStream(ctx)
+}
+
+// GreetEveryone calls greet.v1.GreetService.GreetEveryone.
+func (c *greetServiceClient) GreetEveryone(ctx context.Context) *connect.BidiStreamForClient[greetpb.GreetEveryoneRequest, greetpb.GreetEveryoneResponse] {
+	return c.greetEveryone.CallBidiStream(ctx)
+}
+
+// ListTemplates calls greet.v1.GreetService.ListTemplates.
+func (c *greetServiceClient) ListTemplates(ctx context.Context, req *connect.Request[greetpb.ListTemplatesRequest]) (*connect.Response[greetpb.ListTemplatesResponse], error) {
+	return c.listTemplates.CallUnary(ctx, req)
+}
+
+// CreateTemplate calls greet.v1.GreetService.CreateTemplate.
+func (c *greetServiceClient) CreateTemplate(ctx context.Context, req *connect.Request[greetpb.CreateTemplateRequest]) (*connect.Response[greetpb.Template], error) {
+	return c.createTemplate.CallUnary(ctx, req)
+}
+
+// UpdateTemplate calls greet.v1.GreetService.UpdateTemplate.
+func (c *greetServiceClient) UpdateTemplate(ctx context.Context, req *connect.Request[greetpb.UpdateTemplateRequest]) (*connect.Response[greetpb.Template], error) {
+	return c.updateTemplate.CallUnary(ctx, req)
+}
+
+// ListGreetings calls greet.v1.GreetService.ListGreetings.
+func (c *greetServiceClient) ListGreetings(ctx context.Context, req *connect.Request[greetpb.ListGreetingsRequest]) (*connect.Response[greetpb.ListGreetingsResponse], error) {
+	return c.listGreetings.CallUnary(ctx, req)
+}
+
+// GreetServiceHandler is an implementation of the greet.v1.GreetService service.
+type GreetServiceHandler interface {
+	// Unary GRPC
+	Greet(context.Context, *connect.Request[greetpb.GreetRequest]) (*connect.Response[greetpb.GreetResponse], error)
+	// Server streaming
+	GreetManyTimes(context.Context, *connect.Request[greetpb.GreetManyTimesRequest], *connect.ServerStream[greetpb.GreetManyTimesResponse]) error
+	// Client streaming
+	LongGreet(context.Context, *connect.ClientStream[greetpb.LongGreetRequest]) (*connect.Response[greetpb.LongGreetResponse], error)
+	// Bi-directional streaming
+	GreetEveryone(context.Context, *connect.BidiStream[greetpb.GreetEveryoneRequest, greetpb.GreetEveryoneResponse]) error
+	// Lists the greeting templates
+	ListTemplates(context.Context, *connect.Request[greetpb.ListTemplatesRequest]) (*connect.Response[greetpb.ListTemplatesResponse], error)
+	// Adds a greeting template
+	CreateTemplate(context.Context, *connect.Request[greetpb.CreateTemplateRequest]) (*connect.Response[greetpb.Template], error)
+	// Replaces the text of a greeting template
+	UpdateTemplate(context.Context, *connect.Request[greetpb.UpdateTemplateRequest]) (*connect.Response[greetpb.Template], error)
+	// Lists the greetings sent by every method
+	ListGreetings(context.Context, *connect.Request[greetpb.ListGreetingsRequest]) (*connect.Response[greetpb.ListGreetingsResponse], error)
+}
+
+// NewGreetServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewGreetServiceHandler(svc GreetServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	greetServiceMethods := greetpb.File_greet_greetpb_greet_proto.Services().ByName("GreetService").Methods()
+	greetServiceGreetHandler := connect.NewUnaryHandler(
+		GreetServiceGreetProcedure,
+		svc.Greet,
+		connect.WithSchema(greetServiceMethods.ByName("Greet")),
+		connect.WithHandlerOptions(opts...),
+	)
+	greetServiceGreetManyTimesHandler := connect.NewServerStreamHandler(
+		GreetServiceGreetManyTimesProcedure,
+		svc.GreetManyTimes,
+		connect.WithSchema(greetServiceMethods.ByName("GreetManyTimes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	greetServiceLongGreetHandler := connect.NewClientStreamHandler(
+		GreetServiceLongGreetProcedure,
+		svc.LongGreet,
+		connect.WithSchema(greetServiceMethods.ByName("LongGreet")),
+		connect.WithHandlerOptions(opts...),
+	)
+	greetServiceGreetEveryoneHandler := connect.NewBidiStreamHandler(
+		GreetServiceGreetEveryoneProcedure,
+		svc.GreetEveryone,
+		connect.WithSchema(greetServiceMethods.ByName("GreetEveryone")),
+		connect.WithHandlerOptions(opts...),
+	)
+	greetServiceListTemplatesHandler := connect.NewUnaryHandler(
+		GreetServiceListTemplatesProcedure,
+		svc.ListTemplates,
+		connect.WithSchema(greetServiceMethods.ByName("ListTemplates")),
+		connect.WithHandlerOptions(opts...),
+	)
+	greetServiceCreateTemplateHandler := connect.NewUnaryHandler(
+		GreetServiceCreateTemplateProcedure,
+		svc.CreateTemplate,
+		connect.WithSchema(greetServiceMethods.ByName("CreateTemplate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	greetServiceUpdateTemplateHandler := connect.NewUnaryHandler(
+		GreetServiceUpdateTemplateProcedure,
+		svc.UpdateTemplate,
+		connect.WithSchema(greetServiceMethods.ByName("UpdateTemplate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	greetServiceListGreetingsHandler := connect.NewUnaryHandler(
+		GreetServiceListGreetingsProcedure,
+		svc.ListGreetings,
+		connect.WithSchema(greetServiceMethods.ByName("ListGreetings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/greet.v1.GreetService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GreetServiceGreetProcedure:
+			greetServiceGreetHandler.ServeHTTP(w, r)
+		case GreetServiceGreetManyTimesProcedure:
+			greetServiceGreetManyTimesHandler.ServeHTTP(w, r)
+		case GreetServiceLongGreetProcedure:
+			greetServiceLongGreetHandler.ServeHTTP(w, r)
+		case GreetServiceGreetEveryoneProcedure:
+			greetServiceGreetEveryoneHandler.ServeHTTP(w, r)
+		case GreetServiceListTemplatesProcedure:
+			greetServiceListTemplatesHandler.ServeHTTP(w, r)
+		case GreetServiceCreateTemplateProcedure:
+			greetServiceCreateTemplateHandler.ServeHTTP(w, r)
+		case GreetServiceUpdateTemplateProcedure:
+			greetServiceUpdateTemplateHandler.ServeHTTP(w, r)
+		case GreetServiceListGreetingsProcedure:
+			greetServiceListGreetingsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedGreetServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedGreetServiceHandler struct{}
+
+func (UnimplementedGreetServiceHandler) Greet(context.Context, *connect.Request[greetpb.GreetRequest]) (*connect.Response[greetpb.GreetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greet.v1.GreetService.Greet is not implemented"))
+}
+
+func (UnimplementedGreetServiceHandler) GreetManyTimes(context.Context, *connect.Request[greetpb.GreetManyTimesRequest], *connect.ServerStream[greetpb.GreetManyTimesResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("greet.v1.GreetService.GreetManyTimes is not implemented"))
+}
+
+func (UnimplementedGreetServiceHandler) LongGreet(context.Context, *connect.ClientStream[greetpb.LongGreetRequest]) (*connect.Response[greetpb.LongGreetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greet.v1.GreetService.LongGreet is not implemented"))
+}
+
+func (UnimplementedGreetServiceHandler) GreetEveryone(context.Context, *connect.BidiStream[greetpb.GreetEveryoneRequest, greetpb.GreetEveryoneResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("greet.v1.GreetService.GreetEveryone is not implemented"))
+}
+
+func (UnimplementedGreetServiceHandler) ListTemplates(context.Context, *connect.Request[greetpb.ListTemplatesRequest]) (*connect.Response[greetpb.ListTemplatesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greet.v1.GreetService.ListTemplates is not implemented"))
+}
+
+func (UnimplementedGreetServiceHandler) CreateTemplate(context.Context, *connect.Request[greetpb.CreateTemplateRequest]) (*connect.Response[greetpb.Template], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greet.v1.GreetService.CreateTemplate is not implemented"))
+}
+
+func (UnimplementedGreetServiceHandler) UpdateTemplate(context.Context, *connect.Request[greetpb.UpdateTemplateRequest]) (*connect.Response[greetpb.Template], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greet.v1.GreetService.UpdateTemplate is not implemented"))
+}
+
+func (UnimplementedGreetServiceHandler) ListGreetings(context.Context, *connect.Request[greetpb.ListGreetingsRequest]) (*connect.Response[greetpb.ListGreetingsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("greet.v1.GreetService.ListGreetings is not implemented"))
+}
@@ -0,0 +1,11 @@
+package greetpb
+
+import "github.com/AlanKev117/go-grpc/alias"
+
+// LegacyGreetService_ServiceDesc describes GreetService under
+// greet.GreetService, its name before it moved to the greet.v1 package, with
+// the methods it had then. Servers register it next to
+// GreetService_ServiceDesc to keep answering clients built earlier; the
+// messages did not change.
+var LegacyGreetService_ServiceDesc = alias.Desc(GreetService_ServiceDesc, "greet.GreetService",
+	"Greet", "GreetManyTimes", "LongGreet", "GreetEveryone")
@@ -0,0 +1,9 @@
+package greetpb
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI v2 spec of the HTTP/JSON mapping of GreetService,
+// generated by protoc-gen-openapiv2.
+//
+//go:embed greet.swagger.json
+var OpenAPI []byte
@@ -0,0 +1,30 @@
+package history
+
+import (
+	"flag"
+	"log"
+)
+
+// Flags selects the history store of a server from the command line.
+type Flags struct {
+	// Path is the SQLite file to use. When empty, the last Limit entries
+	// are kept in memory.
+	Path  string
+	Limit int
+}
+
+// RegisterFlags registers the history flags on fs, using the current values
+// as defaults.
+func (f *Flags) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&f.Path, "history-db", f.Path, "SQLite file recording the greetings sent; in memory when empty")
+	fs.IntVar(&f.Limit, "history-limit", f.Limit, "how many greetings are kept when they are recorded in memory")
+}
+
+// Open opens the selected store.
+func (f *Flags) Open() (Store, error) {
+	if f.Path == "" {
+		log.Printf("Greeting history is kept in memory, up to %d greetings.", f.Limit)
+		return NewMemory(f.Limit), nil
+	}
+	return OpenSQLite(f.Path)
+}
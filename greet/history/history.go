@@ -0,0 +1,147 @@
+// Package history records the greetings GreetService sends, so that they can
+// be listed later by name and time.
+package history
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidCursor is returned for a cursor no store returned.
+var ErrInvalidCursor = errors.New("invalid page token")
+
+// Entry is a greeting sent by the server.
+type Entry struct {
+	// ID is set by the store, increasing with every entry.
+	ID         int64
+	Time       time.Time
+	Method     string
+	Caller     string
+	FirstName  string
+	SecondName string
+	Result     string
+	Locale     string
+}
+
+// Query selects entries.
+type Query struct {
+	// Name matches the first name, second name or full name of the entries,
+	// ignoring case. Empty matches every entry.
+	Name string
+	// Start and End select the entries from Start, included, to End,
+	// excluded. Zero values leave the range open.
+	Start, End time.Time
+	// Limit is how many entries to return at most, all of them when 0.
+	Limit int
+	// Cursor continues the listing after the last page.
+	Cursor string
+}
+
+// Store records entries. Implementations are safe for concurrent use.
+type Store interface {
+	// Record adds e, setting its ID.
+	Record(ctx context.Context, e *Entry) error
+	// List returns the entries matching q, newest first, and the cursor of
+	// the next page, which is empty after the last one.
+	List(ctx context.Context, q Query) ([]*Entry, string, error)
+	// Close releases the resources held by the store.
+	Close() error
+}
+
+// fold returns the form of a name compared by queries.
+func fold(name string) string {
+	return strings.ToLower(name)
+}
+
+// cursor returns the cursor of the page following the entry id.
+func cursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+// parseCursor returns the ID of the entry preceding the page of c, or 0
+// when c is empty.
+func parseCursor(c string) (int64, error) {
+	if c == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(c)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	id, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidCursor
+	}
+	return id, nil
+}
+
+// Memory is a Store keeping the most recent entries in memory, so they are
+// lost when the process exits.
+type Memory struct {
+	limit int
+
+	mu      sync.RWMutex
+	entries []*Entry // oldest first
+	lastID  int64
+}
+
+// NewMemory returns an empty Memory store keeping up to limit entries.
+func NewMemory(limit int) *Memory {
+	return &Memory{limit: limit}
+}
+
+func (m *Memory) Record(ctx context.Context, e *Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastID++
+	e.ID = m.lastID
+	entry := *e
+	m.entries = append(m.entries, &entry)
+	if len(m.entries) > m.limit {
+		// append copies the entries left to a new array once this one is
+		// full.
+		m.entries = m.entries[len(m.entries)-m.limit:]
+	}
+	return nil
+}
+
+func (m *Memory) List(ctx context.Context, q Query) ([]*Entry, string, error) {
+	after, err := parseCursor(q.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	name := fold(q.Name)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var list []*Entry
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		e := m.entries[i]
+		switch {
+		case after > 0 && e.ID >= after,
+			!q.Start.IsZero() && e.Time.Before(q.Start),
+			!q.End.IsZero() && !e.Time.Before(q.End):
+			continue
+		case name != "":
+			first, second := fold(e.FirstName), fold(e.SecondName)
+			if name != first && name != second && name != first+" "+second {
+				continue
+			}
+		}
+		if q.Limit > 0 && len(list) == q.Limit {
+			return list, cursor(list[len(list)-1].ID), nil
+		}
+		entry := *e
+		list = append(list, &entry)
+	}
+	return list, "", nil
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
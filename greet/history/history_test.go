@@ -0,0 +1,166 @@
+package history
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// stores returns an empty store of every kind.
+func stores(t *testing.T) map[string]Store {
+	t.Helper()
+	sqlite, err := OpenSQLite(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlite.Close() })
+	return map[string]Store{
+		"memory": NewMemory(100),
+		"sqlite": sqlite,
+	}
+}
+
+var epoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// people are recorded one minute apart from epoch.
+var people = [][2]string{
+	{"Alan", "Kevin"},
+	{"Émile", "Zola"},
+	{"Ana", "García"},
+	{"Alan", "Turing"},
+	{"Luis", "García"},
+}
+
+func record(t *testing.T, s Store) {
+	t.Helper()
+	for i, p := range people {
+		e := &Entry{
+			Time:       epoch.Add(time.Duration(i) * time.Minute),
+			Method:     "/greet.v1.GreetService/Greet",
+			Caller:     "alice",
+			FirstName:  p[0],
+			SecondName: p[1],
+			Result:     "Hello, " + p[0] + " " + p[1],
+			Locale:     "en",
+		}
+		if err := s.Record(context.Background(), e); err != nil {
+			t.Fatal(err)
+		}
+		if e.ID == 0 {
+			t.Fatal("Record did not set the ID")
+		}
+	}
+}
+
+// fullNames lists the full names of entries.
+func fullNames(entries []*Entry) []string {
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = e.FirstName + " " + e.SecondName
+	}
+	return names
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{"everyone newest first", Query{}, []string{"Luis García", "Alan Turing", "Ana García", "Émile Zola", "Alan Kevin"}},
+		{"first name", Query{Name: "alan"}, []string{"Alan Turing", "Alan Kevin"}},
+		{"second name", Query{Name: "GARCÍA"}, []string{"Luis García", "Ana García"}},
+		{"full name", Query{Name: "émile zola"}, []string{"Émile Zola"}},
+		{"part of a name", Query{Name: "Al"}, []string{}},
+		{"start", Query{Start: epoch.Add(3 * time.Minute)}, []string{"Luis García", "Alan Turing"}},
+		{"end", Query{End: epoch.Add(time.Minute)}, []string{"Alan Kevin"}},
+		{"range and name", Query{Name: "García", Start: epoch.Add(time.Minute), End: epoch.Add(3 * time.Minute)}, []string{"Ana García"}},
+	}
+	for kind, s := range stores(t) {
+		record(t, s)
+		for _, tt := range tests {
+			t.Run(kind+"/"+tt.name, func(t *testing.T) {
+				entries, next, err := s.List(context.Background(), tt.query)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := fullNames(entries)
+				if len(got) != len(tt.want) {
+					t.Fatalf("List = %q, want %q", got, tt.want)
+				}
+				for i := range got {
+					if got[i] != tt.want[i] {
+						t.Fatalf("List = %q, want %q", got, tt.want)
+					}
+				}
+				if next != "" {
+					t.Errorf("next cursor = %q after the only page", next)
+				}
+			})
+		}
+
+		t.Run(kind+"/fields", func(t *testing.T) {
+			entries, _, err := s.List(context.Background(), Query{Name: "Zola"})
+			if err != nil || len(entries) != 1 {
+				t.Fatalf("List = %v, %v", entries, err)
+			}
+			e := entries[0]
+			if !e.Time.Equal(epoch.Add(time.Minute)) || e.Method != "/greet.v1.GreetService/Greet" ||
+				e.Caller != "alice" || e.Result != "Hello, Émile Zola" || e.Locale != "en" {
+				t.Errorf("entry = %+v", e)
+			}
+		})
+	}
+}
+
+func TestPages(t *testing.T) {
+	for kind, s := range stores(t) {
+		t.Run(kind, func(t *testing.T) {
+			record(t, s)
+			q := Query{Limit: 2}
+			var got []string
+			for pages := 1; ; pages++ {
+				entries, next, err := s.List(context.Background(), q)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, fullNames(entries)...)
+				if next == "" {
+					if pages != 3 {
+						t.Errorf("got %d pages, want 3", pages)
+					}
+					break
+				}
+				// New entries do not show up in the next pages.
+				s.Record(context.Background(), &Entry{Time: epoch, FirstName: "Late"})
+				q.Cursor = next
+			}
+			if len(got) != len(people) {
+				t.Errorf("pages hold %q, want every person once", got)
+			}
+
+			// A page that is exactly full has no next page.
+			entries, next, err := s.List(context.Background(), Query{Name: "Alan", Limit: 2})
+			if err != nil || len(entries) != 2 || next != "" {
+				t.Errorf("List of a full last page = %d entries, next %q, %v", len(entries), next, err)
+			}
+
+			if _, _, err := s.List(context.Background(), Query{Cursor: "not a cursor"}); err != ErrInvalidCursor {
+				t.Errorf("List with an invalid cursor = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestMemoryLimit(t *testing.T) {
+	m := NewMemory(3)
+	record(t, m)
+	entries, _, err := m.List(context.Background(), Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fullNames(entries); len(got) != 3 || got[2] != "Ana García" {
+		t.Errorf("List = %q, want the last 3 people", got)
+	}
+}
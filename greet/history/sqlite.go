@@ -0,0 +1,124 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3" // registers the sqlite3 driver
+)
+
+// schema creates the table of the entries. The folded names are what
+// queries compare, as SQLite only lowercases ASCII letters.
+const schema = `
+CREATE TABLE IF NOT EXISTS greetings (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	time INTEGER NOT NULL,
+	method TEXT NOT NULL,
+	caller TEXT NOT NULL,
+	first_name TEXT NOT NULL,
+	second_name TEXT NOT NULL,
+	result TEXT NOT NULL,
+	locale TEXT NOT NULL,
+	first_folded TEXT NOT NULL,
+	second_folded TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS greetings_time ON greetings (time);
+CREATE INDEX IF NOT EXISTS greetings_first_folded ON greetings (first_folded);
+CREATE INDEX IF NOT EXISTS greetings_second_folded ON greetings (second_folded);
+`
+
+// SQLite is a Store keeping entries in a SQLite database file.
+type SQLite struct {
+	db *sql.DB
+}
+
+// OpenSQLite opens the SQLite database at path, creating it if needed.
+func OpenSQLite(path string) (*SQLite, error) {
+	db, err := sql.Open("sqlite3", path+"?_busy_timeout=5000&_journal_mode=WAL")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLite{db: db}, nil
+}
+
+func (s *SQLite) Record(ctx context.Context, e *Entry) error {
+	res, err := s.db.ExecContext(ctx, `INSERT INTO greetings
+		(time, method, caller, first_name, second_name, result, locale, first_folded, second_folded)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		e.Time.UnixNano(), e.Method, e.Caller, e.FirstName, e.SecondName, e.Result, e.Locale,
+		fold(e.FirstName), fold(e.SecondName))
+	if err != nil {
+		return err
+	}
+	e.ID, err = res.LastInsertId()
+	return err
+}
+
+func (s *SQLite) List(ctx context.Context, q Query) ([]*Entry, string, error) {
+	after, err := parseCursor(q.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := `SELECT id, time, method, caller, first_name, second_name, result, locale
+		FROM greetings WHERE 1 = 1`
+	var args []interface{}
+	if after > 0 {
+		query += ` AND id < ?`
+		args = append(args, after)
+	}
+	if !q.Start.IsZero() {
+		query += ` AND time >= ?`
+		args = append(args, q.Start.UnixNano())
+	}
+	if !q.End.IsZero() {
+		query += ` AND time < ?`
+		args = append(args, q.End.UnixNano())
+	}
+	if q.Name != "" {
+		name := fold(q.Name)
+		query += ` AND (first_folded = ? OR second_folded = ? OR first_folded || ' ' || second_folded = ?)`
+		args = append(args, name, name, name)
+	}
+	query += ` ORDER BY id DESC`
+	if q.Limit > 0 {
+		// One more entry tells whether there is a next page.
+		query += ` LIMIT ?`
+		args = append(args, q.Limit+1)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	var list []*Entry
+	for rows.Next() {
+		var e Entry
+		var nanos int64
+		err := rows.Scan(&e.ID, &nanos, &e.Method, &e.Caller, &e.FirstName, &e.SecondName, &e.Result, &e.Locale)
+		if err != nil {
+			return nil, "", err
+		}
+		e.Time = time.Unix(0, nanos)
+		list = append(list, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	if q.Limit > 0 && len(list) > q.Limit {
+		list = list[:q.Limit]
+		return list, cursor(list[len(list)-1].ID), nil
+	}
+	return list, "", nil
+}
+
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,198 @@
+// Package i18n holds the greetings of GreetService in every supported
+// language, and picks the language to greet in from the preferences of a
+// caller.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// nameOrder is how a language writes a full name.
+type nameOrder int
+
+const (
+	// givenFirst writes the given name, a space and the family name.
+	givenFirst nameOrder = iota
+	// familyFirst writes the family name first, e.g. "Nagy Anna" in
+	// Hungarian.
+	familyFirst
+	// familyFirstNative writes the family name first with no space, e.g.
+	// "山田太郎", for names in the script of the language. Names in Latin
+	// script keep the order they have in their own language.
+	familyFirstNative
+)
+
+// listStyle is how a language writes a list of names.
+type listStyle struct {
+	// sep separates the names but the last two, which and separates.
+	sep string
+	and string
+	// others summarizes the names left out of a list, formatted with their
+	// number: the first when there is one, the second otherwise.
+	others [2]string
+}
+
+// commas is the style of languages separating names with commas.
+func commas(and, one, other string) listStyle {
+	return listStyle{sep: ", ", and: and, others: [2]string{one, other}}
+}
+
+// Locale is a language greetings can be written in.
+type Locale struct {
+	tag language.Tag
+	// informal and formal are the greetings, formatted with the full name.
+	informal string
+	formal   string
+	order    nameOrder
+	list     listStyle
+}
+
+// English is the language used when no preference of the caller is
+// supported.
+var English = &Locale{tag: language.English, informal: "Hello, %s", formal: "Good day, %s",
+	list: commas(" and ", " and 1 other", " and %d others")}
+
+// locales lists the supported languages, English first as the fallback.
+var locales = []*Locale{
+	English,
+	{tag: language.Spanish, informal: "Hola, %s", formal: "Buenos días, %s",
+		list: commas(" y ", " y 1 más", " y %d más")},
+	{tag: language.French, informal: "Salut, %s", formal: "Bonjour, %s",
+		list: commas(" et ", " et 1 autre", " et %d autres")},
+	{tag: language.German, informal: "Hallo, %s", formal: "Guten Tag, %s",
+		list: commas(" und ", " und 1 weitere Person", " und %d weitere Personen")},
+	{tag: language.Italian, informal: "Ciao, %s", formal: "Buongiorno, %s",
+		list: commas(" e ", " e un altro", " e altri %d")},
+	{tag: language.Portuguese, informal: "Olá, %s", formal: "Bom dia, %s",
+		list: commas(" e ", " e mais 1", " e mais %d")},
+	{tag: language.Dutch, informal: "Hoi, %s", formal: "Goedendag, %s",
+		list: commas(" en ", " en 1 ander", " en %d anderen")},
+	{tag: language.Russian, informal: "Привет, %s", formal: "Здравствуйте, %s",
+		list: commas(" и ", " и ещё 1", " и ещё %d")},
+	{tag: language.Turkish, informal: "Merhaba, %s", formal: "İyi günler, %s",
+		list: commas(" ve ", " ve 1 kişi daha", " ve %d kişi daha")},
+	{tag: language.Hungarian, informal: "Szia, %s!", formal: "Jó napot, %s!", order: familyFirst,
+		list: commas(" és ", " és még 1 fő", " és még %d fő")},
+	{tag: language.Vietnamese, informal: "Chào %s", formal: "Xin chào %s", order: familyFirst,
+		list: commas(" và ", " và 1 người khác", " và %d người khác")},
+	{tag: language.Japanese, informal: "こんにちは、%sさん", formal: "%s様、こんにちは", order: familyFirstNative,
+		list: listStyle{sep: "、", and: "、", others: [2]string{"、他1名", "、他%d名"}}},
+	{tag: language.Korean, informal: "안녕, %s", formal: "안녕하세요, %s님", order: familyFirstNative,
+		list: commas(" 및 ", " 외 1명", " 외 %d명")},
+	{tag: language.SimplifiedChinese, informal: "你好，%s", formal: "您好，%s", order: familyFirstNative,
+		list: listStyle{sep: "、", and: "和", others: [2]string{"和另外1人", "和另外%d人"}}},
+	{tag: language.TraditionalChinese, informal: "你好，%s", formal: "您好，%s", order: familyFirstNative,
+		list: listStyle{sep: "、", and: "和", others: [2]string{"和另外1人", "和另外%d人"}}},
+}
+
+var matcher = func() language.Matcher {
+	tags := make([]language.Tag, len(locales))
+	for i, l := range locales {
+		tags[i] = l.tag
+	}
+	return language.NewMatcher(tags)
+}()
+
+// Match returns the locale to greet in. Each preference is a BCP 47 tag or
+// an Accept-Language header value; the first one with a supported language
+// wins, so that "es-MX" is greeted in Spanish and "zh-TW" in Traditional
+// Chinese. Languages that are not supported fall back to one their speakers
+// commonly understand when CLDR knows one, e.g. Galician to Spanish. Other
+// preferences, and invalid ones, are skipped, and English is used when none
+// is left.
+func Match(preferences ...string) *Locale {
+	for _, pref := range preferences {
+		tags, _, err := language.ParseAcceptLanguage(pref)
+		if err != nil || len(tags) == 0 {
+			continue
+		}
+		if _, i, confidence := matcher.Match(tags...); confidence != language.No {
+			return locales[i]
+		}
+	}
+	return English
+}
+
+// String returns the BCP 47 tag of the locale.
+func (l *Locale) String() string {
+	return l.tag.String()
+}
+
+// Greet greets the person with the given and family names, formally or
+// not.
+func (l *Locale) Greet(given, family string, formal bool) string {
+	return l.GreetName(l.Name(given, family), formal)
+}
+
+// GreetName greets whoever is named, formally or not. The name is used as
+// is, so it can be a full name or several names joined together.
+func (l *Locale) GreetName(name string, formal bool) string {
+	format := l.informal
+	if formal {
+		format = l.formal
+	}
+	return fmt.Sprintf(format, name)
+}
+
+// Name writes a full name the way the locale does. Either name can be
+// empty.
+func (l *Locale) Name(given, family string) string {
+	switch {
+	case family == "":
+		return given
+	case given == "":
+		return family
+	}
+	switch l.order {
+	case familyFirst:
+		return family + " " + given
+	case familyFirstNative:
+		if !latin(given) && !latin(family) {
+			return family + given
+		}
+	}
+	return given + " " + family
+}
+
+// List joins names the way the locale writes lists, e.g. "A, B and C", or
+// "A, B and 3 others" when others names are left out.
+func (l *Locale) List(names []string, others int) string {
+	if others > 0 {
+		return strings.Join(names, l.list.sep) + l.Others(others)
+	}
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	last := len(names) - 1
+	return strings.Join(names[:last], l.list.sep) + l.list.and + names[last]
+}
+
+// Others summarizes n names left out of a list, to be appended to it, e.g.
+// " and 3 others". It is empty when n is 0.
+func (l *Locale) Others(n int) string {
+	switch n {
+	case 0:
+		return ""
+	case 1:
+		return l.list.others[0]
+	}
+	return fmt.Sprintf(l.list.others[1], n)
+}
+
+// Collator returns a collator sorting names the way the locale does. It is
+// not safe for concurrent use.
+func (l *Locale) Collator() *collate.Collator {
+	return collate.New(l.tag)
+}
+
+// latin reports whether s has letters of the Latin script.
+func latin(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool {
+		return unicode.Is(unicode.Latin, r)
+	}) >= 0
+}
@@ -0,0 +1,113 @@
+package i18n
+
+import "testing"
+
+func TestGreet(t *testing.T) {
+	tests := []struct {
+		locale           string
+		given, family    string
+		informal, formal string
+	}{
+		{"en", "Alan", "Kevin", "Hello, Alan Kevin", "Good day, Alan Kevin"},
+		{"es", "Dani", "Elías", "Hola, Dani Elías", "Buenos días, Dani Elías"},
+		{"fr", "Claire", "Martin", "Salut, Claire Martin", "Bonjour, Claire Martin"},
+		{"de", "Jonas", "Weber", "Hallo, Jonas Weber", "Guten Tag, Jonas Weber"},
+		{"it", "Giulia", "Rossi", "Ciao, Giulia Rossi", "Buongiorno, Giulia Rossi"},
+		{"pt", "João", "Silva", "Olá, João Silva", "Bom dia, João Silva"},
+		{"nl", "Daan", "de Vries", "Hoi, Daan de Vries", "Goedendag, Daan de Vries"},
+		{"ru", "Иван", "Петров", "Привет, Иван Петров", "Здравствуйте, Иван Петров"},
+		{"tr", "Ayşe", "Yılmaz", "Merhaba, Ayşe Yılmaz", "İyi günler, Ayşe Yılmaz"},
+		{"hu", "Anna", "Nagy", "Szia, Nagy Anna!", "Jó napot, Nagy Anna!"},
+		{"vi", "Lan", "Nguyễn", "Chào Nguyễn Lan", "Xin chào Nguyễn Lan"},
+		{"ja", "太郎", "山田", "こんにちは、山田太郎さん", "山田太郎様、こんにちは"},
+		{"ko", "민준", "김", "안녕, 김민준", "안녕하세요, 김민준님"},
+		{"zh-Hans", "小明", "王", "你好，王小明", "您好，王小明"},
+		{"zh-Hant", "美玲", "陳", "你好，陳美玲", "您好，陳美玲"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			l := Match(tt.locale)
+			if got := l.Greet(tt.given, tt.family, false); got != tt.informal {
+				t.Errorf("informal greeting = %q, want %q", got, tt.informal)
+			}
+			if got := l.Greet(tt.given, tt.family, true); got != tt.formal {
+				t.Errorf("formal greeting = %q, want %q", got, tt.formal)
+			}
+		})
+	}
+}
+
+func TestLatinNamesInFamilyFirstLocales(t *testing.T) {
+	for _, locale := range []string{"ja", "ko", "zh"} {
+		if got := Match(locale).Name("Alan", "Kevin"); got != "Alan Kevin" {
+			t.Errorf("%s: Name(Alan, Kevin) = %q, want %q", locale, got, "Alan Kevin")
+		}
+	}
+}
+
+func TestMissingNames(t *testing.T) {
+	for _, l := range locales {
+		if got := l.Name("Ana", ""); got != "Ana" {
+			t.Errorf("%v: Name(Ana, \"\") = %q, want Ana", l, got)
+		}
+		if got := l.Name("", "Nagy"); got != "Nagy" {
+			t.Errorf("%v: Name(\"\", Nagy) = %q, want Nagy", l, got)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		preferences []string
+		want        string
+	}{
+		{"none", nil, "en"},
+		{"region", []string{"es-MX"}, "es"},
+		{"brazilian portuguese", []string{"pt-BR"}, "pt"},
+		{"taiwan", []string{"zh-TW"}, "zh-Hant"},
+		{"mainland", []string{"zh-CN"}, "zh-Hans"},
+		{"unsupported", []string{"nb"}, "en"},
+		{"related language", []string{"gl"}, "es"},
+		{"invalid", []string{"not a locale!"}, "en"},
+		{"first supported wins", []string{"ca", "fr-CA"}, "fr"},
+		{"field before header", []string{"de", "ja;q=1"}, "de"},
+		{"empty field", []string{"", "ko-KR,ko;q=0.9,en;q=0.8"}, "ko"},
+		{"header weights", []string{"en;q=0.5, it;q=0.9"}, "it"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Match(tt.preferences...).String(); got != tt.want {
+				t.Errorf("Match(%q) = %s, want %s", tt.preferences, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		locale string
+		names  []string
+		others int
+		want   string
+	}{
+		{"en", nil, 0, ""},
+		{"en", []string{"Ana"}, 0, "Ana"},
+		{"en", []string{"Ana", "Luis"}, 0, "Ana and Luis"},
+		{"en", []string{"Ana", "Luis", "Eva"}, 0, "Ana, Luis and Eva"},
+		{"en", []string{"Ana", "Luis"}, 1, "Ana, Luis and 1 other"},
+		{"en", []string{"Ana", "Luis"}, 3, "Ana, Luis and 3 others"},
+		{"es", []string{"Ana", "Luis", "Eva"}, 0, "Ana, Luis y Eva"},
+		{"es", []string{"Ana"}, 2, "Ana y 2 más"},
+		{"hu", []string{"Anna", "Péter"}, 0, "Anna és Péter"},
+		{"ja", []string{"太郎", "花子", "次郎"}, 0, "太郎、花子、次郎"},
+		{"ja", []string{"太郎"}, 2, "太郎、他2名"},
+		{"ko", []string{"민준", "서연"}, 4, "민준, 서연 외 4명"},
+		{"zh-Hans", []string{"小明", "小红", "小刚"}, 0, "小明、小红和小刚"},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.locale).List(tt.names, tt.others); got != tt.want {
+			t.Errorf("%s: List(%q, %d) = %q, want %q", tt.locale, tt.names, tt.others, got, tt.want)
+		}
+	}
+}
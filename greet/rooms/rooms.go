@@ -0,0 +1,256 @@
+// Package rooms fans greetings out to every member of a room, so that
+// GreetEveryone clients joining the same room greet each other.
+package rooms
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// ErrSlowConsumer is the error of a member disconnected because its buffer
+// filled up.
+var ErrSlowConsumer = errors.New("disconnected from the room for not keeping up with its events")
+
+// Policy is what happens to an event for a member whose buffer is full.
+type Policy int
+
+const (
+	// Drop drops the event. The next event delivered to the member counts
+	// the events it missed.
+	Drop Policy = iota
+	// Disconnect removes the member from the room.
+	Disconnect
+)
+
+// ParsePolicy parses "drop" or "disconnect".
+func ParsePolicy(s string) (Policy, error) {
+	switch s {
+	case "drop":
+		return Drop, nil
+	case "disconnect":
+		return Disconnect, nil
+	}
+	return 0, fmt.Errorf("unknown slow consumer policy %q, want drop or disconnect", s)
+}
+
+func (p Policy) String() string {
+	if p == Disconnect {
+		return "disconnect"
+	}
+	return "drop"
+}
+
+// EventType tells what happened in a room.
+type EventType int
+
+const (
+	// Joined is sent when a member joins, to the member as well.
+	Joined EventType = iota + 1
+	// Left is sent when a member leaves or is disconnected.
+	Left
+	// Greeting carries a greeting published by a member.
+	Greeting
+)
+
+// Event is something that happened in a room.
+type Event struct {
+	Type EventType
+	// MemberID and Name identify the member who joined, left or greeted.
+	MemberID string
+	Name     string
+	// Result is the greeting of Greeting events.
+	Result string
+	// Dropped is how many events the receiving member missed before this
+	// one because its buffer was full.
+	Dropped uint64
+}
+
+// Hub holds the rooms of a server. It is safe for concurrent use.
+type Hub struct {
+	buffer int
+	policy Policy
+
+	mu     sync.Mutex
+	rooms  map[string]*room
+	nextID uint64
+}
+
+// NewHub returns a Hub buffering up to buffer events for each member, and
+// applying policy to the members whose buffer is full. buffer must be
+// positive, leaving room for the Joined event of a new member.
+func NewHub(buffer int, policy Policy) *Hub {
+	return &Hub{buffer: buffer, policy: policy, rooms: make(map[string]*room)}
+}
+
+type room struct {
+	id  string
+	hub *Hub
+
+	mu      sync.Mutex
+	members map[string]*Member
+	// closed is set once the last member left. Members joining the ID
+	// afterwards get a new room.
+	closed bool
+}
+
+// Member is a subscriber of a room.
+type Member struct {
+	ID   string
+	Name string
+
+	room   *room
+	events chan Event
+	// dropped, left and err are guarded by room.mu.
+	dropped uint64
+	left    bool
+	err     error
+}
+
+// Join adds a member named name to the room id, creating the room if
+// needed. Every member, the new one included, gets a Joined event.
+func (h *Hub) Join(id, name string) *Member {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextID++
+	m := &Member{
+		ID:     strconv.FormatUint(h.nextID, 10),
+		Name:   name,
+		events: make(chan Event, h.buffer),
+	}
+
+	r := h.rooms[id]
+	if r != nil {
+		r.mu.Lock()
+		if r.closed {
+			r.mu.Unlock()
+			r = nil
+		}
+	}
+	if r == nil {
+		r = &room{id: id, hub: h, members: make(map[string]*Member)}
+		h.rooms[id] = r
+		r.mu.Lock()
+	}
+	defer r.mu.Unlock()
+
+	m.room = r
+	r.members[m.ID] = m
+	r.broadcast(Event{Type: Joined, MemberID: m.ID, Name: m.Name})
+	return m
+}
+
+// Size returns how many members the room id has.
+func (h *Hub) Size(id string) int {
+	h.mu.Lock()
+	r := h.rooms[id]
+	h.mu.Unlock()
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.members)
+}
+
+// Events returns the events of the room for m. It is closed once m left the
+// room or was disconnected.
+func (m *Member) Events() <-chan Event {
+	return m.events
+}
+
+// Err returns ErrSlowConsumer once m was disconnected for being slow, and
+// nil otherwise.
+func (m *Member) Err() error {
+	m.room.mu.Lock()
+	defer m.room.mu.Unlock()
+	return m.err
+}
+
+// Publish sends a greeting to every member of the room, m included. It
+// does nothing once m left the room.
+func (m *Member) Publish(result string) {
+	r := m.room
+	r.mu.Lock()
+	if !m.left {
+		r.broadcast(Event{Type: Greeting, MemberID: m.ID, Name: m.Name, Result: result})
+	}
+	empty := r.closed
+	r.mu.Unlock()
+	if empty {
+		r.forget()
+	}
+}
+
+// Leave removes m from the room and tells the other members. Leaving more
+// than once does nothing.
+func (m *Member) Leave() {
+	r := m.room
+	r.mu.Lock()
+	if !m.left {
+		r.remove(m, nil)
+	}
+	empty := r.closed
+	r.mu.Unlock()
+	if empty {
+		r.forget()
+	}
+}
+
+// broadcast delivers e to every member. Members disconnected for being
+// slow are removed, and their Left events broadcast in turn. It must be
+// called with r.mu held.
+func (r *room) broadcast(e Event) {
+	pending := []Event{e}
+	for len(pending) > 0 {
+		e, pending = pending[0], pending[1:]
+		for _, m := range r.members {
+			e := e
+			e.Dropped = m.dropped
+			select {
+			case m.events <- e:
+				m.dropped = 0
+				continue
+			default:
+			}
+			if r.hub.policy == Drop {
+				m.dropped++
+				continue
+			}
+			pending = append(pending, r.remove(m, ErrSlowConsumer)...)
+		}
+	}
+}
+
+// remove takes m out of the room, closing its events with err, and returns
+// the Left event the other members must get. The event is broadcast right
+// away unless err is set, in which case broadcast is already running and
+// delivers it. It must be called with r.mu held.
+func (r *room) remove(m *Member, err error) []Event {
+	delete(r.members, m.ID)
+	m.left = true
+	m.err = err
+	close(m.events)
+	if len(r.members) == 0 {
+		r.closed = true
+		return nil
+	}
+	left := Event{Type: Left, MemberID: m.ID, Name: m.Name}
+	if err != nil {
+		return []Event{left}
+	}
+	r.broadcast(left)
+	return nil
+}
+
+// forget removes the closed room r from its hub.
+func (r *room) forget() {
+	h := r.hub
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.rooms[r.id] == r {
+		delete(h.rooms, r.id)
+	}
+}
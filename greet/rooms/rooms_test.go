@@ -0,0 +1,193 @@
+package rooms
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// next returns the next event of m, failing when there is none.
+func next(t *testing.T, m *Member) Event {
+	t.Helper()
+	select {
+	case e, ok := <-m.Events():
+		if !ok {
+			t.Fatalf("events of member %s closed", m.ID)
+		}
+		return e
+	default:
+		t.Fatalf("no event for member %s", m.ID)
+	}
+	return Event{}
+}
+
+func TestJoinAndLeave(t *testing.T) {
+	h := NewHub(8, Drop)
+	a := h.Join("lobby", "Ana")
+	if e := next(t, a); e.Type != Joined || e.MemberID != a.ID || e.Name != "Ana" {
+		t.Errorf("first event of a = %+v, want it joining", e)
+	}
+
+	b := h.Join("lobby", "Luis")
+	for _, m := range []*Member{a, b} {
+		if e := next(t, m); e.Type != Joined || e.MemberID != b.ID {
+			t.Errorf("member %s got %+v, want b joining", m.ID, e)
+		}
+	}
+	if got := h.Size("lobby"); got != 2 {
+		t.Errorf("Size = %d, want 2", got)
+	}
+
+	b.Leave()
+	b.Leave()
+	if e := next(t, a); e.Type != Left || e.MemberID != b.ID {
+		t.Errorf("a got %+v, want b leaving", e)
+	}
+	if _, ok := <-b.Events(); ok {
+		t.Error("events of b are still open after leaving")
+	}
+	if err := b.Err(); err != nil {
+		t.Errorf("Err after leaving = %v, want nil", err)
+	}
+
+	a.Leave()
+	if got := h.Size("lobby"); got != 0 {
+		t.Errorf("Size of the empty room = %d, want 0", got)
+	}
+	if len(h.rooms) != 0 {
+		t.Errorf("hub still holds %d rooms", len(h.rooms))
+	}
+}
+
+func TestRoomsAreSeparate(t *testing.T) {
+	h := NewHub(8, Drop)
+	a := h.Join("one", "Ana")
+	b := h.Join("two", "Luis")
+	next(t, a)
+	next(t, b)
+
+	a.Publish("Hello, Ana")
+	if e := next(t, a); e.Type != Greeting || e.Result != "Hello, Ana" {
+		t.Errorf("a got %+v, want its own greeting", e)
+	}
+	select {
+	case e := <-b.Events():
+		t.Errorf("b got %+v from another room", e)
+	default:
+	}
+}
+
+func TestDropPolicy(t *testing.T) {
+	h := NewHub(2, Drop)
+	a := h.Join("lobby", "Ana")
+	b := h.Join("lobby", "Luis")
+	// a now holds both Joined events and has no room for more.
+	for i := 0; i < 5; i++ {
+		next(t, b)
+		b.Publish(fmt.Sprint("greeting ", i))
+	}
+	next(t, a)
+	next(t, a)
+
+	b.Publish("last")
+	e := next(t, a)
+	if e.Result != "last" || e.Dropped != 5 {
+		t.Errorf("a got %+v, want the last greeting after 5 dropped", e)
+	}
+	b.Publish("again")
+	if e := next(t, a); e.Dropped != 0 {
+		t.Errorf("a got %+v, want no more drops", e)
+	}
+	if err := a.Err(); err != nil {
+		t.Errorf("Err = %v, want nil under the drop policy", err)
+	}
+}
+
+func TestDisconnectPolicy(t *testing.T) {
+	h := NewHub(2, Disconnect)
+	a := h.Join("lobby", "Ana")
+	b := h.Join("lobby", "Luis")
+	next(t, b)
+
+	b.Publish("Hello")
+	if e := next(t, b); e.Type != Greeting {
+		t.Errorf("b got %+v, want its greeting", e)
+	}
+	if e := next(t, b); e.Type != Left || e.MemberID != a.ID {
+		t.Errorf("b got %+v, want a leaving", e)
+	}
+
+	// a still gets the events buffered before it was disconnected.
+	next(t, a)
+	next(t, a)
+	if _, ok := <-a.Events(); ok {
+		t.Error("events of a are still open after it was disconnected")
+	}
+	if err := a.Err(); err != ErrSlowConsumer {
+		t.Errorf("Err = %v, want ErrSlowConsumer", err)
+	}
+	if got := h.Size("lobby"); got != 1 {
+		t.Errorf("Size = %d, want 1", got)
+	}
+	a.Leave()
+}
+
+func TestConcurrentMembers(t *testing.T) {
+	const members, greetings = 50, 20
+	// Every event fits in the buffers, so none is dropped.
+	h := NewHub(2*members+members*greetings, Disconnect)
+
+	joined := make([]*Member, members)
+	var wg sync.WaitGroup
+	for i := range joined {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			joined[i] = h.Join("lobby", fmt.Sprint("member ", i))
+		}(i)
+	}
+	wg.Wait()
+
+	for _, m := range joined {
+		wg.Add(1)
+		go func(m *Member) {
+			defer wg.Done()
+			for i := 0; i < greetings; i++ {
+				m.Publish(fmt.Sprint(i))
+			}
+		}(m)
+	}
+	wg.Wait()
+
+	for _, m := range joined {
+		// Greetings of each member arrive in the order they were sent.
+		next := make(map[string]int)
+		total := 0
+		for len(m.Events()) > 0 {
+			e := <-m.Events()
+			if e.Type != Greeting {
+				continue
+			}
+			if want := fmt.Sprint(next[e.MemberID]); e.Result != want {
+				t.Fatalf("member %s got greeting %s of %s, want %s", m.ID, e.Result, e.MemberID, want)
+			}
+			next[e.MemberID]++
+			total++
+		}
+		if total != members*greetings {
+			t.Errorf("member %s got %d greetings, want %d", m.ID, total, members*greetings)
+		}
+	}
+
+	for _, m := range joined {
+		wg.Add(1)
+		go func(m *Member) {
+			defer wg.Done()
+			m.Leave()
+		}(m)
+	}
+	wg.Wait()
+	if got := h.Size("lobby"); got != 0 {
+		t.Errorf("Size = %d after everyone left, want 0", got)
+	}
+}
@@ -0,0 +1,361 @@
+// Package templates keeps the text/template greetings of GreetService, so
+// that every method renders its greetings the same way and operators can
+// change them without a new release.
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+// DefaultID is the ID of the template used when a request names none.
+const DefaultID = "default"
+
+// defaultText renders the greeting as is, numbered in GreetManyTimes.
+const defaultText = "{{.Greeting}}{{if .Number}} ({{.Number}}){{end}}"
+
+// ext is the extension of the template files in a registry directory.
+const ext = ".tmpl"
+
+const (
+	// MaxOutput is the longest greeting, in bytes, a template can render.
+	MaxOutput = 16 << 10
+	// maxSampleOutput is the longest sample greeting a new template can
+	// render; greetings for many people can be longer, up to MaxOutput.
+	maxSampleOutput = 1 << 10
+	// maxWidth is the widest width or precision printf accepts.
+	maxWidth = 64
+)
+
+var (
+	// ErrNotFound is returned for an ID with no template.
+	ErrNotFound = errors.New("template not found")
+	// ErrExists is returned when creating a template whose ID is taken.
+	ErrExists = errors.New("template already exists")
+	// ErrInvalid is returned for an ID or text that cannot be used.
+	ErrInvalid = errors.New("invalid template")
+	// ErrTooLong is returned when a greeting would be longer than
+	// MaxOutput.
+	ErrTooLong = errors.New("greeting too long")
+)
+
+// validID matches the IDs templates can have, which are also their file
+// names.
+var validID = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Data is what templates are executed with.
+type Data struct {
+	// Greeting is the greeting in the language of the caller, e.g.
+	// "Hello, Alan Kevin".
+	Greeting string
+	// Name is the full name of the person greeted, or the names of
+	// everyone greeted separated by commas.
+	Name string
+	// Names holds the full name of everyone greeted.
+	Names []string
+	// FirstName and SecondName are the names of the first person greeted.
+	FirstName  string
+	SecondName string
+	// Locale is the BCP 47 tag of the language of the greeting.
+	Locale string
+	// Number counts the greetings of GreetManyTimes from 1. It is 0 for
+	// the other methods.
+	Number int
+}
+
+// sample is rendered to check new templates before they are accepted.
+var sample = Data{
+	Greeting:   "Hello, Alan Kevin",
+	Name:       "Alan Kevin",
+	Names:      []string{"Alan Kevin"},
+	FirstName:  "Alan",
+	SecondName: "Kevin",
+	Locale:     "en",
+	Number:     1,
+}
+
+// Template is a parsed greeting template.
+type Template struct {
+	ID         string
+	Text       string
+	UpdateTime time.Time
+
+	tmpl *template.Template
+}
+
+// Render executes the template with d. Greetings longer than MaxOutput
+// fail with ErrTooLong.
+func (t *Template) Render(d Data) (string, error) {
+	return t.render(d, MaxOutput)
+}
+
+func (t *Template) render(d Data, limit int) (string, error) {
+	w := &limitedWriter{limit: limit}
+	if err := t.tmpl.Execute(w, d); err != nil {
+		if w.exceeded {
+			return "", fmt.Errorf("%w: more than %d bytes", ErrTooLong, limit)
+		}
+		return "", err
+	}
+	return w.b.String(), nil
+}
+
+// limitedWriter collects up to limit bytes, and fails the writes past them.
+type limitedWriter struct {
+	b        strings.Builder
+	limit    int
+	exceeded bool
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.b.Len()+len(p) > w.limit {
+		w.exceeded = true
+		return 0, ErrTooLong
+	}
+	return w.b.Write(p)
+}
+
+// compile parses text as the template id, and renders it once so that
+// templates using fields Data does not have, or rendering long greetings,
+// are rejected up front.
+func compile(id, text string, updated time.Time) (*Template, error) {
+	if !validID.MatchString(id) {
+		return nil, fmt.Errorf("%w: ID %q must match %s", ErrInvalid, id, validID)
+	}
+	tmpl, err := template.New(id).Funcs(template.FuncMap{"printf": printf}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if len(tmpl.Templates()) > 1 {
+		return nil, fmt.Errorf("%w: templates cannot define other templates", ErrInvalid)
+	}
+	if err := checkNode(tmpl.Tree.Root, false); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	t := &Template{ID: id, Text: text, UpdateTime: updated, tmpl: tmpl}
+	if _, err := t.render(sample, maxSampleOutput); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return t, nil
+}
+
+// checkNode rejects the actions that could keep a template running without
+// writing anything, which the output limit would not stop: calls of other
+// templates, which can recurse, ranges over anything but a field of Data,
+// such as {{range 1000000000}}, and ranges nested in another range.
+func checkNode(node parse.Node, inRange bool) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkNode(child, inRange); err != nil {
+				return err
+			}
+		}
+	case *parse.TemplateNode:
+		return errors.New("templates cannot call other templates")
+	case *parse.IfNode:
+		return checkBranch(&n.BranchNode, inRange)
+	case *parse.WithNode:
+		return checkBranch(&n.BranchNode, inRange)
+	case *parse.RangeNode:
+		if inRange {
+			return errors.New("ranges cannot be nested")
+		}
+		if !isField(n.Pipe) {
+			return fmt.Errorf("range %s: only fields such as .Names can be ranged over", n.Pipe)
+		}
+		return checkBranch(&n.BranchNode, true)
+	}
+	return nil
+}
+
+func checkBranch(b *parse.BranchNode, inRange bool) error {
+	if err := checkNode(b.List, inRange); err != nil {
+		return err
+	}
+	return checkNode(b.ElseList, inRange)
+}
+
+// isField reports whether pipe is a field, like .Names or $.Names.
+func isField(pipe *parse.PipeNode) bool {
+	if len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return false
+	}
+	switch arg := pipe.Cmds[0].Args[0].(type) {
+	case *parse.FieldNode:
+		return true
+	case *parse.VariableNode:
+		return len(arg.Ident) > 1 && arg.Ident[0] == "$"
+	}
+	return false
+}
+
+// printf replaces the printf of text/template, refusing the widths and
+// precisions, such as %999999999d, that would build huge strings before the
+// output limit sees them.
+func printf(format string, args ...interface{}) (string, error) {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		n := 0
+		for i++; i < len(format) && strings.IndexByte("+-# 0123456789.*[]", format[i]) >= 0; i++ {
+			switch c := format[i]; {
+			case c == '*':
+				return "", errors.New("printf: widths and precisions must be literal")
+			case c >= '0' && c <= '9':
+				if n = n*10 + int(c-'0'); n > maxWidth {
+					return "", fmt.Errorf("printf: widths and precisions are at most %d", maxWidth)
+				}
+			default:
+				n = 0
+			}
+		}
+	}
+	return fmt.Sprintf(format, args...), nil
+}
+
+// Registry holds the templates by ID. It always has a DefaultID template.
+// It is safe for concurrent use.
+type Registry struct {
+	// dir is where templates are persisted, one <id>.tmpl file each. When
+	// empty, templates are only kept in memory.
+	dir string
+
+	mu        sync.RWMutex
+	templates map[string]*Template
+}
+
+// NewRegistry returns a registry holding only the built-in default
+// template, kept in memory.
+func NewRegistry() *Registry {
+	t, err := compile(DefaultID, defaultText, time.Now())
+	if err != nil {
+		panic(err)
+	}
+	return &Registry{templates: map[string]*Template{DefaultID: t}}
+}
+
+// Load returns a registry with the templates of the <id>.tmpl files in dir.
+// A default.tmpl file replaces the built-in default template. Templates
+// created or updated later are written to dir.
+func Load(dir string) (*Registry, error) {
+	r := NewRegistry()
+	r.dir = dir
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+ext))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		text, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		id := strings.TrimSuffix(filepath.Base(path), ext)
+		// Editors end files with a newline that is not part of the
+		// greeting.
+		t, err := compile(id, strings.TrimSuffix(string(text), "\n"), info.ModTime())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		r.templates[id] = t
+	}
+	return r, nil
+}
+
+// Get returns the template id, or the default one when id is empty.
+func (r *Registry) Get(id string) (*Template, error) {
+	if id == "" {
+		id = DefaultID
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.templates[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+	return t, nil
+}
+
+// List returns every template, sorted by ID.
+func (r *Registry) List() []*Template {
+	r.mu.RLock()
+	list := make([]*Template, 0, len(r.templates))
+	for _, t := range r.templates {
+		list = append(list, t)
+	}
+	r.mu.RUnlock()
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// Create adds the template id, failing with ErrExists when there is one.
+func (r *Registry) Create(id, text string) (*Template, error) {
+	return r.put(id, text, false)
+}
+
+// Update replaces the text of the template id, failing with ErrNotFound
+// when there is none.
+func (r *Registry) Update(id, text string) (*Template, error) {
+	return r.put(id, text, true)
+}
+
+func (r *Registry) put(id, text string, exists bool) (*Template, error) {
+	t, err := compile(id, text, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.templates[id]; ok != exists {
+		if exists {
+			return nil, fmt.Errorf("%w: %q", ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("%w: %q", ErrExists, id)
+	}
+	if r.dir != "" {
+		if err := writeFile(filepath.Join(r.dir, id+ext), text); err != nil {
+			return nil, err
+		}
+	}
+	r.templates[id] = t
+	return t, nil
+}
+
+// writeFile replaces the file at path with text, so that a crash leaves
+// either the old or the new template.
+func writeFile(path, text string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(text); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
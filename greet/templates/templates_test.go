@@ -0,0 +1,160 @@
+package templates
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompile(t *testing.T) {
+	// doubling defines a template rendering the greeting 2^20 times with a
+	// few hundred characters.
+	var doubling strings.Builder
+	doubling.WriteString(`{{define "t0"}}{{.Greeting}}{{end}}`)
+	for i := 1; i <= 20; i++ {
+		doubling.WriteString(`{{define "t` + strconv.Itoa(i) + `"}}{{template "t` + strconv.Itoa(i-1) + `" .}}{{template "t` + strconv.Itoa(i-1) + `" .}}{{end}}`)
+	}
+	doubling.WriteString(`{{template "t20" .}}`)
+
+	tests := []struct {
+		name string
+		id   string
+		text string
+		want string // the sample greeting, or "" when the template is rejected
+	}{
+		{name: "default", id: "default", text: defaultText, want: "Hello, Alan Kevin (1)"},
+		{name: "fields", id: "shout", text: "{{.Greeting}}! ({{.Locale}})", want: "Hello, Alan Kevin! (en)"},
+		{name: "range", id: "list", text: "{{range .Names}}{{$.Greeting}}: {{.}}{{end}}", want: "Hello, Alan Kevin: Alan Kevin"},
+		{name: "printf", id: "padded", text: `{{printf "%-12s|%5.2f" .FirstName 1.5}}`, want: "Alan        | 1.50"},
+		{name: "invalid id", id: "Bad ID", text: "{{.Greeting}}"},
+		{name: "syntax error", id: "broken", text: "{{.Greeting"},
+		{name: "unknown field", id: "unknown", text: "{{.Age}}"},
+		{name: "define", id: "define", text: `{{define "x"}}{{.Greeting}}{{end}}{{.Greeting}}`},
+		{name: "nested define", id: "nested", text: doubling.String()},
+		{name: "template call", id: "call", text: `{{template "call" .}}`},
+		{name: "range over a number", id: "count", text: "{{range 1000000000}}{{end}}"},
+		{name: "nested range", id: "squared", text: "{{range .Names}}{{range $.Names}}{{end}}{{end}}"},
+		{name: "long sample", id: "long", text: strings.Repeat("{{.Greeting}}", 100)},
+		{name: "wide printf", id: "wide", text: `{{printf "%999999999d" 1}}`},
+		{name: "printf star", id: "star", text: `{{printf "%*d" 999999999 1}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := compile(tt.id, tt.text, time.Time{})
+			if tt.want == "" {
+				if !errors.Is(err, ErrInvalid) {
+					t.Fatalf("compile = %v, want ErrInvalid", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := tmpl.Render(sample)
+			if err != nil || got != tt.want {
+				t.Errorf("Render = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderLimit(t *testing.T) {
+	tmpl, err := compile("everyone", "{{range .Names}}{{$.Greeting}}{{end}}", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := Data{Greeting: strings.Repeat("x", 1024)}
+	for len(d.Names)*len(d.Greeting) < MaxOutput {
+		d.Names = append(d.Names, "")
+	}
+	if got, err := tmpl.Render(d); err != nil || len(got) != MaxOutput {
+		t.Errorf("Render of %d bytes = %d bytes, %v", MaxOutput, len(got), err)
+	}
+	d.Names = append(d.Names, "")
+	if _, err := tmpl.Render(d); !errors.Is(err, ErrTooLong) {
+		t.Errorf("Render of more than %d bytes = %v, want ErrTooLong", MaxOutput, err)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	if tmpl, err := r.Get(""); err != nil || tmpl.ID != DefaultID {
+		t.Fatalf("Get(\"\") = %v, %v, want the default template", tmpl, err)
+	}
+	if _, err := r.Get("shout"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get of a missing template = %v, want ErrNotFound", err)
+	}
+	if _, err := r.Update("shout", "{{.Greeting}}!"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update of a missing template = %v, want ErrNotFound", err)
+	}
+	if _, err := r.Create("shout", "{{.Greeting}}!"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Create("shout", "{{.Greeting}}!!"); !errors.Is(err, ErrExists) {
+		t.Errorf("Create of an existing template = %v, want ErrExists", err)
+	}
+	if _, err := r.Update("shout", "{{.Greeting"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Update with invalid text = %v, want ErrInvalid", err)
+	}
+	if _, err := r.Update("shout", "{{.Greeting}}!!"); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := r.Get("shout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := tmpl.Render(Data{Greeting: "Hi"}); got != "Hi!!" {
+		t.Errorf("Render after Update = %q, want %q", got, "Hi!!")
+	}
+	var ids []string
+	for _, tmpl := range r.List() {
+		ids = append(ids, tmpl.ID)
+	}
+	if got := strings.Join(ids, ","); got != "default,shout" {
+		t.Errorf("List = %s, want default,shout", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "default.tmpl"), []byte("{{.Greeting}}.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Create("shout", "{{.Greeting}}!"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second registry loads both the edited default and the created
+	// template.
+	r, err = Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, want := range map[string]string{"default": "Hi.", "shout": "Hi!"} {
+		tmpl, err := r.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := tmpl.Render(Data{Greeting: "Hi"}); got != want {
+			t.Errorf("Render(%s) = %q, want %q", id, got, want)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.tmpl"), []byte("{{.Greeting"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err == nil {
+		t.Error("Load of a broken template succeeded")
+	}
+	if _, err := Load(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Load of a missing directory succeeded")
+	}
+}
@@ -0,0 +1,66 @@
+// Package grpctest serves gRPC services in memory over bufconn, so that
+// tests can call them through real clients, interceptors and codecs without
+// opening ports.
+package grpctest
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// bufSize is the size of the in-memory connection buffers.
+const bufSize = 1 << 20
+
+// Options configures the server and the client connection.
+type Options struct {
+	// ServerOptions are passed to grpc.NewServer, e.g. interceptors.
+	ServerOptions []grpc.ServerOption
+	// DialOptions are added to those connecting to the server.
+	DialOptions []grpc.DialOption
+}
+
+// Serve starts a server with the services register adds, and returns a
+// client connection to it. Both are closed when the test ends.
+func Serve(t testing.TB, register func(*grpc.Server), opts Options) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(bufSize)
+	s := grpc.NewServer(opts.ServerOptions...)
+	register(s)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	dialOpts := append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, opts.DialOptions...)
+	conn, err := grpc.NewClient("passthrough:///bufnet", dialOpts...)
+	if err != nil {
+		t.Fatalf("connecting to the in-memory server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// Greet serves impl as GreetService and returns a client of it.
+func Greet(t testing.TB, impl greetpb.GreetServiceServer, opts Options) greetpb.GreetServiceClient {
+	t.Helper()
+	conn := Serve(t, func(s *grpc.Server) { greetpb.RegisterGreetServiceServer(s, impl) }, opts)
+	return greetpb.NewGreetServiceClient(conn)
+}
+
+// Calculator serves impl as calculator.v2 CalculatorService and returns a
+// client of it.
+func Calculator(t testing.TB, impl calculatorv2pb.CalculatorServiceServer, opts Options) calculatorv2pb.CalculatorServiceClient {
+	t.Helper()
+	conn := Serve(t, func(s *grpc.Server) { calculatorv2pb.RegisterCalculatorServiceServer(s, impl) }, opts)
+	return calculatorv2pb.NewCalculatorServiceClient(conn)
+}
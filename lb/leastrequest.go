@@ -0,0 +1,96 @@
+// Package lb provides the client side load balancing used to spread calls
+// over several server replicas.
+package lb
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+)
+
+// LeastRequest is the name of the least request balancing policy. Select it
+// with a service config such as {"loadBalancingConfig": [{"least_request": {}}]}.
+const LeastRequest = "least_request"
+
+func init() {
+	balancer.Register(leastRequestBuilder{})
+}
+
+// leastRequestBuilder gives every client connection its own picker
+// builder, so in flight counters are not shared between connections.
+type leastRequestBuilder struct{}
+
+func (leastRequestBuilder) Name() string {
+	return LeastRequest
+}
+
+func (leastRequestBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	b := base.NewBalancerBuilder(LeastRequest, &leastRequestPickerBuilder{}, base.Config{HealthCheck: true})
+	return b.Build(cc, opts)
+}
+
+// leastRequestPickerBuilder builds pickers sending each call to the ready
+// backend with the fewest calls in flight. Streams count as in flight until
+// they finish, so long lived FindMaximum and GreetEveryone streams are
+// spread evenly instead of piling up on the replica that happened to be
+// next in a round robin.
+type leastRequestPickerBuilder struct {
+	// inflight counts the outstanding calls of every SubConn. It outlives
+	// pickers, which are rebuilt each time a SubConn changes state.
+	inflight sync.Map // balancer.SubConn -> *int64
+}
+
+func (b *leastRequestPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+
+	// Forget the counters of SubConns that are no longer ready. Calls still
+	// running on them hold a pointer to their counter.
+	b.inflight.Range(func(sc, _ interface{}) bool {
+		if _, ok := info.ReadySCs[sc.(balancer.SubConn)]; !ok {
+			b.inflight.Delete(sc)
+		}
+		return true
+	})
+
+	p := &leastRequestPicker{}
+	for sc := range info.ReadySCs {
+		counter, _ := b.inflight.LoadOrStore(sc, new(int64))
+		p.backends = append(p.backends, backend{subConn: sc, inflight: counter.(*int64)})
+	}
+	return p
+}
+
+type backend struct {
+	subConn  balancer.SubConn
+	inflight *int64
+}
+
+type leastRequestPicker struct {
+	backends []backend
+}
+
+// Pick uses the "power of two choices": it samples two backends at random
+// and picks the one with fewer calls in flight, which avoids both scanning
+// every backend and herding all clients onto the same idle one.
+func (p *leastRequestPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	chosen := p.backends[rand.Intn(len(p.backends))]
+	if len(p.backends) > 1 {
+		other := p.backends[rand.Intn(len(p.backends))]
+		if atomic.LoadInt64(other.inflight) < atomic.LoadInt64(chosen.inflight) {
+			chosen = other
+		}
+	}
+
+	atomic.AddInt64(chosen.inflight, 1)
+	return balancer.PickResult{
+		SubConn: chosen.subConn,
+		Done: func(balancer.DoneInfo) {
+			atomic.AddInt64(chosen.inflight, -1)
+		},
+	}, nil
+}
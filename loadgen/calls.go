@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// call makes one call of an RPC, streams included, and returns its error.
+type call func(ctx context.Context) error
+
+// rpc builds the call of an RPC on conn. messages is how many messages the
+// streams of the call carry; LongGreet and ComputeAverage send at least one,
+// as the services reject empty streams.
+type rpc func(conn grpc.ClientConnInterface, messages int) call
+
+// rpcs are the RPCs loadgen drives, by method name. Calculator calls go to
+// calculator.v2.
+var rpcs = map[string]rpc{
+	"Greet":                    greet,
+	"GreetManyTimes":           greetManyTimes,
+	"LongGreet":                longGreet,
+	"GreetEveryone":            greetEveryone,
+	"ListTemplates":            listTemplates,
+	"ListGreetings":            listGreetings,
+	"Calculate":                calculate,
+	"PrimeNumberDecomposition": primeNumberDecomposition,
+	"ComputeAverage":           computeAverage,
+	"FindMaximum":              findMaximum,
+}
+
+// rpcNames returns the names of rpcs, sorted.
+func rpcNames() []string {
+	var names []string
+	for name := range rpcs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+var loadGreeting = &greetpb.Greeting{FirstName: "Load", SecondName: "Generator"}
+
+func greet(conn grpc.ClientConnInterface, _ int) call {
+	c := greetpb.NewGreetServiceClient(conn)
+	return func(ctx context.Context) error {
+		_, err := c.Greet(ctx, &greetpb.GreetRequest{Greeting: loadGreeting})
+		return err
+	}
+}
+
+func greetManyTimes(conn grpc.ClientConnInterface, messages int) call {
+	c := greetpb.NewGreetServiceClient(conn)
+	req := &greetpb.GreetManyTimesRequest{
+		Greeting: loadGreeting,
+		Count:    uint32(messages),
+		// The shortest interval the servers accept, as 0 asks for their
+		// default.
+		Interval: durationpb.New(time.Nanosecond),
+	}
+	return func(ctx context.Context) error {
+		stream, err := c.GreetManyTimes(ctx, req)
+		if err != nil {
+			return err
+		}
+		return drain(stream.Recv)
+	}
+}
+
+func longGreet(conn grpc.ClientConnInterface, messages int) call {
+	c := greetpb.NewGreetServiceClient(conn)
+	return func(ctx context.Context) error {
+		stream, err := c.LongGreet(ctx)
+		if err != nil {
+			return err
+		}
+		for range max(messages, 1) {
+			if err := stream.Send(&greetpb.LongGreetRequest{Greeting: loadGreeting}); err != nil {
+				break
+			}
+		}
+		_, err = stream.CloseAndRecv()
+		return err
+	}
+}
+
+func greetEveryone(conn grpc.ClientConnInterface, messages int) call {
+	c := greetpb.NewGreetServiceClient(conn)
+	return func(ctx context.Context) error {
+		stream, err := c.GreetEveryone(ctx)
+		if err != nil {
+			return err
+		}
+		for range messages {
+			if err := stream.Send(&greetpb.GreetEveryoneRequest{Greeting: loadGreeting}); err != nil {
+				break
+			}
+			if _, err := stream.Recv(); err != nil {
+				return err
+			}
+		}
+		stream.CloseSend()
+		return drain(stream.Recv)
+	}
+}
+
+func listTemplates(conn grpc.ClientConnInterface, _ int) call {
+	c := greetpb.NewGreetServiceClient(conn)
+	return func(ctx context.Context) error {
+		_, err := c.ListTemplates(ctx, &greetpb.ListTemplatesRequest{})
+		return err
+	}
+}
+
+func listGreetings(conn grpc.ClientConnInterface, _ int) call {
+	c := greetpb.NewGreetServiceClient(conn)
+	return func(ctx context.Context) error {
+		_, err := c.ListGreetings(ctx, &greetpb.ListGreetingsRequest{PageSize: 10})
+		return err
+	}
+}
+
+func calculate(conn grpc.ClientConnInterface, _ int) call {
+	c := calculatorv2pb.NewCalculatorServiceClient(conn)
+	return func(ctx context.Context) error {
+		_, err := c.Calculate(ctx, &calculatorv2pb.OperationRequest{OperationArgs: &calculatorv2pb.OperationArgs{
+			Value1:    rand.Float64(),
+			Value2:    rand.Float64(),
+			Operation: calculatorv2pb.Operation(rand.IntN(4)),
+		}})
+		return err
+	}
+}
+
+func primeNumberDecomposition(conn grpc.ClientConnInterface, _ int) call {
+	c := calculatorv2pb.NewCalculatorServiceClient(conn)
+	return func(ctx context.Context) error {
+		stream, err := c.PrimeNumberDecomposition(ctx, &calculatorv2pb.PrimeNumberDecompositionRequest{
+			Number: 2 + rand.Uint64N(1<<20),
+		})
+		if err != nil {
+			return err
+		}
+		return drain(stream.Recv)
+	}
+}
+
+func computeAverage(conn grpc.ClientConnInterface, messages int) call {
+	c := calculatorv2pb.NewCalculatorServiceClient(conn)
+	return func(ctx context.Context) error {
+		stream, err := c.ComputeAverage(ctx)
+		if err != nil {
+			return err
+		}
+		for range max(messages, 1) {
+			if err := stream.Send(&calculatorv2pb.ComputeAverageRequest{Number: rand.Float64()}); err != nil {
+				break
+			}
+		}
+		_, err = stream.CloseAndRecv()
+		return err
+	}
+}
+
+func findMaximum(conn grpc.ClientConnInterface, messages int) call {
+	c := calculatorv2pb.NewCalculatorServiceClient(conn)
+	return func(ctx context.Context) error {
+		stream, err := c.FindMaximum(ctx)
+		if err != nil {
+			return err
+		}
+		// Only new maxima are answered, so the numbers sent increase.
+		for i := range messages {
+			if err := stream.Send(&calculatorv2pb.FindMaximumRequest{Number: float64(i)}); err != nil {
+				break
+			}
+			if _, err := stream.Recv(); err != nil {
+				return err
+			}
+		}
+		stream.CloseSend()
+		return drain(stream.Recv)
+	}
+}
+
+// drain receives from a stream until it ends, returning nil when it ends
+// successfully.
+func drain[Res any](recv func() (*Res, error)) error {
+	for {
+		_, err := recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// weight is the share of the calls of an RPC in the message mix.
+type weight struct {
+	Name   string
+	Weight int
+}
+
+// parseMix parses a message mix such as "Greet=3,FindMaximum=1". An RPC
+// without a weight has weight 1.
+func parseMix(s string) ([]weight, error) {
+	var mix []weight
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		w := weight{Name: field, Weight: 1}
+		if name, value, ok := strings.Cut(field, "="); ok {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("weight of %s: %q is not a positive integer", name, value)
+			}
+			w.Name, w.Weight = name, n
+		}
+		if _, ok := rpcs[w.Name]; !ok {
+			return nil, fmt.Errorf("unknown RPC %q, expected one of %v", w.Name, rpcNames())
+		}
+		if slices.ContainsFunc(mix, func(other weight) bool { return other.Name == w.Name }) {
+			return nil, fmt.Errorf("RPC %s given twice", w.Name)
+		}
+		mix = append(mix, w)
+	}
+	if len(mix) == 0 {
+		return nil, fmt.Errorf("no RPC in the mix")
+	}
+	return mix, nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/calculator/calcserver"
+	"github.com/AlanKev117/go-grpc/greet/greeterserver"
+	"github.com/AlanKev117/go-grpc/grpctest"
+	"github.com/AlanKev117/go-grpc/validate"
+	"google.golang.org/grpc"
+)
+
+func TestParseMix(t *testing.T) {
+	tests := []struct {
+		mix     string
+		want    []weight
+		wantErr bool
+	}{
+		{"Greet", []weight{{"Greet", 1}}, false},
+		{"Greet=3, FindMaximum=1,", []weight{{"Greet", 3}, {"FindMaximum", 1}}, false},
+		{"", nil, true},
+		{"Greet=0", nil, true},
+		{"Greet=x", nil, true},
+		{"Greet,Greet=2", nil, true},
+		{"Divide", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseMix(tt.mix)
+		if (err != nil) != tt.wantErr || !tt.wantErr && !equalMix(got, tt.want) {
+			t.Errorf("parseMix(%q) = %v, %v, want %v", tt.mix, got, err, tt.want)
+		}
+	}
+}
+
+func equalMix(a, b []weight) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPercentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 1000; i++ {
+		latencies = append(latencies, time.Duration(i))
+	}
+	l := newLatency(latencies)
+	want := latency{Min: 1, Mean: 500, P50: 500, P90: 900, P95: 950, P99: 990, P999: 999, Max: 1000}
+	if l != want {
+		t.Errorf("newLatency = %+v, want %+v", l, want)
+	}
+	if l := newLatency([]time.Duration{7}); l.P50 != 7 || l.P999 != 7 {
+		t.Errorf("newLatency of one call = %+v", l)
+	}
+}
+
+func TestRun(t *testing.T) {
+	conn := grpctest.Serve(t, func(s *grpc.Server) {
+		greeterserver.New(greeterserver.Options{}).Register(s)
+		calcserver.New(calcserver.Options{}).Register(s)
+	}, grpctest.Options{ServerOptions: validate.ServerOptions()})
+
+	var mix []weight
+	for _, name := range rpcNames() {
+		mix = append(mix, weight{name, 1})
+	}
+	r := run(context.Background(), conn, options{
+		Concurrency: 4,
+		Duration:    10 * time.Second,
+		Requests:    200,
+		Timeout:     5 * time.Second,
+		Mix:         mix,
+		Messages:    3,
+	})
+	if r.Total.Calls != 200 || len(r.Total.Errors) != 0 {
+		t.Errorf("run made %d calls with errors %v, want 200 successful calls", r.Total.Calls, r.Total.Errors)
+	}
+	sum := 0
+	for _, m := range r.Methods {
+		sum += m.Calls
+	}
+	if sum != r.Total.Calls || r.Total.Latency.Max == 0 {
+		t.Errorf("methods made %d calls, total %+v", sum, r.Total)
+	}
+
+	var text bytes.Buffer
+	if err := r.writeText(&text); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(text.String(), "200 calls in") || !strings.Contains(text.String(), "FindMaximum") {
+		t.Errorf("text report:\n%s", &text)
+	}
+	var out bytes.Buffer
+	if err := r.writeJSON(&out); err != nil {
+		t.Fatal(err)
+	}
+	var decoded report
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil || decoded.Total.Calls != 200 || len(decoded.Methods) != len(mix) {
+		t.Errorf("JSON report = %s, %v", &out, err)
+	}
+}
+
+func TestRunRate(t *testing.T) {
+	conn := grpctest.Serve(t, calcserver.New(calcserver.Options{}).Register, grpctest.Options{})
+	r := run(context.Background(), conn, options{
+		Concurrency: 4,
+		Rate:        50,
+		Duration:    200 * time.Millisecond,
+		Mix:         []weight{{"Calculate", 1}, {"Greet", 1}},
+	})
+	// About 10 calls fit in 200ms at 50 calls/s.
+	if r.Total.Calls < 5 || r.Total.Calls > 15 {
+		t.Errorf("run made %d calls in 200ms at 50 calls/s", r.Total.Calls)
+	}
+	// Greet is not served, which the report breaks down.
+	if greet := r.Methods[1]; greet.failed() != greet.Calls || greet.Errors["Unimplemented"] != greet.Calls {
+		t.Errorf("Greet errors = %v of %d calls, want all Unimplemented", greet.Errors, greet.Calls)
+	}
+	if len(r.Methods[0].Errors) != 0 {
+		t.Errorf("Calculate errors = %v", r.Methods[0].Errors)
+	}
+}
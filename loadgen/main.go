@@ -0,0 +1,81 @@
+// Command loadgen drives the RPCs of GreetService and calculator.v2
+// CalculatorService with a mix of calls, at a given concurrency and rate,
+// and reports the latency percentiles and errors of every RPC as text or
+// JSON.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/auth"
+	"github.com/AlanKev117/go-grpc/config"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	clientConfig := config.DefaultClient()
+	clientConfig.RegisterFlags(flag.CommandLine)
+	var authFlags auth.ClientFlags
+	authFlags.RegisterFlags(flag.CommandLine)
+	var cfg options
+	flag.IntVar(&cfg.Concurrency, "concurrency", 10, "how many calls are in flight at most")
+	flag.Float64Var(&cfg.Rate, "rate", 0, "calls started per second over all workers; as fast as possible when 0")
+	flag.DurationVar(&cfg.Duration, "duration", 10*time.Second, "how long calls are started for; until -requests or interrupted when 0")
+	flag.IntVar(&cfg.Requests, "requests", 0, "stop after starting this many calls; no limit when 0")
+	flag.DurationVar(&cfg.Timeout, "timeout", 10*time.Second, "deadline of every call; none when 0")
+	flag.IntVar(&cfg.Messages, "messages", 10, "messages carried by the streams of each call")
+	mix := flag.String("mix", "Greet", "comma separated RPCs to call, each with an optional weight, e.g. Greet=3,FindMaximum=1; one of "+strings.Join(rpcNames(), ", "))
+	format := flag.String("format", "text", "format of the report: text or json")
+	flag.Parse()
+
+	var err error
+	if cfg.Mix, err = parseMix(*mix); err != nil {
+		log.Fatalf("Invalid mix: %v", err)
+	}
+	if cfg.Concurrency < 1 {
+		log.Fatalf("Invalid concurrency %d: must be positive", cfg.Concurrency)
+	}
+	if cfg.Rate < 0 || cfg.Requests < 0 || cfg.Messages < 0 {
+		log.Fatalf("-rate, -requests and -messages must not be negative")
+	}
+	if *format != "text" && *format != "json" {
+		log.Fatalf("Unknown report format %q", *format)
+	}
+	if err := clientConfig.Validate(); err != nil {
+		log.Fatalf("Invalid client settings: %v", err)
+	}
+
+	authOpts, err := authFlags.DialOptions()
+	if err != nil {
+		log.Fatalf("Couldn't read token: %v", err)
+	}
+	opts := append([]grpc.DialOption{grpc.WithInsecure()}, authOpts...)
+	conn, err := clientConfig.Dial(opts...)
+	if err != nil {
+		log.Fatalf("Couldn't connect: %v", err)
+	}
+	defer conn.Close()
+
+	// Interrupting loadgen ends the test early, still reporting the calls
+	// made so far.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Fprintf(os.Stderr, "Calling %s on %s with %d workers...\n", *mix, clientConfig.Target, cfg.Concurrency)
+	r := run(ctx, conn, cfg)
+
+	if *format == "json" {
+		err = r.writeJSON(os.Stdout)
+	} else {
+		err = r.writeText(os.Stdout)
+	}
+	if err != nil {
+		log.Fatalf("Couldn't write the report: %v", err)
+	}
+}
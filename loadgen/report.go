@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// report summarizes a load test, for every RPC and in total.
+type report struct {
+	// Elapsed is how long the test took, in nanoseconds in JSON.
+	Elapsed time.Duration `json:"elapsed_ns"`
+	// Rate is how many calls completed per second.
+	Rate    float64         `json:"calls_per_second"`
+	Total   methodReport    `json:"total"`
+	Methods []*methodReport `json:"methods"`
+}
+
+// methodReport summarizes the calls of an RPC.
+type methodReport struct {
+	Method string `json:"method"`
+	Calls  int    `json:"calls"`
+	// Errors counts the failed calls by status code.
+	Errors  map[string]int `json:"errors,omitempty"`
+	Latency latency        `json:"latency"`
+}
+
+// failed returns how many calls failed.
+func (m *methodReport) failed() int {
+	n := 0
+	for _, count := range m.Errors {
+		n += count
+	}
+	return n
+}
+
+// latency holds the distribution of the latencies of calls, in
+// nanoseconds in JSON.
+type latency struct {
+	Min  time.Duration `json:"min_ns"`
+	Mean time.Duration `json:"mean_ns"`
+	P50  time.Duration `json:"p50_ns"`
+	P90  time.Duration `json:"p90_ns"`
+	P95  time.Duration `json:"p95_ns"`
+	P99  time.Duration `json:"p99_ns"`
+	P999 time.Duration `json:"p999_ns"`
+	Max  time.Duration `json:"max_ns"`
+}
+
+// newLatency returns the distribution of latencies, which it sorts.
+func newLatency(latencies []time.Duration) latency {
+	if len(latencies) == 0 {
+		return latency{}
+	}
+	slices.Sort(latencies)
+	var sum time.Duration
+	for _, l := range latencies {
+		sum += l
+	}
+	return latency{
+		Min:  latencies[0],
+		Mean: sum / time.Duration(len(latencies)),
+		P50:  percentile(latencies, 50),
+		P90:  percentile(latencies, 90),
+		P95:  percentile(latencies, 95),
+		P99:  percentile(latencies, 99),
+		P999: percentile(latencies, 99.9),
+		Max:  latencies[len(latencies)-1],
+	}
+}
+
+// percentile returns the p-th percentile of the sorted latencies, by the
+// nearest rank method: the smallest latency that p percent of them do not
+// exceed.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	// The epsilon keeps ranks like 99.9% of 1000 from rounding up past 999.
+	rank := int(math.Ceil(p/100*float64(len(sorted)) - 1e-9))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
+
+// newReport summarizes the results of the RPCs of mix, in the same order.
+func newReport(mix []weight, results []result, elapsed time.Duration) *report {
+	r := &report{Elapsed: elapsed, Total: methodReport{Method: "total"}}
+	var all result
+	for i, w := range mix {
+		m := &methodReport{
+			Method:  w.Name,
+			Calls:   len(results[i].latencies),
+			Errors:  results[i].errors,
+			Latency: newLatency(results[i].latencies),
+		}
+		r.Methods = append(r.Methods, m)
+		all.merge(&results[i])
+	}
+	r.Total.Calls = len(all.latencies)
+	r.Total.Errors = all.errors
+	r.Total.Latency = newLatency(all.latencies)
+	if elapsed > 0 {
+		r.Rate = float64(r.Total.Calls) / elapsed.Seconds()
+	}
+	return r
+}
+
+// writeJSON writes r as indented JSON.
+func (r *report) writeJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// writeText writes r as a table of the latencies of every RPC, followed by
+// the errors of those that failed.
+func (r *report) writeText(w io.Writer) error {
+	fmt.Fprintf(w, "%d calls in %v, %.1f calls/s, %d failed\n\n",
+		r.Total.Calls, r.Elapsed.Round(time.Millisecond), r.Rate, r.Total.failed())
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "method\tcalls\terrors\tmin\tmean\tp50\tp90\tp95\tp99\tp99.9\tmax\t")
+	rows := append(slices.Clone(r.Methods), &r.Total)
+	for _, m := range rows {
+		l := m.Latency
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n",
+			m.Method, m.Calls, m.failed(),
+			round(l.Min), round(l.Mean), round(l.P50), round(l.P90), round(l.P95), round(l.P99), round(l.P999), round(l.Max))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(r.Total.Errors) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w, "\nerrors:")
+	for _, m := range r.Methods {
+		for _, code := range slices.Sorted(maps.Keys(m.Errors)) {
+			fmt.Fprintf(w, "  %s: %d %s\n", m.Method, m.Errors[code], code)
+		}
+	}
+	return nil
+}
+
+// round rounds latencies for display, keeping three significant digits
+// or so.
+func round(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	}
+	return d.Round(time.Microsecond)
+}
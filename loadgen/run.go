@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// options configure a load test.
+type options struct {
+	// Concurrency is how many calls are in flight at most.
+	Concurrency int
+	// Rate caps the calls started per second, over all workers; no cap
+	// when 0.
+	Rate float64
+	// Duration is how long calls are started for. Calls in flight when it
+	// ends are waited for.
+	Duration time.Duration
+	// Requests stops the test after starting that many calls; no limit
+	// when 0.
+	Requests int
+	// Timeout bounds every call; no bound when 0.
+	Timeout time.Duration
+	// Mix is the share of the calls of each RPC.
+	Mix []weight
+	// Messages is how many messages the streams of each call carry.
+	Messages int
+}
+
+// result is the outcome of the calls of an RPC.
+type result struct {
+	latencies []time.Duration
+	// errors counts the failed calls by status code.
+	errors map[string]int
+}
+
+func (r *result) add(latency time.Duration, err error) {
+	r.latencies = append(r.latencies, latency)
+	if err != nil {
+		if r.errors == nil {
+			r.errors = make(map[string]int)
+		}
+		r.errors[status.Code(err).String()]++
+	}
+}
+
+func (r *result) merge(other *result) {
+	r.latencies = append(r.latencies, other.latencies...)
+	for code, n := range other.errors {
+		if r.errors == nil {
+			r.errors = make(map[string]int)
+		}
+		r.errors[code] += n
+	}
+}
+
+// run drives the RPCs of cfg.Mix on conn until cfg.Duration passes,
+// cfg.Requests calls were started or ctx ends, and reports the calls. The
+// calls in flight then are waited for, not cancelled, so that they are
+// not reported as failed.
+func run(ctx context.Context, conn grpc.ClientConnInterface, cfg options) *report {
+	calls := make([]call, len(cfg.Mix))
+	total := 0
+	for i, w := range cfg.Mix {
+		calls[i] = rpcs[w.Name](conn, cfg.Messages)
+		total += w.Weight
+	}
+	// pick returns the index in cfg.Mix of the RPC to call next.
+	pick := func() int {
+		n := rand.IntN(total)
+		for i, w := range cfg.Mix {
+			if n < w.Weight {
+				return i
+			}
+			n -= w.Weight
+		}
+		panic("unreachable")
+	}
+
+	limit := rate.Inf
+	if cfg.Rate > 0 {
+		limit = rate.Limit(cfg.Rate)
+	}
+	limiter := rate.NewLimiter(limit, 1)
+
+	// Calls stop starting when starting ends.
+	starting := ctx
+	if cfg.Duration > 0 {
+		var cancel context.CancelFunc
+		starting, cancel = context.WithTimeout(ctx, cfg.Duration)
+		defer cancel()
+	}
+	ctx = context.WithoutCancel(ctx)
+
+	var started atomic.Int64
+	results := make([][]result, max(cfg.Concurrency, 1))
+	begin := time.Now()
+	var wg sync.WaitGroup
+	for w := range results {
+		// Every worker records its own results, merged once all are done.
+		results[w] = make([]result, len(calls))
+		wg.Go(func() {
+			for {
+				if limiter.Wait(starting) != nil {
+					return
+				}
+				if cfg.Requests > 0 && started.Add(1) > int64(cfg.Requests) {
+					return
+				}
+				i := pick()
+				callCtx, cancel := ctx, context.CancelFunc(func() {})
+				if cfg.Timeout > 0 {
+					callCtx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+				}
+				start := time.Now()
+				err := calls[i](callCtx)
+				results[w][i].add(time.Since(start), err)
+				cancel()
+			}
+		})
+	}
+	wg.Wait()
+	elapsed := time.Since(begin)
+
+	merged := make([]result, len(calls))
+	for _, worker := range results {
+		for i := range worker {
+			merged[i].merge(&worker[i])
+		}
+	}
+	return newReport(cfg.Mix, merged, elapsed)
+}
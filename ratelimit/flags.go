@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+// Flags holds the command line flags configuring a Limiter.
+type Flags struct {
+	Config Config
+}
+
+// RegisterFlags defines the rate limiting flags on fs.
+func (f *Flags) RegisterFlags(fs *flag.FlagSet) {
+	fs.Float64Var(&f.Config.Call.Rate, "rate", 0, "calls per second allowed per client and method (0 disables the limit)")
+	fs.IntVar(&f.Config.Call.Burst, "burst", 10, "calls a client can make in a burst per method")
+	fs.Var((*methodLimits)(&f.Config.Methods), "method-rate", "per method limit as METHOD=RATE[:BURST], e.g. /calculator.v2.CalculatorService/PrimeNumberDecomposition=2:5 (repeatable)")
+	fs.IntVar(&f.Config.MaxStreams, "max-streams", 0, "concurrent streams allowed per client (0 disables the cap)")
+	fs.Float64Var(&f.Config.StreamMessages.Rate, "stream-message-rate", 0, "messages per second a client can send on each client stream (0 disables the limit)")
+	fs.IntVar(&f.Config.StreamMessages.Burst, "stream-message-burst", 20, "messages a client can send in a burst on each client stream")
+}
+
+// ServerOptions returns the interceptors enforcing the configured limits.
+// They must be installed after the authentication interceptors.
+func (f *Flags) ServerOptions() []grpc.ServerOption {
+	l := New(f.Config)
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(l.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(l.StreamServerInterceptor()),
+	}
+}
+
+// methodLimits implements flag.Value for repeated -method-rate flags.
+type methodLimits map[string]Limit
+
+func (m *methodLimits) String() string {
+	if m == nil {
+		return ""
+	}
+	var entries []string
+	for method, l := range *m {
+		entries = append(entries, fmt.Sprintf("%s=%g:%d", method, l.Rate, l.Burst))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
+
+func (m *methodLimits) Set(value string) error {
+	method, limit, found := strings.Cut(value, "=")
+	if !found || !strings.HasPrefix(method, "/") {
+		return fmt.Errorf("expected METHOD=RATE[:BURST], got %q", value)
+	}
+	rateValue, burstValue, hasBurst := strings.Cut(limit, ":")
+
+	var l Limit
+	var err error
+	if l.Rate, err = strconv.ParseFloat(rateValue, 64); err != nil {
+		return fmt.Errorf("bad rate in %q: %v", value, err)
+	}
+	l.Burst = 1
+	if hasBurst {
+		if l.Burst, err = strconv.Atoi(burstValue); err != nil {
+			return fmt.Errorf("bad burst in %q: %v", value, err)
+		}
+	}
+
+	if *m == nil {
+		*m = make(map[string]Limit)
+	}
+	(*m)[method] = l
+	return nil
+}
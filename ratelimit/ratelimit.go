@@ -0,0 +1,288 @@
+// Package ratelimit protects the gRPC servers from clients sending too many
+// calls or stream messages. Limits are tracked per client, identified by its
+// authenticated principal or, failing that, by its peer address.
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/alias"
+	"github.com/AlanKev117/go-grpc/auth"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RetryAfterKey is the trailer carrying the number of milliseconds a client
+// should wait before retrying a call rejected by the limiter.
+const RetryAfterKey = "retry-after-ms"
+
+// ForwardedForKey is the metadata key carrying the address of a client
+// whose call was bridged in process, for instance from gRPC-Web. It is only
+// trusted on in-process connections.
+const ForwardedForKey = "x-forwarded-for"
+
+// healthPrefix is the prefix of the health service methods, which are not
+// limited: balancing clients keep a Watch stream open on every server.
+const healthPrefix = "/grpc.health.v1.Health/"
+
+// streamRetryDelay is the wait suggested to a client at its stream cap,
+// since when one of its streams will end is unknown.
+const streamRetryDelay = time.Second
+
+// idleTimeout is how long the buckets of a client that stopped calling are
+// kept around.
+const idleTimeout = 10 * time.Minute
+
+// Limit is a token bucket configuration: Rate tokens are added every second
+// up to Burst. A zero Rate disables the limit.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Config holds the limits enforced by a Limiter.
+type Config struct {
+	// Call limits the calls a client can start per method.
+	Call Limit
+	// Methods overrides Call for the given full method names. A limit
+	// applies to every alias of its method, and the calls under all of them
+	// share one bucket.
+	Methods map[string]Limit
+	// MaxStreams caps the streams a client can have open at once.
+	// Zero means no cap.
+	MaxStreams int
+	// StreamMessages limits the messages a client can send per second on
+	// each of its client streaming calls, such as ComputeAverage or
+	// FindMaximum.
+	StreamMessages Limit
+}
+
+type bucketKey struct {
+	client string
+	method string
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Limiter enforces a Config through gRPC server interceptors.
+type Limiter struct {
+	config Config
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	streams   map[string]int
+	lastSweep time.Time
+}
+
+// New returns a Limiter enforcing config.
+func New(config Config) *Limiter {
+	methods := make(map[string]Limit, len(config.Methods))
+	for method, limit := range config.Methods {
+		methods[alias.Method(method)] = limit
+	}
+	config.Methods = methods
+	return &Limiter{
+		config:    config,
+		buckets:   make(map[bucketKey]*bucket),
+		streams:   make(map[string]int),
+		lastSweep: time.Now(),
+	}
+}
+
+// UnaryServerInterceptor rate limits unary calls. It must run after the
+// authentication interceptor so callers are keyed by principal.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, healthPrefix) {
+			return handler(ctx, req)
+		}
+		if delay, ok := l.allowCall(clientKey(ctx), info.FullMethod); !ok {
+			grpc.SetTrailer(ctx, retryAfter(delay))
+			return nil, exhausted(delay, "rate limit exceeded for %s", info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rate limits the creation of streams, caps the
+// number of concurrent streams per client and limits the messages received
+// on client streams.
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, healthPrefix) {
+			return handler(srv, ss)
+		}
+		client := clientKey(ss.Context())
+		if delay, ok := l.allowCall(client, info.FullMethod); !ok {
+			ss.SetTrailer(retryAfter(delay))
+			return exhausted(delay, "rate limit exceeded for %s", info.FullMethod)
+		}
+
+		if !l.openStream(client) {
+			ss.SetTrailer(retryAfter(streamRetryDelay))
+			return exhausted(streamRetryDelay, "too many concurrent streams, at most %d are allowed", l.config.MaxStreams)
+		}
+		defer l.closeStream(client)
+
+		if info.IsClientStream && l.config.StreamMessages.Rate > 0 {
+			ss = &limitedStream{
+				ServerStream: ss,
+				limiter:      rate.NewLimiter(rate.Limit(l.config.StreamMessages.Rate), burst(l.config.StreamMessages)),
+				method:       info.FullMethod,
+			}
+		}
+		return handler(srv, ss)
+	}
+}
+
+// allowCall takes a token from the bucket of the client and method. When
+// none is left, it returns how long the client should wait.
+func (l *Limiter) allowCall(client, method string) (time.Duration, bool) {
+	method = alias.Method(method)
+	limit, ok := l.config.Methods[method]
+	if !ok {
+		limit = l.config.Call
+	}
+	if limit.Rate <= 0 {
+		return 0, true
+	}
+
+	now := time.Now()
+	l.mu.Lock()
+	l.sweep(now)
+	key := bucketKey{client: client, method: method}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.Rate), burst(limit))}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	l.mu.Unlock()
+
+	return take(b.limiter, now)
+}
+
+// sweep forgets the buckets of idle clients. It must be called with l.mu held.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleTimeout {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > idleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+func (l *Limiter) openStream(client string) bool {
+	if l.config.MaxStreams <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.streams[client] >= l.config.MaxStreams {
+		return false
+	}
+	l.streams[client]++
+	return true
+}
+
+func (l *Limiter) closeStream(client string) {
+	if l.config.MaxStreams <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.streams[client]--; l.streams[client] <= 0 {
+		delete(l.streams, client)
+	}
+}
+
+// limitedStream rejects messages received faster than its limiter allows.
+type limitedStream struct {
+	grpc.ServerStream
+	limiter *rate.Limiter
+	method  string
+}
+
+func (s *limitedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if delay, ok := take(s.limiter, time.Now()); !ok {
+		s.SetTrailer(retryAfter(delay))
+		return exhausted(delay, "too many messages on %s stream", s.method)
+	}
+	return nil
+}
+
+// take consumes a token from limiter, returning the wait until the next token
+// when none is available.
+func take(limiter *rate.Limiter, now time.Time) (time.Duration, bool) {
+	r := limiter.ReserveN(now, 1)
+	if !r.OK() {
+		return time.Second, false
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return delay, false
+	}
+	return 0, true
+}
+
+func burst(l Limit) int {
+	if l.Burst < 1 {
+		return 1
+	}
+	return l.Burst
+}
+
+// clientKey identifies the caller by principal when it authenticated, or by
+// the IP address it connected from.
+func clientKey(ctx context.Context) string {
+	if p, ok := auth.FromContext(ctx); ok {
+		return "principal:" + p.Name
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if p.Addr.Network() == "bufconn" {
+			if forwarded := metadata.ValueFromIncomingContext(ctx, ForwardedForKey); len(forwarded) > 0 {
+				return "peer:" + forwarded[0]
+			}
+		}
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "peer:" + host
+	}
+	return "unknown"
+}
+
+func retryAfter(delay time.Duration) metadata.MD {
+	ms := (delay + time.Millisecond - 1) / time.Millisecond
+	return metadata.Pairs(RetryAfterKey, strconv.FormatInt(int64(ms), 10))
+}
+
+// exhausted builds a ResourceExhausted status carrying a RetryInfo detail.
+func exhausted(delay time.Duration, format string, args ...interface{}) error {
+	st := status.Newf(codes.ResourceExhausted, format, args...)
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
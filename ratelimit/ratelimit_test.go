@@ -0,0 +1,214 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/alias"
+	"github.com/AlanKev117/go-grpc/auth"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// addr is a net.Addr of any network.
+type addr struct{ network, address string }
+
+func (a addr) Network() string { return a.network }
+func (a addr) String() string  { return a.address }
+
+// fromPeer returns a context of a call from address over network.
+func fromPeer(network, address string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: addr{network, address}})
+}
+
+func TestClientKey(t *testing.T) {
+	forwarded := metadata.NewIncomingContext(fromPeer("bufconn", "bufconn"), metadata.Pairs(ForwardedForKey, "203.0.113.7"))
+	spoofed := metadata.NewIncomingContext(fromPeer("tcp", "198.51.100.1:4000"), metadata.Pairs(ForwardedForKey, "203.0.113.7"))
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"principal", auth.NewContext(fromPeer("tcp", "198.51.100.1:4000"), &auth.Principal{Name: "alice"}), "principal:alice"},
+		{"TCP peer", fromPeer("tcp", "198.51.100.1:4000"), "peer:198.51.100.1"},
+		{"other port", fromPeer("tcp", "198.51.100.1:4001"), "peer:198.51.100.1"},
+		{"IPv6 peer", fromPeer("tcp", "[2001:db8::1]:4000"), "peer:2001:db8::1"},
+		{"bridged call", forwarded, "peer:203.0.113.7"},
+		{"forwarded over TCP", spoofed, "peer:198.51.100.1"},
+		{"no peer", context.Background(), "unknown"},
+	}
+	for _, tt := range tests {
+		if got := clientKey(tt.ctx); got != tt.want {
+			t.Errorf("%s: clientKey = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAllowCall(t *testing.T) {
+	alias.Register("ratelimit.OldService", "ratelimit.v1.Service")
+	l := New(Config{
+		Call: Limit{Rate: 0.001, Burst: 2},
+		Methods: map[string]Limit{
+			"/ratelimit.OldService/Slow": {Rate: 0.001, Burst: 1},
+			"/ratelimit.v1.Service/Free": {},
+		},
+	})
+	allow := func(client, method string) bool {
+		_, ok := l.allowCall(client, method)
+		return ok
+	}
+
+	// Each client and method has its own bucket of Call.
+	for i := 0; i < 2; i++ {
+		if !allow("a", "/ratelimit.v1.Service/Get") {
+			t.Fatalf("call %d within the burst was rejected", i+1)
+		}
+	}
+	if delay, ok := l.allowCall("a", "/ratelimit.v1.Service/Get"); ok || delay <= 0 {
+		t.Errorf("call over the burst = %v, %v, want a delay", delay, ok)
+	}
+	if !allow("b", "/ratelimit.v1.Service/Get") || !allow("a", "/ratelimit.v1.Service/Put") {
+		t.Error("the bucket of another client or method was used")
+	}
+
+	// Method limits apply to every name of the method, sharing a bucket.
+	if !allow("a", "/ratelimit.v1.Service/Slow") {
+		t.Error("first call of a method limit was rejected")
+	}
+	if allow("a", "/ratelimit.OldService/Slow") {
+		t.Error("the alias of a method has its own bucket")
+	}
+
+	// A zero method limit disables the limit.
+	for i := 0; i < 10; i++ {
+		if !allow("a", "/ratelimit.OldService/Free") {
+			t.Fatal("call of an unlimited method was rejected")
+		}
+	}
+}
+
+// testStream is a grpc.ServerStream receiving empty messages.
+type testStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	trailer metadata.MD
+}
+
+func (s *testStream) Context() context.Context    { return s.ctx }
+func (s *testStream) SetTrailer(md metadata.MD)   { s.trailer = metadata.Join(s.trailer, md) }
+func (s *testStream) RecvMsg(m interface{}) error { return nil }
+
+// checkExhausted fails unless err is ResourceExhausted with a RetryInfo
+// detail and the stream trailer suggests when to retry.
+func checkExhausted(t *testing.T, err error, ss *testStream) {
+	t.Helper()
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("error = %v, want ResourceExhausted", err)
+	}
+	if len(st.Details()) != 1 {
+		t.Errorf("details = %v, want a RetryInfo", st.Details())
+	} else if info, ok := st.Details()[0].(*errdetails.RetryInfo); !ok || info.GetRetryDelay().AsDuration() <= 0 {
+		t.Errorf("details = %v, want a RetryInfo with a delay", st.Details())
+	}
+	if len(ss.trailer.Get(RetryAfterKey)) != 1 {
+		t.Errorf("trailer = %v, want %s", ss.trailer, RetryAfterKey)
+	}
+}
+
+func TestMaxStreams(t *testing.T) {
+	intercept := New(Config{MaxStreams: 2}).StreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/ratelimit.v1.Service/Watch", IsServerStream: true}
+	ctx := auth.NewContext(context.Background(), &auth.Principal{Name: "alice"})
+
+	// Two streams stay open until release is closed.
+	release := make(chan struct{})
+	started := make(chan struct{})
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			done <- intercept(nil, &testStream{ctx: ctx}, info, func(srv interface{}, ss grpc.ServerStream) error {
+				started <- struct{}{}
+				<-release
+				return nil
+			})
+		}()
+		<-started
+	}
+
+	handler := func(srv interface{}, ss grpc.ServerStream) error { return nil }
+	ss := &testStream{ctx: ctx}
+	checkExhausted(t, intercept(nil, ss, info, handler), ss)
+	// Other clients and the health service are not capped.
+	if err := intercept(nil, &testStream{ctx: context.Background()}, info, handler); err != nil {
+		t.Errorf("stream of another client = %v", err)
+	}
+	health := &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch", IsServerStream: true}
+	if err := intercept(nil, &testStream{ctx: ctx}, health, handler); err != nil {
+		t.Errorf("health stream = %v", err)
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Closed streams free their slot.
+	if err := intercept(nil, &testStream{ctx: ctx}, info, handler); err != nil {
+		t.Errorf("stream after the others closed = %v", err)
+	}
+}
+
+func TestStreamMessages(t *testing.T) {
+	intercept := New(Config{StreamMessages: Limit{Rate: 0.001, Burst: 3}}).StreamServerInterceptor()
+	recv := func(ss grpc.ServerStream) (int, error) {
+		for n := 0; ; n++ {
+			if err := ss.RecvMsg(nil); err != nil {
+				return n, err
+			}
+			if n == 10 {
+				return n, nil
+			}
+		}
+	}
+
+	client := &grpc.StreamServerInfo{FullMethod: "/ratelimit.v1.Service/Upload", IsClientStream: true}
+	for i := 0; i < 2; i++ {
+		// Every stream has its own limit.
+		ss := &testStream{ctx: context.Background()}
+		err := intercept(nil, ss, client, func(srv interface{}, stream grpc.ServerStream) error {
+			n, err := recv(stream)
+			if n != 3 {
+				t.Errorf("received %d messages, want the burst of 3", n)
+			}
+			return err
+		})
+		checkExhausted(t, err, ss)
+	}
+
+	server := &grpc.StreamServerInfo{FullMethod: "/ratelimit.v1.Service/Watch", IsServerStream: true}
+	err := intercept(nil, &testStream{ctx: context.Background()}, server, func(srv interface{}, stream grpc.ServerStream) error {
+		_, err := recv(stream)
+		return err
+	})
+	if err != nil {
+		t.Errorf("messages of a server stream = %v, want no limit", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	for delay, want := range map[time.Duration]string{
+		time.Second:             "1000",
+		1500 * time.Microsecond: "2",
+	} {
+		if got := retryAfter(delay).Get(RetryAfterKey); len(got) != 1 || got[0] != want {
+			t.Errorf("retryAfter(%v) = %v, want %s", delay, got, want)
+		}
+	}
+}
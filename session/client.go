@@ -0,0 +1,36 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewID returns a random session ID for a client to open a session with.
+func NewID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// Reconnect calls stream until it succeeds, calling it again after it fails
+// with codes.Unavailable, which is what a broken connection looks like, at
+// most attempts times. resume is false on the first call only. The wait
+// before every new call starts at backoff and doubles each time.
+func Reconnect(attempts int, backoff time.Duration, stream func(resume bool) error) error {
+	for i := 0; ; i++ {
+		err := stream(i > 0)
+		if err == nil || status.Code(err) != codes.Unavailable || i >= attempts {
+			return err
+		}
+		log.Printf("Stream interrupted (%v), reconnecting in %v...", err, backoff)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
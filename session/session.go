@@ -0,0 +1,158 @@
+// Package session keeps the state of resumable bidirectional streams, such
+// as FindMaximum and GreetEveryone, so that a client whose connection drops
+// can reconnect and continue where it left off.
+package session
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// MaxIDLength is the longest session ID accepted.
+const MaxIDLength = 128
+
+var (
+	// ErrSuperseded is returned to a stream whose session was taken over by
+	// a newer stream, typically after the client reconnected.
+	ErrSuperseded = errors.New("session resumed by another stream")
+	// ErrInvalidID is returned for empty or overly long session IDs.
+	ErrInvalidID = errors.New("invalid session ID")
+	// ErrNotOwner is returned when attaching to a session created by
+	// another owner.
+	ErrNotOwner = errors.New("session belongs to another caller")
+)
+
+// State is the part of a session a stream handler reads and updates.
+type State struct {
+	// LastSequence is the highest sequence number processed.
+	LastSequence uint64
+	// Value holds the service specific state, e.g. the running maximum.
+	Value interface{}
+}
+
+type session struct {
+	mu       sync.Mutex
+	state    State
+	creator  string // owner of the stream that created the session
+	owner    uint64 // generation of the stream attached to the session
+	attached bool
+	expires  time.Time
+}
+
+// Manager holds the sessions of one service. Sessions whose stream broke are
+// kept for a TTL, after which they are forgotten.
+type Manager struct {
+	ttl time.Duration
+
+	mu         sync.Mutex
+	sessions   map[string]*session
+	generation uint64
+	lastSweep  time.Time
+}
+
+// NewManager returns a Manager keeping detached sessions for ttl.
+func NewManager(ttl time.Duration) *Manager {
+	return &Manager{
+		ttl:       ttl,
+		sessions:  make(map[string]*session),
+		lastSweep: time.Now(),
+	}
+}
+
+// Handle binds a stream to a session.
+type Handle struct {
+	m   *Manager
+	id  string
+	s   *session
+	gen uint64
+}
+
+// Attach binds the calling stream of owner, as returned by auth.Owner, to
+// the session id, creating it if needed. The second value reports whether an
+// existing session was resumed. A stream still attached to the session is
+// superseded. Sessions created by another owner fail with ErrNotOwner.
+func (m *Manager) Attach(id, owner string) (*Handle, bool, error) {
+	if id == "" || len(id) > MaxIDLength {
+		return nil, false, ErrInvalidID
+	}
+
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sweep(now)
+
+	s, resumed := m.sessions[id]
+	if !resumed {
+		s = &session{creator: owner}
+		m.sessions[id] = s
+	} else if s.creator != owner {
+		return nil, false, ErrNotOwner
+	}
+	m.generation++
+	gen := m.generation
+
+	s.mu.Lock()
+	s.owner = gen
+	s.attached = true
+	s.mu.Unlock()
+
+	return &Handle{m: m, id: id, s: s, gen: gen}, resumed, nil
+}
+
+// sweep forgets expired sessions. It must be called with m.mu held.
+func (m *Manager) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < m.ttl/2 {
+		return
+	}
+	for id, s := range m.sessions {
+		s.mu.Lock()
+		expired := !s.attached && now.After(s.expires)
+		s.mu.Unlock()
+		if expired {
+			delete(m.sessions, id)
+		}
+	}
+	m.lastSweep = now
+}
+
+// ID returns the session ID.
+func (h *Handle) ID() string {
+	return h.id
+}
+
+// Do calls f with the session state, unless the stream was superseded.
+func (h *Handle) Do(f func(*State)) error {
+	h.s.mu.Lock()
+	defer h.s.mu.Unlock()
+	if h.s.owner != h.gen {
+		return ErrSuperseded
+	}
+	f(&h.s.state)
+	return nil
+}
+
+// Detach releases the session after its stream broke, keeping its state
+// for the manager TTL so the client can resume it.
+func (h *Handle) Detach() {
+	h.s.mu.Lock()
+	defer h.s.mu.Unlock()
+	if h.s.owner != h.gen {
+		return
+	}
+	h.s.attached = false
+	h.s.expires = time.Now().Add(h.m.ttl)
+}
+
+// Close ends the session once the client finished it.
+func (h *Handle) Close() {
+	h.m.mu.Lock()
+	defer h.m.mu.Unlock()
+
+	h.s.mu.Lock()
+	owner := h.s.owner == h.gen
+	h.s.mu.Unlock()
+	if owner && h.m.sessions[h.id] == h.s {
+		delete(h.m.sessions, h.id)
+	}
+}
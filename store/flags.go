@@ -0,0 +1,27 @@
+package store
+
+import (
+	"flag"
+	"log"
+)
+
+// Flags selects the store of a server from the command line.
+type Flags struct {
+	// Path is the BoltDB file to use. When empty, state is kept in memory.
+	Path string
+}
+
+// RegisterFlags registers the store flags on fs, using the current values
+// as defaults.
+func (f *Flags) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&f.Path, "state-file", f.Path, "BoltDB file checkpointing streaming computations; in memory when empty")
+}
+
+// Open opens the selected store.
+func (f *Flags) Open() (Store, error) {
+	if f.Path == "" {
+		log.Println("State is kept in memory, aggregations are lost on restart.")
+		return NewMemory(), nil
+	}
+	return OpenBolt(f.Path)
+}
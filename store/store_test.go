@@ -0,0 +1,115 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// testStore checks the behavior every Store shares.
+func testStore(t *testing.T, s Store) {
+	t.Helper()
+	if _, err := s.Get("missing"); err != ErrNotFound {
+		t.Errorf("Get of a missing key = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete("missing"); err != nil {
+		t.Errorf("Delete of a missing key = %v", err)
+	}
+
+	value := []byte("v1")
+	if err := s.Put("k", value); err != nil {
+		t.Fatal(err)
+	}
+	// Stores keep their own copy of values.
+	value[0] = 'x'
+	got, err := s.Get("k")
+	if err != nil || !bytes.Equal(got, []byte("v1")) {
+		t.Fatalf("Get = %q, %v, want v1", got, err)
+	}
+	got[0] = 'x'
+	if got, _ := s.Get("k"); !bytes.Equal(got, []byte("v1")) {
+		t.Errorf("changing a value changed the store: %q", got)
+	}
+
+	if err := s.Put("k", []byte("v2")); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := s.Get("k"); err != nil || !bytes.Equal(got, []byte("v2")) {
+		t.Errorf("Get after Put = %q, %v, want v2", got, err)
+	}
+	if err := s.Delete("k"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get("k"); err != ErrNotFound {
+		t.Errorf("Get after Delete = %v, want ErrNotFound", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprint("key", i)
+			for j := 0; j < 20; j++ {
+				if err := s.Put(key, []byte(fmt.Sprint(j))); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := s.Get(key); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestMemory(t *testing.T) {
+	testStore(t, NewMemory())
+}
+
+func TestBolt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	s, err := OpenBolt(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, s)
+
+	if err := s.Put("kept", []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	s, err = OpenBolt(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if got, err := s.Get("kept"); err != nil || string(got) != "value" {
+		t.Errorf("Get after reopening = %q, %v, want value", got, err)
+	}
+}
+
+func TestFlags(t *testing.T) {
+	s, err := (&Flags{}).Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.(*Memory); !ok {
+		t.Errorf("Open without a path = %T, want *Memory", s)
+	}
+
+	s, err = (&Flags{Path: filepath.Join(t.TempDir(), "state.db")}).Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if _, ok := s.(*Bolt); !ok {
+		t.Errorf("Open with a path = %T, want *Bolt", s)
+	}
+}
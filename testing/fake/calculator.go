@@ -0,0 +1,126 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/AlanKev117/go-grpc/calculator/calc"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Streams of CalculatorService.
+type (
+	PrimeNumberDecompositionStream = ServerStream[calculatorv2pb.PrimeNumberDecompositionResponse]
+	ComputeAverageStream           = ClientStream[calculatorv2pb.ComputeAverageRequest, calculatorv2pb.ComputeAverageResponse]
+	FindMaximumStream              = BidiStream[calculatorv2pb.FindMaximumRequest, calculatorv2pb.FindMaximumResponse]
+)
+
+// CalculatorClient is a fake calculatorv2pb.CalculatorServiceClient. Each
+// method calls the matching function when it is set, and otherwise
+// calculates as the service does, without checkpointing aggregations: they
+// are not found by GetAggregation. Call options are ignored.
+type CalculatorClient struct {
+	CalculateFunc                func(context.Context, *calculatorv2pb.OperationRequest) (*calculatorv2pb.OperationResponse, error)
+	PrimeNumberDecompositionFunc func(context.Context, *calculatorv2pb.PrimeNumberDecompositionRequest) *PrimeNumberDecompositionStream
+	ComputeAverageFunc           func(context.Context) *ComputeAverageStream
+	FindMaximumFunc              func(context.Context) *FindMaximumStream
+	GetAggregationFunc           func(context.Context, *calculatorv2pb.GetAggregationRequest) (*calculatorv2pb.Aggregation, error)
+}
+
+var _ calculatorv2pb.CalculatorServiceClient = (*CalculatorClient)(nil)
+
+func (c *CalculatorClient) Calculate(ctx context.Context, req *calculatorv2pb.OperationRequest, _ ...grpc.CallOption) (*calculatorv2pb.OperationResponse, error) {
+	if c.CalculateFunc != nil {
+		return c.CalculateFunc(ctx, req)
+	}
+	args := req.GetOperationArgs()
+	result, err := calc.Calculate(calc.Operation(args.GetOperation()), args.GetValue1(), args.GetValue2())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &calculatorv2pb.OperationResponse{Result: result}, nil
+}
+
+func (c *CalculatorClient) PrimeNumberDecomposition(ctx context.Context, req *calculatorv2pb.PrimeNumberDecompositionRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[calculatorv2pb.PrimeNumberDecompositionResponse], error) {
+	var s *PrimeNumberDecompositionStream
+	if c.PrimeNumberDecompositionFunc != nil {
+		s = c.PrimeNumberDecompositionFunc(ctx, req)
+	} else {
+		s = &PrimeNumberDecompositionStream{}
+		for prime := range calc.Factors(req.GetNumber()) {
+			s.Responses = append(s.Responses, &calculatorv2pb.PrimeNumberDecompositionResponse{Prime: prime})
+		}
+	}
+	s.ctx = ctx
+	return s, nil
+}
+
+func (c *CalculatorClient) ComputeAverage(ctx context.Context, _ ...grpc.CallOption) (grpc.ClientStreamingClient[calculatorv2pb.ComputeAverageRequest, calculatorv2pb.ComputeAverageResponse], error) {
+	var s *ComputeAverageStream
+	if c.ComputeAverageFunc != nil {
+		s = c.ComputeAverageFunc(ctx)
+	} else {
+		s = NewClientStream(Average)
+	}
+	s.ctx = ctx
+	return s, nil
+}
+
+func (c *CalculatorClient) FindMaximum(ctx context.Context, _ ...grpc.CallOption) (grpc.BidiStreamingClient[calculatorv2pb.FindMaximumRequest, calculatorv2pb.FindMaximumResponse], error) {
+	var s *FindMaximumStream
+	if c.FindMaximumFunc != nil {
+		s = c.FindMaximumFunc(ctx)
+	} else {
+		s = Maxima()
+	}
+	s.ctx = ctx
+	return s, nil
+}
+
+func (c *CalculatorClient) GetAggregation(ctx context.Context, req *calculatorv2pb.GetAggregationRequest, _ ...grpc.CallOption) (*calculatorv2pb.Aggregation, error) {
+	if c.GetAggregationFunc != nil {
+		return c.GetAggregationFunc(ctx, req)
+	}
+	return nil, status.Errorf(codes.NotFound, "no aggregation %s", req.GetId())
+}
+
+// Average answers ComputeAverage with the average of the numbers sent,
+// failing as the service does when none was.
+func Average(sent []*calculatorv2pb.ComputeAverageRequest) (*calculatorv2pb.ComputeAverageResponse, error) {
+	var avg calc.Average
+	for _, req := range sent {
+		avg.Add(req.GetNumber())
+	}
+	if avg.Count == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no numbers to average")
+	}
+	return &calculatorv2pb.ComputeAverageResponse{
+		Average:       avg.Value,
+		AggregationId: sent[0].GetAggregationId(),
+		Count:         avg.Count,
+	}, nil
+}
+
+// Maxima returns a FindMaximum stream answering every new maximum the
+// client sends, as the service does for streams without a session.
+func Maxima() *FindMaximumStream {
+	var maximum calc.Maximum
+	return NewBidiStream(func(req *calculatorv2pb.FindMaximumRequest) ([]*calculatorv2pb.FindMaximumResponse, error) {
+		if !maximum.Add(req.GetNumber()) {
+			return nil, nil
+		}
+		return []*calculatorv2pb.FindMaximumResponse{{Maximum: maximum.Value}}, nil
+	})
+}
+
+// ReplayMaxima returns a FindMaximum stream receiving maxima, in order,
+// whatever the client sends. It ends once the client closes it.
+func ReplayMaxima(maxima ...float64) *FindMaximumStream {
+	s := &FindMaximumStream{}
+	for _, m := range maxima {
+		s.Responses = append(s.Responses, &calculatorv2pb.FindMaximumResponse{Maximum: m})
+	}
+	return s
+}
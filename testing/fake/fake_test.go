@@ -0,0 +1,275 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestCalculate(t *testing.T) {
+	ctx := testContext(t)
+	c := &CalculatorClient{}
+	res, err := c.Calculate(ctx, &calculatorv2pb.OperationRequest{OperationArgs: &calculatorv2pb.OperationArgs{
+		Value1: 3, Value2: 4, Operation: calculatorv2pb.Operation_OPCODE_DIV,
+	}})
+	if err != nil || res.GetResult() != 0.75 {
+		t.Errorf("Calculate = %v, %v, want 0.75", res, err)
+	}
+	_, err = c.Calculate(ctx, &calculatorv2pb.OperationRequest{OperationArgs: &calculatorv2pb.OperationArgs{Operation: 9}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Calculate of an unknown operation = %v, want InvalidArgument", err)
+	}
+
+	c.CalculateFunc = func(context.Context, *calculatorv2pb.OperationRequest) (*calculatorv2pb.OperationResponse, error) {
+		return nil, status.Error(codes.Unavailable, "down")
+	}
+	if _, err := c.Calculate(ctx, &calculatorv2pb.OperationRequest{}); status.Code(err) != codes.Unavailable {
+		t.Errorf("scripted Calculate = %v, want Unavailable", err)
+	}
+}
+
+func TestPrimeNumberDecomposition(t *testing.T) {
+	ctx := testContext(t)
+	c := &CalculatorClient{}
+	stream, err := c.PrimeNumberDecomposition(ctx, &calculatorv2pb.PrimeNumberDecompositionRequest{Number: 120})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var primes []uint64
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		primes = append(primes, res.GetPrime())
+	}
+	if fmt.Sprint(primes) != "[2 2 2 3 5]" {
+		t.Errorf("primes of 120 = %v", primes)
+	}
+
+	// A scripted stream fails after its responses.
+	c.PrimeNumberDecompositionFunc = func(context.Context, *calculatorv2pb.PrimeNumberDecompositionRequest) *PrimeNumberDecompositionStream {
+		s := NewServerStream(&calculatorv2pb.PrimeNumberDecompositionResponse{Prime: 7})
+		s.Err = status.Error(codes.Unavailable, "down")
+		return s
+	}
+	stream, err = c.PrimeNumberDecomposition(ctx, &calculatorv2pb.PrimeNumberDecompositionRequest{Number: 120})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res, err := stream.Recv(); err != nil || res.GetPrime() != 7 {
+		t.Errorf("first Recv = %v, %v, want 7", res, err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("second Recv = %v, want Unavailable", err)
+	}
+}
+
+func TestComputeAverage(t *testing.T) {
+	ctx := testContext(t)
+	c := &CalculatorClient{}
+	stream, err := c.ComputeAverage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []float64{1, 2, 6} {
+		if err := stream.Send(&calculatorv2pb.ComputeAverageRequest{Number: n}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil || res.GetAverage() != 3 || res.GetCount() != 3 {
+		t.Errorf("CloseAndRecv = %v, %v, want 3 of 3 numbers", res, err)
+	}
+	if err := stream.Send(&calculatorv2pb.ComputeAverageRequest{Number: 1}); err == nil {
+		t.Error("Send after CloseAndRecv succeeded")
+	}
+
+	stream, err = c.ComputeAverage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.CloseAndRecv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CloseAndRecv of no numbers = %v, want InvalidArgument", err)
+	}
+
+	// The stream records what was sent.
+	fake := NewClientStream[calculatorv2pb.ComputeAverageRequest, calculatorv2pb.ComputeAverageResponse](nil)
+	c.ComputeAverageFunc = func(context.Context) *ComputeAverageStream { return fake }
+	stream, err = c.ComputeAverage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Send(&calculatorv2pb.ComputeAverageRequest{Number: 4})
+	if res, err := stream.CloseAndRecv(); err != nil || res.GetAverage() != 0 {
+		t.Errorf("CloseAndRecv without Reply = %v, %v, want an empty response", res, err)
+	}
+	if sent := fake.Sent(); len(sent) != 1 || sent[0].GetNumber() != 4 {
+		t.Errorf("Sent = %v, want 4", sent)
+	}
+}
+
+func TestFindMaximum(t *testing.T) {
+	ctx := testContext(t)
+	c := &CalculatorClient{}
+	stream, err := c.FindMaximum(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Receive from another goroutine, as clients of bidirectional streams
+	// do.
+	done := make(chan []float64)
+	go func() {
+		var maxima []float64
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF {
+					t.Error(err)
+				}
+				done <- maxima
+				return
+			}
+			maxima = append(maxima, res.GetMaximum())
+		}
+	}()
+	for _, n := range []float64{1, 5, 3, 6, 2, 20} {
+		if err := stream.Send(&calculatorv2pb.FindMaximumRequest{Number: n}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stream.CloseSend()
+	if maxima := <-done; fmt.Sprint(maxima) != "[1 5 6 20]" {
+		t.Errorf("maxima = %v, want [1 5 6 20]", maxima)
+	}
+}
+
+func TestReplayMaxima(t *testing.T) {
+	ctx := testContext(t)
+	replay := ReplayMaxima(4, 8)
+	c := &CalculatorClient{FindMaximumFunc: func(context.Context) *FindMaximumStream { return replay }}
+	stream, err := c.FindMaximum(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Send(&calculatorv2pb.FindMaximumRequest{Number: 100})
+	for _, want := range []float64{4, 8} {
+		if res, err := stream.Recv(); err != nil || res.GetMaximum() != want {
+			t.Errorf("Recv = %v, %v, want %v", res, err, want)
+		}
+	}
+	stream.CloseSend()
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("Recv after the maxima = %v, want io.EOF", err)
+	}
+	if sent := replay.Sent(); len(sent) != 1 || sent[0].GetNumber() != 100 {
+		t.Errorf("Sent = %v, want 100", sent)
+	}
+}
+
+func TestBidiStream(t *testing.T) {
+	errFailed := status.Error(codes.Aborted, "failed")
+	s := NewBidiStream(func(req *calculatorv2pb.FindMaximumRequest) ([]*calculatorv2pb.FindMaximumResponse, error) {
+		if req.GetNumber() < 0 {
+			return nil, errFailed
+		}
+		return []*calculatorv2pb.FindMaximumResponse{{Maximum: req.GetNumber()}}, nil
+	})
+	s.Send(&calculatorv2pb.FindMaximumRequest{Number: 1})
+	s.Send(&calculatorv2pb.FindMaximumRequest{Number: -1})
+	if err := s.Send(&calculatorv2pb.FindMaximumRequest{Number: 2}); err != io.EOF {
+		t.Errorf("Send after a failure = %v, want io.EOF", err)
+	}
+	if res, err := s.Recv(); err != nil || res.GetMaximum() != 1 {
+		t.Errorf("Recv = %v, %v, want 1", res, err)
+	}
+	if _, err := s.Recv(); !errors.Is(err, errFailed) {
+		t.Errorf("Recv after the responses = %v, want %v", err, errFailed)
+	}
+
+	// Recv waits for the context of the call.
+	ctx, cancel := context.WithCancel(context.Background())
+	s = NewBidiStream[calculatorv2pb.FindMaximumRequest, calculatorv2pb.FindMaximumResponse](nil)
+	s.ctx = ctx
+	cancel()
+	if _, err := s.Recv(); status.Code(err) != codes.Canceled {
+		t.Errorf("Recv after cancelling = %v, want Canceled", err)
+	}
+}
+
+func TestGreetClient(t *testing.T) {
+	ctx := testContext(t)
+	c := &GreetClient{}
+	res, err := c.Greet(ctx, &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "Alan", SecondName: "Kevin"}})
+	if err != nil || res.GetResult() != "Hello, Alan Kevin" || res.GetLocale() != "en" {
+		t.Errorf("Greet = %v, %v", res, err)
+	}
+	spanish := metadata.AppendToOutgoingContext(ctx, "accept-language", "es")
+	if res, err := c.Greet(spanish, &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "Alan"}}); err != nil || res.GetResult() != "Hola, Alan" {
+		t.Errorf("Greet in Spanish = %v, %v", res, err)
+	}
+	_, err = c.Greet(ctx, &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: "Alan", TemplateId: "missing"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Greet with a missing template = %v, want NotFound", err)
+	}
+
+	many, err := c.GreetManyTimes(ctx, &greetpb.GreetManyTimesRequest{Greeting: &greetpb.Greeting{FirstName: "Alan"}, Count: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []string
+	for {
+		res, err := many.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, res.GetResult())
+	}
+	if fmt.Sprint(results) != "[Hello, Alan (1) Hello, Alan (2)]" {
+		t.Errorf("GreetManyTimes = %q", results)
+	}
+
+	long, err := c.LongGreet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	long.Send(&greetpb.LongGreetRequest{Greeting: &greetpb.Greeting{FirstName: "Luis", SecondName: "García"}, Options: &greetpb.LongGreetOptions{ListJoin: true}})
+	long.Send(&greetpb.LongGreetRequest{Greeting: &greetpb.Greeting{FirstName: "Ana", SecondName: "García"}})
+	if res, err := long.CloseAndRecv(); err != nil || res.GetResult() != "Hello, Luis García and Ana García" || len(res.GetPeople()) != 2 {
+		t.Errorf("LongGreet = %v, %v", res, err)
+	}
+
+	everyone, err := c.GreetEveryone(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	everyone.Send(&greetpb.GreetEveryoneRequest{Greeting: &greetpb.Greeting{FirstName: "Alan"}})
+	if res, err := everyone.Recv(); err != nil || res.GetResult() != "Hello, Alan" {
+		t.Errorf("GreetEveryone = %v, %v", res, err)
+	}
+
+	if _, err := c.ListGreetings(ctx, &greetpb.ListGreetingsRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("ListGreetings = %v, want Unimplemented", err)
+	}
+}
@@ -0,0 +1,197 @@
+package fake
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AlanKev117/go-grpc/greet/greeter"
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/AlanKev117/go-grpc/greet/templates"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Streams of GreetService.
+type (
+	GreetManyTimesStream = ServerStream[greetpb.GreetManyTimesResponse]
+	LongGreetStream      = ClientStream[greetpb.LongGreetRequest, greetpb.LongGreetResponse]
+	GreetEveryoneStream  = BidiStream[greetpb.GreetEveryoneRequest, greetpb.GreetEveryoneResponse]
+)
+
+// GreetClient is a fake greetpb.GreetServiceClient. Each method calls the
+// matching function when it is set, and otherwise greets as the service
+// does with the default template, in the locale of the greeting or the
+// accept-language metadata of the call. GreetManyTimes does not wait
+// between greetings, GreetEveryone ignores rooms and sessions, and the
+// template and history methods are unimplemented. Call options are
+// ignored.
+type GreetClient struct {
+	GreetFunc          func(context.Context, *greetpb.GreetRequest) (*greetpb.GreetResponse, error)
+	GreetManyTimesFunc func(context.Context, *greetpb.GreetManyTimesRequest) *GreetManyTimesStream
+	LongGreetFunc      func(context.Context) *LongGreetStream
+	GreetEveryoneFunc  func(context.Context) *GreetEveryoneStream
+	ListTemplatesFunc  func(context.Context, *greetpb.ListTemplatesRequest) (*greetpb.ListTemplatesResponse, error)
+	CreateTemplateFunc func(context.Context, *greetpb.CreateTemplateRequest) (*greetpb.Template, error)
+	UpdateTemplateFunc func(context.Context, *greetpb.UpdateTemplateRequest) (*greetpb.Template, error)
+	ListGreetingsFunc  func(context.Context, *greetpb.ListGreetingsRequest) (*greetpb.ListGreetingsResponse, error)
+}
+
+var _ greetpb.GreetServiceClient = (*GreetClient)(nil)
+
+// defaultGreeter greets with the default template.
+var defaultGreeter = greeter.New(templates.NewRegistry())
+
+func (c *GreetClient) Greet(ctx context.Context, req *greetpb.GreetRequest, _ ...grpc.CallOption) (*greetpb.GreetResponse, error) {
+	if c.GreetFunc != nil {
+		return c.GreetFunc(ctx, req)
+	}
+	result, locale, err := defaultGreeter.Greet(greeting(ctx, req.GetGreeting()), 0)
+	if err != nil {
+		return nil, greetError(err)
+	}
+	return &greetpb.GreetResponse{Result: result, Locale: locale.String()}, nil
+}
+
+func (c *GreetClient) GreetManyTimes(ctx context.Context, req *greetpb.GreetManyTimesRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[greetpb.GreetManyTimesResponse], error) {
+	var s *GreetManyTimesStream
+	if c.GreetManyTimesFunc != nil {
+		s = c.GreetManyTimesFunc(ctx, req)
+	} else {
+		s = &GreetManyTimesStream{}
+		count := greeter.DefaultSchedule.Count
+		if req.GetCount() > 0 {
+			count = int(req.GetCount())
+		}
+		g := greeting(ctx, req.GetGreeting())
+		for i := 1; i <= count; i++ {
+			result, _, err := defaultGreeter.Greet(g, i)
+			if err != nil {
+				s.Err = greetError(err)
+				break
+			}
+			s.Responses = append(s.Responses, &greetpb.GreetManyTimesResponse{Result: result})
+		}
+	}
+	s.ctx = ctx
+	return s, nil
+}
+
+func (c *GreetClient) LongGreet(ctx context.Context, _ ...grpc.CallOption) (grpc.ClientStreamingClient[greetpb.LongGreetRequest, greetpb.LongGreetResponse], error) {
+	var s *LongGreetStream
+	if c.LongGreetFunc != nil {
+		s = c.LongGreetFunc(ctx)
+	} else {
+		s = NewClientStream(func(sent []*greetpb.LongGreetRequest) (*greetpb.LongGreetResponse, error) {
+			return greetGroup(ctx, sent)
+		})
+	}
+	s.ctx = ctx
+	return s, nil
+}
+
+func (c *GreetClient) GreetEveryone(ctx context.Context, _ ...grpc.CallOption) (grpc.BidiStreamingClient[greetpb.GreetEveryoneRequest, greetpb.GreetEveryoneResponse], error) {
+	var s *GreetEveryoneStream
+	if c.GreetEveryoneFunc != nil {
+		s = c.GreetEveryoneFunc(ctx)
+	} else {
+		s = NewBidiStream(func(req *greetpb.GreetEveryoneRequest) ([]*greetpb.GreetEveryoneResponse, error) {
+			result, _, err := defaultGreeter.Greet(greeting(ctx, req.GetGreeting()), 0)
+			if err != nil {
+				return nil, greetError(err)
+			}
+			return []*greetpb.GreetEveryoneResponse{{Result: result}}, nil
+		})
+	}
+	s.ctx = ctx
+	return s, nil
+}
+
+func (c *GreetClient) ListTemplates(ctx context.Context, req *greetpb.ListTemplatesRequest, _ ...grpc.CallOption) (*greetpb.ListTemplatesResponse, error) {
+	if c.ListTemplatesFunc != nil {
+		return c.ListTemplatesFunc(ctx, req)
+	}
+	return nil, status.Error(codes.Unimplemented, "method ListTemplates not implemented")
+}
+
+func (c *GreetClient) CreateTemplate(ctx context.Context, req *greetpb.CreateTemplateRequest, _ ...grpc.CallOption) (*greetpb.Template, error) {
+	if c.CreateTemplateFunc != nil {
+		return c.CreateTemplateFunc(ctx, req)
+	}
+	return nil, status.Error(codes.Unimplemented, "method CreateTemplate not implemented")
+}
+
+func (c *GreetClient) UpdateTemplate(ctx context.Context, req *greetpb.UpdateTemplateRequest, _ ...grpc.CallOption) (*greetpb.Template, error) {
+	if c.UpdateTemplateFunc != nil {
+		return c.UpdateTemplateFunc(ctx, req)
+	}
+	return nil, status.Error(codes.Unimplemented, "method UpdateTemplate not implemented")
+}
+
+func (c *GreetClient) ListGreetings(ctx context.Context, req *greetpb.ListGreetingsRequest, _ ...grpc.CallOption) (*greetpb.ListGreetingsResponse, error) {
+	if c.ListGreetingsFunc != nil {
+		return c.ListGreetingsFunc(ctx, req)
+	}
+	return nil, status.Error(codes.Unimplemented, "method ListGreetings not implemented")
+}
+
+// greeting returns what g asks for, in the locale of g, else the languages
+// of the accept-language metadata sent with ctx.
+func greeting(ctx context.Context, g *greetpb.Greeting) greeter.Greeting {
+	languages := []string{g.GetLocale()}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		languages = append(languages, md.Get("accept-language")...)
+	}
+	return greeter.Greeting{
+		FirstName:  g.GetFirstName(),
+		SecondName: g.GetSecondName(),
+		Languages:  languages,
+		Formal:     g.GetFormality() == greetpb.Formality_FORMALITY_FORMAL,
+		TemplateID: g.GetTemplateId(),
+	}
+}
+
+// greetGroup answers LongGreet, with the options of the first request.
+func greetGroup(ctx context.Context, sent []*greetpb.LongGreetRequest) (*greetpb.LongGreetResponse, error) {
+	if len(sent) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no greetings received")
+	}
+	group := make([]greeter.Greeting, len(sent))
+	for i, req := range sent {
+		group[i] = greeting(ctx, req.GetGreeting())
+	}
+	opts := sent[0].GetOptions()
+	greeted, err := defaultGreeter.GreetGroup(group, greeter.GroupOptions{
+		Dedupe:            opts.GetDedupe(),
+		Sort:              opts.GetSort(),
+		GroupBySecondName: opts.GetGroupBySecondName(),
+		ListJoin:          opts.GetListJoin(),
+		MaxNames:          int(opts.GetMaxNames()),
+	})
+	if err != nil {
+		return nil, greetError(err)
+	}
+	res := &greetpb.LongGreetResponse{
+		Result: greeted.Result,
+		Others: uint32(greeted.Others),
+		Locale: greeted.Locale.String(),
+	}
+	for _, p := range greeted.People {
+		res.People = append(res.People, &greetpb.Person{
+			FirstName:  p.FirstName,
+			SecondName: p.SecondName,
+			Name:       p.Name,
+			Count:      uint32(p.Count),
+		})
+	}
+	return res, nil
+}
+
+// greetError maps the errors of greeter to statuses, as the service does.
+func greetError(err error) error {
+	if errors.Is(err, templates.ErrNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,281 @@
+// Package fake has in-process fakes of the clients of GreetService and
+// calculator.v2 CalculatorService, for testing code that calls the services
+// without running them. Unlike the mocks of package mock, fakes answer
+// every call: by default as the services would, computing the answers with
+// the greeter and calc packages, else as scripted by the test. The streams
+// record what the client sent, for the test to check afterwards.
+package fake
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// errClosed is returned when sending on a stream the client closed.
+var errClosed = errors.New("fake: send on a closed stream")
+
+// stream implements the methods of grpc.ClientStream shared by the fake
+// streams. Servers send no metadata.
+type stream struct {
+	ctx context.Context
+}
+
+func (s *stream) Header() (metadata.MD, error) { return metadata.MD{}, nil }
+func (s *stream) Trailer() metadata.MD         { return metadata.MD{} }
+
+func (s *stream) Context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
+// ctxErr returns the status of the call when its context ended.
+func (s *stream) ctxErr() error {
+	if err := s.Context().Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
+
+// recvMsg receives into m with recv, for the RecvMsg methods.
+func recvMsg[Res any](m any, recv func() (*Res, error)) error {
+	res, err := recv()
+	if err != nil {
+		return err
+	}
+	proto.Reset(m.(proto.Message))
+	proto.Merge(m.(proto.Message), any(res).(proto.Message))
+	return nil
+}
+
+// ServerStream is the stream of a call streaming from the server. It
+// implements grpc.ServerStreamingClient.
+type ServerStream[Res any] struct {
+	stream
+
+	// Responses are received in order.
+	Responses []*Res
+	// Err ends the stream once every response was received; io.EOF when
+	// nil.
+	Err error
+
+	mu       sync.Mutex
+	received int
+}
+
+// NewServerStream returns a stream receiving responses.
+func NewServerStream[Res any](responses ...*Res) *ServerStream[Res] {
+	return &ServerStream[Res]{Responses: responses}
+}
+
+func (s *ServerStream[Res]) Recv() (*Res, error) {
+	if err := s.ctxErr(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.received < len(s.Responses) {
+		s.received++
+		return s.Responses[s.received-1], nil
+	}
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	return nil, io.EOF
+}
+
+func (s *ServerStream[Res]) RecvMsg(m any) error { return recvMsg(m, s.Recv) }
+func (s *ServerStream[Res]) SendMsg(m any) error { return errClosed }
+func (s *ServerStream[Res]) CloseSend() error    { return nil }
+
+// ClientStream is the stream of a call streaming from the client. It
+// implements grpc.ClientStreamingClient.
+type ClientStream[Req, Res any] struct {
+	stream
+
+	// Reply returns the response to the requests sent, once the client
+	// closes the stream. The response is empty when nil.
+	Reply func(sent []*Req) (*Res, error)
+
+	mu     sync.Mutex
+	sent   []*Req
+	closed bool
+}
+
+// NewClientStream returns a stream answering the requests sent with reply.
+func NewClientStream[Req, Res any](reply func(sent []*Req) (*Res, error)) *ClientStream[Req, Res] {
+	return &ClientStream[Req, Res]{Reply: reply}
+}
+
+func (s *ClientStream[Req, Res]) Send(req *Req) error {
+	if err := s.ctxErr(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errClosed
+	}
+	s.sent = append(s.sent, req)
+	return nil
+}
+
+func (s *ClientStream[Req, Res]) CloseAndRecv() (*Res, error) {
+	if err := s.ctxErr(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.closed = true
+	sent := s.sent
+	s.mu.Unlock()
+	if s.Reply == nil {
+		return new(Res), nil
+	}
+	return s.Reply(sent)
+}
+
+// Sent returns the requests sent so far.
+func (s *ClientStream[Req, Res]) Sent() []*Req {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Req(nil), s.sent...)
+}
+
+func (s *ClientStream[Req, Res]) SendMsg(m any) error { return s.Send(m.(*Req)) }
+func (s *ClientStream[Req, Res]) RecvMsg(m any) error { return recvMsg(m, s.CloseAndRecv) }
+
+func (s *ClientStream[Req, Res]) CloseSend() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+// BidiStream is the stream of a call streaming both ways. It implements
+// grpc.BidiStreamingClient. Recv blocks until a response is queued, the
+// client closes the stream or the context of the call ends, so the client
+// can send and receive from different goroutines as with real streams.
+type BidiStream[Req, Res any] struct {
+	stream
+
+	// Responses are received first, whatever the client sends.
+	Responses []*Res
+	// Reply returns the responses to a request, received after those to
+	// the requests sent before it. An error ends the stream once the
+	// responses before it were received. Requests get no response when
+	// Reply is nil.
+	Reply func(*Req) ([]*Res, error)
+	// Err ends the stream once the client closed it and every response
+	// was received; io.EOF when nil.
+	Err error
+
+	mu      sync.Mutex
+	started bool
+	queue   []*Res
+	sent    []*Req
+	closed  bool
+	failed  error
+	// changed is closed when the queue or the state of the stream change.
+	changed chan struct{}
+}
+
+// NewBidiStream returns a stream answering every request with reply.
+func NewBidiStream[Req, Res any](reply func(*Req) ([]*Res, error)) *BidiStream[Req, Res] {
+	return &BidiStream[Req, Res]{Reply: reply}
+}
+
+// start queues the initial responses. s.mu is held.
+func (s *BidiStream[Req, Res]) start() {
+	if !s.started {
+		s.started = true
+		s.queue = append(s.queue, s.Responses...)
+		s.changed = make(chan struct{})
+	}
+}
+
+// notify wakes up Recv. s.mu is held.
+func (s *BidiStream[Req, Res]) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *BidiStream[Req, Res]) Send(req *Req) error {
+	if err := s.ctxErr(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.start()
+	if s.failed != nil {
+		// As with gRPC, the error of the stream is returned by Recv.
+		return io.EOF
+	}
+	if s.closed {
+		return errClosed
+	}
+	s.sent = append(s.sent, req)
+	if s.Reply != nil {
+		responses, err := s.Reply(req)
+		s.queue = append(s.queue, responses...)
+		s.failed = err
+		s.notify()
+	}
+	return nil
+}
+
+func (s *BidiStream[Req, Res]) Recv() (*Res, error) {
+	for {
+		s.mu.Lock()
+		s.start()
+		switch {
+		case len(s.queue) > 0:
+			res := s.queue[0]
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+			return res, nil
+		case s.failed != nil:
+			s.mu.Unlock()
+			return nil, s.failed
+		case s.closed && s.Err != nil:
+			s.mu.Unlock()
+			return nil, s.Err
+		case s.closed:
+			s.mu.Unlock()
+			return nil, io.EOF
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-s.Context().Done():
+			return nil, s.ctxErr()
+		}
+	}
+}
+
+func (s *BidiStream[Req, Res]) CloseSend() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.start()
+	s.closed = true
+	s.notify()
+	return nil
+}
+
+// Sent returns the requests sent so far.
+func (s *BidiStream[Req, Res]) Sent() []*Req {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Req(nil), s.sent...)
+}
+
+func (s *BidiStream[Req, Res]) SendMsg(m any) error { return s.Send(m.(*Req)) }
+func (s *BidiStream[Req, Res]) RecvMsg(m any) error { return recvMsg(m, s.Recv) }
@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlanKev117/go-grpc/calculator/calculatorv2pb (interfaces: CalculatorServiceClient)
+//
+// Generated by this command:
+//
+//	mockgen -destination=calculator.go -package=mock -write_package_comment=false github.com/AlanKev117/go-grpc/calculator/calculatorv2pb CalculatorServiceClient
+//
+
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	calculatorv2pb "github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockCalculatorServiceClient is a mock of CalculatorServiceClient interface.
+type MockCalculatorServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalculatorServiceClientMockRecorder
+	isgomock struct{}
+}
+
+// MockCalculatorServiceClientMockRecorder is the mock recorder for MockCalculatorServiceClient.
+type MockCalculatorServiceClientMockRecorder struct {
+	mock *MockCalculatorServiceClient
+}
+
+// NewMockCalculatorServiceClient creates a new mock instance.
+func NewMockCalculatorServiceClient(ctrl *gomock.Controller) *MockCalculatorServiceClient {
+	mock := &MockCalculatorServiceClient{ctrl: ctrl}
+	mock.recorder = &MockCalculatorServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalculatorServiceClient) EXPECT() *MockCalculatorServiceClientMockRecorder {
+	return m.recorder
+}
+
+// Calculate mocks base method.
+func (m *MockCalculatorServiceClient) Calculate(ctx context.Context, in *calculatorv2pb.OperationRequest, opts ...grpc.CallOption) (*calculatorv2pb.OperationResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Calculate", varargs...)
+	ret0, _ := ret[0].(*calculatorv2pb.OperationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Calculate indicates an expected call of Calculate.
+func (mr *MockCalculatorServiceClientMockRecorder) Calculate(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Calculate", reflect.TypeOf((*MockCalculatorServiceClient)(nil).Calculate), varargs...)
+}
+
+// ComputeAverage mocks base method.
+func (m *MockCalculatorServiceClient) ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[calculatorv2pb.ComputeAverageRequest, calculatorv2pb.ComputeAverageResponse], error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ComputeAverage", varargs...)
+	ret0, _ := ret[0].(grpc.ClientStreamingClient[calculatorv2pb.ComputeAverageRequest, calculatorv2pb.ComputeAverageResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ComputeAverage indicates an expected call of ComputeAverage.
+func (mr *MockCalculatorServiceClientMockRecorder) ComputeAverage(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComputeAverage", reflect.TypeOf((*MockCalculatorServiceClient)(nil).ComputeAverage), varargs...)
+}
+
+// FindMaximum mocks base method.
+func (m *MockCalculatorServiceClient) FindMaximum(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[calculatorv2pb.FindMaximumRequest, calculatorv2pb.FindMaximumResponse], error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindMaximum", varargs...)
+	ret0, _ := ret[0].(grpc.BidiStreamingClient[calculatorv2pb.FindMaximumRequest, calculatorv2pb.FindMaximumResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMaximum indicates an expected call of FindMaximum.
+func (mr *MockCalculatorServiceClientMockRecorder) FindMaximum(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMaximum", reflect.TypeOf((*MockCalculatorServiceClient)(nil).FindMaximum), varargs...)
+}
+
+// GetAggregation mocks base method.
+func (m *MockCalculatorServiceClient) GetAggregation(ctx context.Context, in *calculatorv2pb.GetAggregationRequest, opts ...grpc.CallOption) (*calculatorv2pb.Aggregation, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAggregation", varargs...)
+	ret0, _ := ret[0].(*calculatorv2pb.Aggregation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAggregation indicates an expected call of GetAggregation.
+func (mr *MockCalculatorServiceClientMockRecorder) GetAggregation(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregation", reflect.TypeOf((*MockCalculatorServiceClient)(nil).GetAggregation), varargs...)
+}
+
+// PrimeNumberDecomposition mocks base method.
+func (m *MockCalculatorServiceClient) PrimeNumberDecomposition(ctx context.Context, in *calculatorv2pb.PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[calculatorv2pb.PrimeNumberDecompositionResponse], error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PrimeNumberDecomposition", varargs...)
+	ret0, _ := ret[0].(grpc.ServerStreamingClient[calculatorv2pb.PrimeNumberDecompositionResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrimeNumberDecomposition indicates an expected call of PrimeNumberDecomposition.
+func (mr *MockCalculatorServiceClientMockRecorder) PrimeNumberDecomposition(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrimeNumberDecomposition", reflect.TypeOf((*MockCalculatorServiceClient)(nil).PrimeNumberDecomposition), varargs...)
+}
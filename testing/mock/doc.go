@@ -0,0 +1,15 @@
+// Package mock has gomock mocks of the clients of GreetService and
+// calculator.v2 CalculatorService, and of the streams their calls return.
+// The stream mocks are generic, as the stream types of every service are
+// instances of the streams of grpc, e.g.
+//
+//	stream := mock.NewMockBidiStreamingClient[calculatorv2pb.FindMaximumRequest, calculatorv2pb.FindMaximumResponse](ctrl)
+//	client := mock.NewMockCalculatorServiceClient(ctrl)
+//	client.EXPECT().FindMaximum(gomock.Any()).Return(stream, nil)
+//
+// Regenerate them with go generate after changing the services.
+package mock
+
+//go:generate mockgen -destination=greet.go -package=mock -write_package_comment=false github.com/AlanKev117/go-grpc/greet/greetpb GreetServiceClient
+//go:generate mockgen -destination=calculator.go -package=mock -write_package_comment=false github.com/AlanKev117/go-grpc/calculator/calculatorv2pb CalculatorServiceClient
+//go:generate mockgen -destination=streams.go -package=mock -write_package_comment=false google.golang.org/grpc ServerStreamingClient,ClientStreamingClient,BidiStreamingClient
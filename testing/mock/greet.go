@@ -0,0 +1,202 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/AlanKev117/go-grpc/greet/greetpb (interfaces: GreetServiceClient)
+//
+// Generated by this command:
+//
+//	mockgen -destination=greet.go -package=mock -write_package_comment=false github.com/AlanKev117/go-grpc/greet/greetpb GreetServiceClient
+//
+
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	greetpb "github.com/AlanKev117/go-grpc/greet/greetpb"
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockGreetServiceClient is a mock of GreetServiceClient interface.
+type MockGreetServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockGreetServiceClientMockRecorder
+	isgomock struct{}
+}
+
+// MockGreetServiceClientMockRecorder is the mock recorder for MockGreetServiceClient.
+type MockGreetServiceClientMockRecorder struct {
+	mock *MockGreetServiceClient
+}
+
+// NewMockGreetServiceClient creates a new mock instance.
+func NewMockGreetServiceClient(ctrl *gomock.Controller) *MockGreetServiceClient {
+	mock := &MockGreetServiceClient{ctrl: ctrl}
+	mock.recorder = &MockGreetServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGreetServiceClient) EXPECT() *MockGreetServiceClientMockRecorder {
+	return m.recorder
+}
+
+// CreateTemplate mocks base method.
+func (m *MockGreetServiceClient) CreateTemplate(ctx context.Context, in *greetpb.CreateTemplateRequest, opts ...grpc.CallOption) (*greetpb.Template, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateTemplate", varargs...)
+	ret0, _ := ret[0].(*greetpb.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockGreetServiceClientMockRecorder) CreateTemplate(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockGreetServiceClient)(nil).CreateTemplate), varargs...)
+}
+
+// Greet mocks base method.
+func (m *MockGreetServiceClient) Greet(ctx context.Context, in *greetpb.GreetRequest, opts ...grpc.CallOption) (*greetpb.GreetResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Greet", varargs...)
+	ret0, _ := ret[0].(*greetpb.GreetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Greet indicates an expected call of Greet.
+func (mr *MockGreetServiceClientMockRecorder) Greet(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Greet", reflect.TypeOf((*MockGreetServiceClient)(nil).Greet), varargs...)
+}
+
+// GreetEveryone mocks base method.
+func (m *MockGreetServiceClient) GreetEveryone(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[greetpb.GreetEveryoneRequest, greetpb.GreetEveryoneResponse], error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GreetEveryone", varargs...)
+	ret0, _ := ret[0].(grpc.BidiStreamingClient[greetpb.GreetEveryoneRequest, greetpb.GreetEveryoneResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GreetEveryone indicates an expected call of GreetEveryone.
+func (mr *MockGreetServiceClientMockRecorder) GreetEveryone(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GreetEveryone", reflect.TypeOf((*MockGreetServiceClient)(nil).GreetEveryone), varargs...)
+}
+
+// GreetManyTimes mocks base method.
+func (m *MockGreetServiceClient) GreetManyTimes(ctx context.Context, in *greetpb.GreetManyTimesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[greetpb.GreetManyTimesResponse], error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GreetManyTimes", varargs...)
+	ret0, _ := ret[0].(grpc.ServerStreamingClient[greetpb.GreetManyTimesResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GreetManyTimes indicates an expected call of GreetManyTimes.
+func (mr *MockGreetServiceClientMockRecorder) GreetManyTimes(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GreetManyTimes", reflect.TypeOf((*MockGreetServiceClient)(nil).GreetManyTimes), varargs...)
+}
+
+// ListGreetings mocks base method.
+func (m *MockGreetServiceClient) ListGreetings(ctx context.Context, in *greetpb.ListGreetingsRequest, opts ...grpc.CallOption) (*greetpb.ListGreetingsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListGreetings", varargs...)
+	ret0, _ := ret[0].(*greetpb.ListGreetingsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGreetings indicates an expected call of ListGreetings.
+func (mr *MockGreetServiceClientMockRecorder) ListGreetings(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGreetings", reflect.TypeOf((*MockGreetServiceClient)(nil).ListGreetings), varargs...)
+}
+
+// ListTemplates mocks base method.
+func (m *MockGreetServiceClient) ListTemplates(ctx context.Context, in *greetpb.ListTemplatesRequest, opts ...grpc.CallOption) (*greetpb.ListTemplatesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTemplates", varargs...)
+	ret0, _ := ret[0].(*greetpb.ListTemplatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockGreetServiceClientMockRecorder) ListTemplates(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockGreetServiceClient)(nil).ListTemplates), varargs...)
+}
+
+// LongGreet mocks base method.
+func (m *MockGreetServiceClient) LongGreet(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[greetpb.LongGreetRequest, greetpb.LongGreetResponse], error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LongGreet", varargs...)
+	ret0, _ := ret[0].(grpc.ClientStreamingClient[greetpb.LongGreetRequest, greetpb.LongGreetResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LongGreet indicates an expected call of LongGreet.
+func (mr *MockGreetServiceClientMockRecorder) LongGreet(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LongGreet", reflect.TypeOf((*MockGreetServiceClient)(nil).LongGreet), varargs...)
+}
+
+// UpdateTemplate mocks base method.
+func (m *MockGreetServiceClient) UpdateTemplate(ctx context.Context, in *greetpb.UpdateTemplateRequest, opts ...grpc.CallOption) (*greetpb.Template, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateTemplate", varargs...)
+	ret0, _ := ret[0].(*greetpb.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockGreetServiceClientMockRecorder) UpdateTemplate(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockGreetServiceClient)(nil).UpdateTemplate), varargs...)
+}
@@ -0,0 +1,80 @@
+package mock
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"go.uber.org/mock/gomock"
+)
+
+// maximum returns the last maximum FindMaximum answers for numbers, as code
+// under test could.
+func maximum(ctx context.Context, c calculatorv2pb.CalculatorServiceClient, numbers ...float64) (float64, error) {
+	stream, err := c.FindMaximum(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, n := range numbers {
+		if err := stream.Send(&calculatorv2pb.FindMaximumRequest{Number: n}); err != nil {
+			return 0, err
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		return 0, err
+	}
+	var last float64
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return last, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		last = res.GetMaximum()
+	}
+}
+
+func TestCalculatorStreams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	stream := NewMockBidiStreamingClient[calculatorv2pb.FindMaximumRequest, calculatorv2pb.FindMaximumResponse](ctrl)
+	client := NewMockCalculatorServiceClient(ctrl)
+	client.EXPECT().FindMaximum(gomock.Any()).Return(stream, nil)
+
+	stream.EXPECT().Send(gomock.Any()).Return(nil).Times(2)
+	stream.EXPECT().CloseSend().Return(nil)
+	gomock.InOrder(
+		stream.EXPECT().Recv().Return(&calculatorv2pb.FindMaximumResponse{Maximum: 3}, nil),
+		stream.EXPECT().Recv().Return(&calculatorv2pb.FindMaximumResponse{Maximum: 9}, nil),
+		stream.EXPECT().Recv().Return(nil, io.EOF),
+	)
+
+	if got, err := maximum(context.Background(), client, 3, 9); err != nil || got != 9 {
+		t.Errorf("maximum = %v, %v, want 9", got, err)
+	}
+}
+
+func TestGreetClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	stream := NewMockServerStreamingClient[greetpb.GreetManyTimesResponse](ctrl)
+	client := NewMockGreetServiceClient(ctrl)
+	client.EXPECT().GreetManyTimes(gomock.Any(), gomock.Any()).Return(stream, nil)
+	gomock.InOrder(
+		stream.EXPECT().Recv().Return(&greetpb.GreetManyTimesResponse{Result: "Hello, Alan (1)"}, nil),
+		stream.EXPECT().Recv().Return(nil, io.EOF),
+	)
+
+	many, err := client.GreetManyTimes(context.Background(), &greetpb.GreetManyTimesRequest{Count: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res, err := many.Recv(); err != nil || res.GetResult() != "Hello, Alan (1)" {
+		t.Errorf("Recv = %v, %v", res, err)
+	}
+	if _, err := many.Recv(); err != io.EOF {
+		t.Errorf("last Recv = %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,417 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: google.golang.org/grpc (interfaces: ServerStreamingClient,ClientStreamingClient,BidiStreamingClient)
+//
+// Generated by this command:
+//
+//	mockgen -destination=streams.go -package=mock -write_package_comment=false google.golang.org/grpc ServerStreamingClient,ClientStreamingClient,BidiStreamingClient
+//
+
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	metadata "google.golang.org/grpc/metadata"
+)
+
+// MockServerStreamingClient is a mock of ServerStreamingClient interface.
+type MockServerStreamingClient[Res any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockServerStreamingClientMockRecorder[Res]
+	isgomock struct{}
+}
+
+// MockServerStreamingClientMockRecorder is the mock recorder for MockServerStreamingClient.
+type MockServerStreamingClientMockRecorder[Res any] struct {
+	mock *MockServerStreamingClient[Res]
+}
+
+// NewMockServerStreamingClient creates a new mock instance.
+func NewMockServerStreamingClient[Res any](ctrl *gomock.Controller) *MockServerStreamingClient[Res] {
+	mock := &MockServerStreamingClient[Res]{ctrl: ctrl}
+	mock.recorder = &MockServerStreamingClientMockRecorder[Res]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServerStreamingClient[Res]) EXPECT() *MockServerStreamingClientMockRecorder[Res] {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockServerStreamingClient[Res]) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockServerStreamingClientMockRecorder[Res]) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockServerStreamingClient[Res])(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockServerStreamingClient[Res]) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockServerStreamingClientMockRecorder[Res]) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockServerStreamingClient[Res])(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockServerStreamingClient[Res]) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockServerStreamingClientMockRecorder[Res]) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockServerStreamingClient[Res])(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockServerStreamingClient[Res]) Recv() (*Res, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*Res)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockServerStreamingClientMockRecorder[Res]) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockServerStreamingClient[Res])(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockServerStreamingClient[Res]) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockServerStreamingClientMockRecorder[Res]) RecvMsg(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockServerStreamingClient[Res])(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockServerStreamingClient[Res]) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockServerStreamingClientMockRecorder[Res]) SendMsg(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockServerStreamingClient[Res])(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockServerStreamingClient[Res]) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockServerStreamingClientMockRecorder[Res]) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockServerStreamingClient[Res])(nil).Trailer))
+}
+
+// MockClientStreamingClient is a mock of ClientStreamingClient interface.
+type MockClientStreamingClient[Req any, Res any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientStreamingClientMockRecorder[Req, Res]
+	isgomock struct{}
+}
+
+// MockClientStreamingClientMockRecorder is the mock recorder for MockClientStreamingClient.
+type MockClientStreamingClientMockRecorder[Req any, Res any] struct {
+	mock *MockClientStreamingClient[Req, Res]
+}
+
+// NewMockClientStreamingClient creates a new mock instance.
+func NewMockClientStreamingClient[Req any, Res any](ctrl *gomock.Controller) *MockClientStreamingClient[Req, Res] {
+	mock := &MockClientStreamingClient[Req, Res]{ctrl: ctrl}
+	mock.recorder = &MockClientStreamingClientMockRecorder[Req, Res]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClientStreamingClient[Req, Res]) EXPECT() *MockClientStreamingClientMockRecorder[Req, Res] {
+	return m.recorder
+}
+
+// CloseAndRecv mocks base method.
+func (m *MockClientStreamingClient[Req, Res]) CloseAndRecv() (*Res, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAndRecv")
+	ret0, _ := ret[0].(*Res)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAndRecv indicates an expected call of CloseAndRecv.
+func (mr *MockClientStreamingClientMockRecorder[Req, Res]) CloseAndRecv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAndRecv", reflect.TypeOf((*MockClientStreamingClient[Req, Res])(nil).CloseAndRecv))
+}
+
+// CloseSend mocks base method.
+func (m *MockClientStreamingClient[Req, Res]) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockClientStreamingClientMockRecorder[Req, Res]) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockClientStreamingClient[Req, Res])(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockClientStreamingClient[Req, Res]) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockClientStreamingClientMockRecorder[Req, Res]) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockClientStreamingClient[Req, Res])(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockClientStreamingClient[Req, Res]) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockClientStreamingClientMockRecorder[Req, Res]) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockClientStreamingClient[Req, Res])(nil).Header))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockClientStreamingClient[Req, Res]) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockClientStreamingClientMockRecorder[Req, Res]) RecvMsg(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockClientStreamingClient[Req, Res])(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockClientStreamingClient[Req, Res]) Send(arg0 *Req) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockClientStreamingClientMockRecorder[Req, Res]) Send(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockClientStreamingClient[Req, Res])(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockClientStreamingClient[Req, Res]) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockClientStreamingClientMockRecorder[Req, Res]) SendMsg(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockClientStreamingClient[Req, Res])(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockClientStreamingClient[Req, Res]) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockClientStreamingClientMockRecorder[Req, Res]) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockClientStreamingClient[Req, Res])(nil).Trailer))
+}
+
+// MockBidiStreamingClient is a mock of BidiStreamingClient interface.
+type MockBidiStreamingClient[Req any, Res any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockBidiStreamingClientMockRecorder[Req, Res]
+	isgomock struct{}
+}
+
+// MockBidiStreamingClientMockRecorder is the mock recorder for MockBidiStreamingClient.
+type MockBidiStreamingClientMockRecorder[Req any, Res any] struct {
+	mock *MockBidiStreamingClient[Req, Res]
+}
+
+// NewMockBidiStreamingClient creates a new mock instance.
+func NewMockBidiStreamingClient[Req any, Res any](ctrl *gomock.Controller) *MockBidiStreamingClient[Req, Res] {
+	mock := &MockBidiStreamingClient[Req, Res]{ctrl: ctrl}
+	mock.recorder = &MockBidiStreamingClientMockRecorder[Req, Res]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBidiStreamingClient[Req, Res]) EXPECT() *MockBidiStreamingClientMockRecorder[Req, Res] {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockBidiStreamingClient[Req, Res]) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockBidiStreamingClientMockRecorder[Req, Res]) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockBidiStreamingClient[Req, Res])(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockBidiStreamingClient[Req, Res]) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockBidiStreamingClientMockRecorder[Req, Res]) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockBidiStreamingClient[Req, Res])(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockBidiStreamingClient[Req, Res]) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockBidiStreamingClientMockRecorder[Req, Res]) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockBidiStreamingClient[Req, Res])(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockBidiStreamingClient[Req, Res]) Recv() (*Res, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*Res)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockBidiStreamingClientMockRecorder[Req, Res]) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockBidiStreamingClient[Req, Res])(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockBidiStreamingClient[Req, Res]) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockBidiStreamingClientMockRecorder[Req, Res]) RecvMsg(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockBidiStreamingClient[Req, Res])(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockBidiStreamingClient[Req, Res]) Send(arg0 *Req) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockBidiStreamingClientMockRecorder[Req, Res]) Send(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockBidiStreamingClient[Req, Res])(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockBidiStreamingClient[Req, Res]) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockBidiStreamingClientMockRecorder[Req, Res]) SendMsg(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockBidiStreamingClient[Req, Res])(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockBidiStreamingClient[Req, Res]) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockBidiStreamingClientMockRecorder[Req, Res]) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockBidiStreamingClient[Req, Res])(nil).Trailer))
+}
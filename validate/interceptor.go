@@ -0,0 +1,128 @@
+package validate
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Status converts the error returned by Message to a gRPC status:
+// InvalidArgument with a BadRequest detail listing the field violations, or
+// Internal when the rules could not be checked.
+func Status(err error) *status.Status {
+	var invalid *Error
+	if !errors.As(err, &invalid) {
+		return status.Newf(codes.Internal, "validating message: %v", err)
+	}
+	st := status.New(codes.InvalidArgument, invalid.Error())
+	details := &errdetails.BadRequest{}
+	for _, v := range invalid.Violations {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Message,
+			Reason:      v.Rule,
+		})
+	}
+	if detailed, err := st.WithDetails(details); err == nil {
+		st = detailed
+	}
+	return st
+}
+
+func request(m interface{}) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+	if err := Message(msg); err != nil {
+		return Status(err).Err()
+	}
+	return nil
+}
+
+// UnaryServerInterceptor rejects unary requests breaking their rules with
+// InvalidArgument.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := request(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor ends streams with InvalidArgument at the first
+// received message breaking its rules.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatedServerStream{ss})
+	}
+}
+
+type validatedServerStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return request(m)
+}
+
+// UnaryClientInterceptor fails calls whose request breaks its rules with
+// InvalidArgument, without sending it.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := request(req); err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor fails the sending of stream messages breaking
+// their rules with InvalidArgument. The stream stays open.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &validatedClientStream{cs}, nil
+	}
+}
+
+type validatedClientStream struct {
+	grpc.ClientStream
+}
+
+func (s *validatedClientStream) SendMsg(m interface{}) error {
+	if err := request(m); err != nil {
+		return err
+	}
+	return s.ClientStream.SendMsg(m)
+}
+
+// ServerOptions returns the interceptors validating the requests received
+// by a server.
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor()),
+	}
+}
+
+// DialOptions returns the interceptors validating the requests sent by a
+// client.
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(StreamClientInterceptor()),
+	}
+}
@@ -0,0 +1,67 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	validatepb "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestStatus(t *testing.T) {
+	st := Status(&Error{Violations: []Violation{
+		{Field: "greeting.first_name", Rule: "required", Message: "value is required"},
+		{Field: "locale", Rule: "string.max_len", Message: "value length must be at most 35 characters"},
+	}})
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("code = %v, want InvalidArgument", st.Code())
+	}
+	details := st.Details()
+	if len(details) != 1 {
+		t.Fatalf("details = %v, want a BadRequest", details)
+	}
+	bad, ok := details[0].(*errdetails.BadRequest)
+	if !ok || len(bad.GetFieldViolations()) != 2 {
+		t.Fatalf("details = %v, want a BadRequest with 2 violations", details)
+	}
+	if v := bad.GetFieldViolations()[1]; v.GetField() != "locale" || v.GetReason() != "string.max_len" {
+		t.Errorf("violation = %v, want locale breaking string.max_len", v)
+	}
+
+	if st := Status(errors.New("unsupported validation rule")); st.Code() != codes.Internal {
+		t.Errorf("code of an unsupported rule = %v, want Internal", st.Code())
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	intercept := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Call"}
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return req, nil
+	}
+
+	m := newMessage(t, message(field("s", descriptorpb.FieldDescriptorProto_TYPE_STRING, required)))
+	if _, err := intercept(context.Background(), m, info, handler); status.Code(err) != codes.InvalidArgument || called {
+		t.Errorf("invalid request = %v, called %v, want InvalidArgument without calling the handler", err, called)
+	}
+
+	set(m, "s", protoreflect.ValueOfString("x"))
+	if _, err := intercept(context.Background(), m, info, handler); err != nil || !called {
+		t.Errorf("valid request = %v, called %v, want the handler called", err, called)
+	}
+
+	unsupported := newMessage(t, message(field("s", descriptorpb.FieldDescriptorProto_TYPE_STRING,
+		stringRules(validatepb.StringRules_builder{Email: proto.Bool(true)}))))
+	if _, err := intercept(context.Background(), unsupported, info, handler); status.Code(err) != codes.Internal {
+		t.Errorf("request with an unsupported rule = %v, want Internal", err)
+	}
+}
@@ -0,0 +1,263 @@
+// Package validate checks messages against the protovalidate rules declared
+// on their fields, such as (buf.validate.field).string.max_len, and enforces
+// them in gRPC interceptors.
+//
+// Only the standard rules the protos use are supported: required on fields
+// and oneofs, string.min_len, string.max_len and string.pattern,
+// float.finite and double.finite, and enum.defined_only. Message fields are
+// checked recursively when set, and so are the messages in repeated and map
+// fields. Other rules, including CEL expressions, and rules of another type
+// than their field make validation fail with an error rather than being
+// silently ignored.
+package validate
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	validatepb "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Violation is a field breaking one of its rules.
+type Violation struct {
+	// Field is the path of the field, e.g. greeting.first_name.
+	Field string
+	// Rule identifies the rule, e.g. string.max_len.
+	Rule string
+	// Message describes the violation.
+	Message string
+}
+
+// Error lists the violations found in a message.
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Field + ": " + v.Message
+	}
+	return "invalid message: " + strings.Join(parts, "; ")
+}
+
+// Message checks m against the rules of its fields. It returns an *Error
+// listing the violations, or another error when m declares a rule this
+// package does not support.
+func Message(m proto.Message) error {
+	var violations []Violation
+	if err := check(m.ProtoReflect(), "", &violations); err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return &Error{Violations: violations}
+	}
+	return nil
+}
+
+func check(m protoreflect.Message, prefix string, violations *[]Violation) error {
+	desc := m.Descriptor()
+	if rules, _ := proto.GetExtension(desc.Options(), validatepb.E_Message).(*validatepb.MessageRules); rules != nil {
+		if err := supported(rules.ProtoReflect()); err != nil {
+			return fmt.Errorf("%s: %v", desc.FullName(), err)
+		}
+	}
+
+	oneofs := desc.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		rules, _ := proto.GetExtension(od.Options(), validatepb.E_Oneof).(*validatepb.OneofRules)
+		if rules == nil {
+			continue
+		}
+		path := prefix + string(od.Name())
+		if err := supported(rules.ProtoReflect(), "required"); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		if rules.GetRequired() && m.WhichOneof(od) == nil {
+			*violations = append(*violations, Violation{Field: path, Rule: "required", Message: "exactly one field is required in oneof"})
+		}
+	}
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + string(fd.Name())
+
+		rules, _ := proto.GetExtension(fd.Options(), validatepb.E_Field).(*validatepb.FieldRules)
+		if rules != nil {
+			if err := checkField(m, fd, path, rules, violations); err != nil {
+				return err
+			}
+		}
+
+		if err := checkNested(m, fd, path, violations); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkNested checks the messages held by field fd of m, if any.
+func checkNested(m protoreflect.Message, fd protoreflect.FieldDescriptor, path string, violations *[]Violation) error {
+	if !m.Has(fd) {
+		return nil
+	}
+	switch {
+	case fd.IsList():
+		if fd.Message() == nil {
+			return nil
+		}
+		list := m.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			if err := check(list.Get(i).Message(), fmt.Sprintf("%s[%d].", path, i), violations); err != nil {
+				return err
+			}
+		}
+	case fd.IsMap():
+		if fd.MapValue().Message() == nil {
+			return nil
+		}
+		var err error
+		m.Get(fd).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			err = check(v.Message(), fmt.Sprintf("%s[%q].", path, k.String()), violations)
+			return err == nil
+		})
+		return err
+	case fd.Message() != nil:
+		return check(m.Get(fd).Message(), path+".", violations)
+	}
+	return nil
+}
+
+func checkField(m protoreflect.Message, fd protoreflect.FieldDescriptor, path string, rules *validatepb.FieldRules, violations *[]Violation) error {
+	if err := supported(rules.ProtoReflect(), "required", "string", "float", "double", "enum"); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	add := func(rule, format string, args ...interface{}) {
+		*violations = append(*violations, Violation{Field: path, Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if rules.GetRequired() && !m.Has(fd) {
+		add("required", "value is required")
+		return nil
+	}
+	if fd.IsList() || fd.IsMap() {
+		if rules.GetType() != nil {
+			return fmt.Errorf("%s: rules on repeated fields are not supported", path)
+		}
+		return nil
+	}
+	if err := matchesKind(rules, fd); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	value := m.Get(fd)
+
+	switch {
+	case rules.GetString() != nil:
+		r := rules.GetString()
+		if err := supported(r.ProtoReflect(), "min_len", "max_len", "pattern"); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		n := uint64(utf8.RuneCountInString(value.String()))
+		if r.MinLen != nil && n < r.GetMinLen() {
+			add("string.min_len", "value length must be at least %d characters", r.GetMinLen())
+		}
+		if r.MaxLen != nil && n > r.GetMaxLen() {
+			add("string.max_len", "value length must be at most %d characters", r.GetMaxLen())
+		}
+		if r.Pattern != nil {
+			re, err := compile(r.GetPattern())
+			if err != nil {
+				return fmt.Errorf("%s: %v", path, err)
+			}
+			if !re.MatchString(value.String()) {
+				add("string.pattern", "value does not match regex pattern `%s`", r.GetPattern())
+			}
+		}
+	case rules.GetFloat() != nil:
+		if err := supported(rules.GetFloat().ProtoReflect(), "finite"); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		if rules.GetFloat().GetFinite() && !finite(value.Float()) {
+			add("float.finite", "value must be finite")
+		}
+	case rules.GetDouble() != nil:
+		if err := supported(rules.GetDouble().ProtoReflect(), "finite"); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		if rules.GetDouble().GetFinite() && !finite(value.Float()) {
+			add("double.finite", "value must be finite")
+		}
+	case rules.GetEnum() != nil:
+		if err := supported(rules.GetEnum().ProtoReflect(), "defined_only"); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		if rules.GetEnum().GetDefinedOnly() && fd.Enum().Values().ByNumber(value.Enum()) == nil {
+			add("enum.defined_only", "value must be one of the defined enum values")
+		}
+	}
+	return nil
+}
+
+// matchesKind reports an error when the type rules do not apply to the kind
+// of field fd, e.g. string rules on an int32 field.
+func matchesKind(rules *validatepb.FieldRules, fd protoreflect.FieldDescriptor) error {
+	var want protoreflect.Kind
+	switch {
+	case rules.GetString() != nil:
+		want = protoreflect.StringKind
+	case rules.GetFloat() != nil:
+		want = protoreflect.FloatKind
+	case rules.GetDouble() != nil:
+		want = protoreflect.DoubleKind
+	case rules.GetEnum() != nil:
+		want = protoreflect.EnumKind
+	default:
+		return nil
+	}
+	if fd.Kind() != want {
+		return fmt.Errorf("%s rules do not apply to a %s field", want, fd.Kind())
+	}
+	return nil
+}
+
+// supported reports an error when rules sets a field not named in names.
+func supported(rules protoreflect.Message, names ...string) error {
+	var err error
+	rules.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		for _, name := range names {
+			if string(fd.Name()) == name {
+				return true
+			}
+		}
+		err = fmt.Errorf("unsupported validation rule %s", fd.FullName())
+		return false
+	})
+	return err
+}
+
+// patterns caches the compiled string.pattern rules.
+var patterns sync.Map
+
+func compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(pattern, re)
+	return re, nil
+}
+
+func finite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
@@ -0,0 +1,270 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	validatepb "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// The tests build their messages at run time, so that every rule, and the
+// rules the package does not support, can be declared on a field of its
+// own.
+
+// field returns a field of type typ with the given rules, which may be nil.
+// Enum fields are of type Color and message fields of type Inner.
+func field(name string, typ descriptorpb.FieldDescriptorProto_Type, rules *validatepb.FieldRules) *descriptorpb.FieldDescriptorProto {
+	fd := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Type:     typ.Enum(),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	switch typ {
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		fd.TypeName = proto.String(".test.Color")
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
+		fd.TypeName = proto.String(".test.Inner")
+	}
+	if rules != nil {
+		fd.Options = &descriptorpb.FieldOptions{}
+		proto.SetExtension(fd.Options, validatepb.E_Field, rules)
+	}
+	return fd
+}
+
+// repeated makes fd repeated.
+func repeated(fd *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	fd.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return fd
+}
+
+// message returns the Test message declaring fields, numbered in order.
+func message(fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+	for i, fd := range fields {
+		fd.Number = proto.Int32(int32(i + 1))
+	}
+	return &descriptorpb.DescriptorProto{Name: proto.String("Test"), Field: fields}
+}
+
+// withMap adds a map<string, Inner> field named inners to msg.
+func withMap(msg *descriptorpb.DescriptorProto) *descriptorpb.DescriptorProto {
+	msg.NestedType = append(msg.NestedType, &descriptorpb.DescriptorProto{
+		Name: proto.String("InnersEntry"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			{Name: proto.String("key"), JsonName: proto.String("key"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+			{Name: proto.String("value"), JsonName: proto.String("value"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), TypeName: proto.String(".test.Inner")},
+		},
+		Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+	})
+	msg.Field = append(msg.Field, &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("inners"),
+		JsonName: proto.String("inners"),
+		Number:   proto.Int32(int32(len(msg.Field) + 1)),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+		TypeName: proto.String(".test.Test.InnersEntry"),
+	})
+	return msg
+}
+
+// withOneof puts the first field of msg in a oneof named choice with the
+// given rules.
+func withOneof(msg *descriptorpb.DescriptorProto, rules *validatepb.OneofRules) *descriptorpb.DescriptorProto {
+	od := &descriptorpb.OneofDescriptorProto{Name: proto.String("choice"), Options: &descriptorpb.OneofOptions{}}
+	proto.SetExtension(od.Options, validatepb.E_Oneof, rules)
+	msg.OneofDecl = append(msg.OneofDecl, od)
+	msg.Field[0].OneofIndex = proto.Int32(0)
+	return msg
+}
+
+// withRules declares message rules on msg.
+func withRules(msg *descriptorpb.DescriptorProto, rules *validatepb.MessageRules) *descriptorpb.DescriptorProto {
+	msg.Options = &descriptorpb.MessageOptions{}
+	proto.SetExtension(msg.Options, validatepb.E_Message, rules)
+	return msg
+}
+
+// newMessage returns an empty message of type msg, declared in a proto3
+// file next to the enum Color and the message Inner, whose name is
+// required.
+func newMessage(t *testing.T, msg *descriptorpb.DescriptorProto) *dynamicpb.Message {
+	t.Helper()
+	inner := message(field("name", descriptorpb.FieldDescriptorProto_TYPE_STRING, required))
+	inner.Name = proto.String("Inner")
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String(t.Name() + ".proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Color"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("COLOR_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("COLOR_RED"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{inner, msg},
+	}
+	fd, err := protodesc.NewFile(file, nil)
+	if err != nil {
+		t.Fatalf("building the test messages: %v", err)
+	}
+	return dynamicpb.NewMessage(fd.Messages().ByName("Test"))
+}
+
+// set sets field name of m to v.
+func set(m protoreflect.Message, name string, v protoreflect.Value) {
+	m.Set(m.Descriptor().Fields().ByName(protoreflect.Name(name)), v)
+}
+
+// newInner returns an Inner message with the given name, for a field of m.
+func newInner(m protoreflect.Message, field, name string) protoreflect.Message {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(field))
+	if fd.IsMap() {
+		fd = fd.MapValue()
+	}
+	inner := dynamicpb.NewMessage(fd.Message())
+	if name != "" {
+		set(inner, "name", protoreflect.ValueOfString(name))
+	}
+	return inner
+}
+
+var required = validatepb.FieldRules_builder{Required: proto.Bool(true)}.Build()
+
+func stringRules(r validatepb.StringRules_builder) *validatepb.FieldRules {
+	return validatepb.FieldRules_builder{String: r.Build()}.Build()
+}
+
+func TestMessage(t *testing.T) {
+	const (
+		stringType  = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		floatType   = descriptorpb.FieldDescriptorProto_TYPE_FLOAT
+		doubleType  = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE
+		enumType    = descriptorpb.FieldDescriptorProto_TYPE_ENUM
+		messageType = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	)
+	str := protoreflect.ValueOfString
+	tests := []struct {
+		name string
+		msg  *descriptorpb.DescriptorProto
+		set  func(m protoreflect.Message)
+		// want lists the violations as field: rule.
+		want []string
+	}{
+		{"required missing", message(field("s", stringType, required)), nil, []string{"s: required"}},
+		{"required", message(field("s", stringType, required)), func(m protoreflect.Message) { set(m, "s", str("x")) }, nil},
+		{"required message missing", message(field("inner", messageType, required)), nil, []string{"inner: required"}},
+		{"required message", message(field("inner", messageType, required)), func(m protoreflect.Message) {
+			set(m, "inner", protoreflect.ValueOfMessage(newInner(m, "inner", "x")))
+		}, nil},
+		{"min_len", message(field("s", stringType, stringRules(validatepb.StringRules_builder{MinLen: proto.Uint64(2)}))),
+			func(m protoreflect.Message) { set(m, "s", str("a")) }, []string{"s: string.min_len"}},
+		{"max_len", message(field("s", stringType, stringRules(validatepb.StringRules_builder{MaxLen: proto.Uint64(3)}))),
+			func(m protoreflect.Message) { set(m, "s", str("abcd")) }, []string{"s: string.max_len"}},
+		{"lengths count characters", message(field("s", stringType, stringRules(validatepb.StringRules_builder{MinLen: proto.Uint64(2), MaxLen: proto.Uint64(2)}))),
+			func(m protoreflect.Message) { set(m, "s", str("ñé")) }, nil},
+		{"pattern", message(field("s", stringType, stringRules(validatepb.StringRules_builder{Pattern: proto.String("^[a-z]+$")}))),
+			func(m protoreflect.Message) { set(m, "s", str("A1")) }, []string{"s: string.pattern"}},
+		{"pattern matched", message(field("s", stringType, stringRules(validatepb.StringRules_builder{Pattern: proto.String("^[a-z]+$")}))),
+			func(m protoreflect.Message) { set(m, "s", str("ab")) }, nil},
+		{"float NaN", message(field("f", floatType, validatepb.FieldRules_builder{Float: validatepb.FloatRules_builder{Finite: proto.Bool(true)}.Build()}.Build())),
+			func(m protoreflect.Message) { set(m, "f", protoreflect.ValueOfFloat32(float32(math.NaN()))) }, []string{"f: float.finite"}},
+		{"float finite", message(field("f", floatType, validatepb.FieldRules_builder{Float: validatepb.FloatRules_builder{Finite: proto.Bool(true)}.Build()}.Build())),
+			func(m protoreflect.Message) { set(m, "f", protoreflect.ValueOfFloat32(1.5)) }, nil},
+		{"double infinity", message(field("d", doubleType, validatepb.FieldRules_builder{Double: validatepb.DoubleRules_builder{Finite: proto.Bool(true)}.Build()}.Build())),
+			func(m protoreflect.Message) { set(m, "d", protoreflect.ValueOfFloat64(math.Inf(-1))) }, []string{"d: double.finite"}},
+		{"double finite", message(field("d", doubleType, validatepb.FieldRules_builder{Double: validatepb.DoubleRules_builder{Finite: proto.Bool(true)}.Build()}.Build())),
+			func(m protoreflect.Message) { set(m, "d", protoreflect.ValueOfFloat64(math.MaxFloat64)) }, nil},
+		{"enum undefined", message(field("e", enumType, validatepb.FieldRules_builder{Enum: validatepb.EnumRules_builder{DefinedOnly: proto.Bool(true)}.Build()}.Build())),
+			func(m protoreflect.Message) { set(m, "e", protoreflect.ValueOfEnum(7)) }, []string{"e: enum.defined_only"}},
+		{"enum defined", message(field("e", enumType, validatepb.FieldRules_builder{Enum: validatepb.EnumRules_builder{DefinedOnly: proto.Bool(true)}.Build()}.Build())),
+			func(m protoreflect.Message) { set(m, "e", protoreflect.ValueOfEnum(1)) }, nil},
+		{"nested message", message(field("inner", messageType, nil)), func(m protoreflect.Message) {
+			set(m, "inner", protoreflect.ValueOfMessage(newInner(m, "inner", "")))
+		}, []string{"inner.name: required"}},
+		{"repeated messages", message(repeated(field("inners", messageType, nil))), func(m protoreflect.Message) {
+			list := m.Mutable(m.Descriptor().Fields().ByName("inners")).List()
+			list.Append(protoreflect.ValueOfMessage(newInner(m, "inners", "x")))
+			list.Append(protoreflect.ValueOfMessage(newInner(m, "inners", "")))
+		}, []string{"inners[1].name: required"}},
+		{"map messages", withMap(message()), func(m protoreflect.Message) {
+			inners := m.Mutable(m.Descriptor().Fields().ByName("inners")).Map()
+			inners.Set(protoreflect.ValueOfString("k").MapKey(), protoreflect.ValueOfMessage(newInner(m, "inners", "")))
+		}, []string{`inners["k"].name: required`}},
+		{"oneof missing", withOneof(message(field("a", stringType, nil), field("b", stringType, nil)), validatepb.OneofRules_builder{Required: proto.Bool(true)}.Build()),
+			nil, []string{"choice: required"}},
+		{"oneof", withOneof(message(field("a", stringType, nil), field("b", stringType, nil)), validatepb.OneofRules_builder{Required: proto.Bool(true)}.Build()),
+			func(m protoreflect.Message) { set(m, "a", str("")) }, nil},
+		{"several violations", message(field("s", stringType, required), field("d", doubleType, validatepb.FieldRules_builder{Double: validatepb.DoubleRules_builder{Finite: proto.Bool(true)}.Build()}.Build())),
+			func(m protoreflect.Message) { set(m, "d", protoreflect.ValueOfFloat64(math.NaN())) }, []string{"s: required", "d: double.finite"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMessage(t, tt.msg)
+			if tt.set != nil {
+				tt.set(m)
+			}
+			err := Message(m)
+			var got []string
+			var invalid *Error
+			if errors.As(err, &invalid) {
+				for _, v := range invalid.Violations {
+					got = append(got, v.Field+": "+v.Rule)
+				}
+			} else if err != nil {
+				t.Fatalf("Message = %v, want violations", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageUnsupported(t *testing.T) {
+	cel := []*validatepb.Rule{validatepb.Rule_builder{Id: proto.String("even"), Expression: proto.String("this % 2 == 0")}.Build()}
+	tests := []struct {
+		name string
+		msg  *descriptorpb.DescriptorProto
+	}{
+		{"int32 rules", message(field("i", descriptorpb.FieldDescriptorProto_TYPE_INT32,
+			validatepb.FieldRules_builder{Int32: validatepb.Int32Rules_builder{Gt: proto.Int32(0)}.Build()}.Build()))},
+		{"string rule", message(field("s", descriptorpb.FieldDescriptorProto_TYPE_STRING,
+			stringRules(validatepb.StringRules_builder{Email: proto.Bool(true)})))},
+		{"invalid pattern", message(field("s", descriptorpb.FieldDescriptorProto_TYPE_STRING,
+			stringRules(validatepb.StringRules_builder{Pattern: proto.String("(")})))},
+		{"float rule", message(field("f", descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+			validatepb.FieldRules_builder{Float: validatepb.FloatRules_builder{Gt: proto.Float32(0)}.Build()}.Build()))},
+		{"enum rule", message(field("e", descriptorpb.FieldDescriptorProto_TYPE_ENUM,
+			validatepb.FieldRules_builder{Enum: validatepb.EnumRules_builder{In: []int32{1}}.Build()}.Build()))},
+		{"field CEL", message(field("s", descriptorpb.FieldDescriptorProto_TYPE_STRING, validatepb.FieldRules_builder{Cel: cel}.Build()))},
+		{"ignore", message(field("s", descriptorpb.FieldDescriptorProto_TYPE_STRING,
+			validatepb.FieldRules_builder{Ignore: validatepb.Ignore_IGNORE_ALWAYS.Enum()}.Build()))},
+		{"message CEL", withRules(message(field("s", descriptorpb.FieldDescriptorProto_TYPE_STRING, nil)), validatepb.MessageRules_builder{Cel: cel}.Build())},
+		{"repeated rules", message(repeated(field("s", descriptorpb.FieldDescriptorProto_TYPE_STRING,
+			validatepb.FieldRules_builder{Repeated: validatepb.RepeatedRules_builder{MinItems: proto.Uint64(1)}.Build()}.Build())))},
+		{"string rules on repeated field", message(repeated(field("s", descriptorpb.FieldDescriptorProto_TYPE_STRING,
+			stringRules(validatepb.StringRules_builder{MaxLen: proto.Uint64(1)}))))},
+		{"string rules on int32 field", message(field("i", descriptorpb.FieldDescriptorProto_TYPE_INT32,
+			stringRules(validatepb.StringRules_builder{MaxLen: proto.Uint64(1)})))},
+		{"double rules on float field", message(field("f", descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+			validatepb.FieldRules_builder{Double: validatepb.DoubleRules_builder{Finite: proto.Bool(true)}.Build()}.Build()))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Message(newMessage(t, tt.msg))
+			var invalid *Error
+			if err == nil || errors.As(err, &invalid) {
+				t.Errorf("Message = %v, want an unsupported rule error", err)
+			}
+		})
+	}
+}
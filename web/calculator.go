@@ -0,0 +1,86 @@
+package web
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorpb"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorpb/calculatorpbconnect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// CalculatorService serves calculator.v1, and its FindMaximum over
+// WebSocket.
+var CalculatorService = Service{
+	Connect: func(conn *grpc.ClientConn) (string, http.Handler) {
+		return calculatorpbconnect.NewCalculatorServiceHandler(&calculatorBridge{client: calculatorpb.NewCalculatorServiceClient(conn)})
+	},
+	Sockets: []Socket{{
+		Method:      "/calculator.v1.CalculatorService/FindMaximum",
+		NewRequest:  func() proto.Message { return &calculatorpb.FindMaximumRequest{} },
+		NewResponse: func() proto.Message { return &calculatorpb.FindMaximumResponse{} },
+	}},
+}
+
+// calculatorBridge implements the Connect CalculatorService by calling the
+// gRPC one.
+type calculatorBridge struct {
+	client calculatorpb.CalculatorServiceClient
+}
+
+func (b *calculatorBridge) Calculate(ctx context.Context, req *connect.Request[calculatorpb.OperationRequest]) (*connect.Response[calculatorpb.OperationResponse], error) {
+	var trailer metadata.MD
+	res, err := b.client.Calculate(outgoing(ctx, req.Header(), req.Peer().Addr), req.Msg, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, connectError(err, trailer)
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (b *calculatorBridge) PrimeNumberDecomposition(ctx context.Context, req *connect.Request[calculatorpb.PrimeNumberDecompositionRequest], stream *connect.ServerStream[calculatorpb.PrimeNumberDecompositionResponse]) error {
+	grpcStream, err := b.client.PrimeNumberDecomposition(outgoing(ctx, req.Header(), req.Peer().Addr), req.Msg)
+	if err != nil {
+		return connectError(err, nil)
+	}
+	return forwardResponses[calculatorpb.PrimeNumberDecompositionResponse](grpcStream, stream.Send)
+}
+
+func (b *calculatorBridge) ComputeAverage(ctx context.Context, stream *connect.ClientStream[calculatorpb.ComputeAverageRequest]) (*connect.Response[calculatorpb.ComputeAverageResponse], error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	grpcStream, err := b.client.ComputeAverage(outgoing(ctx, stream.RequestHeader(), stream.Peer().Addr))
+	if err != nil {
+		return nil, connectError(err, nil)
+	}
+	forwardRequests[calculatorpb.ComputeAverageRequest](clientReceiver(stream), grpcStream, cancel)
+	res, err := grpcStream.CloseAndRecv()
+	if err != nil {
+		return nil, connectError(err, grpcStream.Trailer())
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (b *calculatorBridge) FindMaximum(ctx context.Context, stream *connect.BidiStream[calculatorpb.FindMaximumRequest, calculatorpb.FindMaximumResponse]) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	grpcStream, err := b.client.FindMaximum(outgoing(ctx, stream.RequestHeader(), stream.Peer().Addr))
+	if err != nil {
+		return connectError(err, nil)
+	}
+	go forwardRequests[calculatorpb.FindMaximumRequest](stream.Receive, grpcStream, cancel)
+	return forwardResponses[calculatorpb.FindMaximumResponse](grpcStream, stream.Send)
+}
+
+func (b *calculatorBridge) GetAggregation(ctx context.Context, req *connect.Request[calculatorpb.GetAggregationRequest]) (*connect.Response[calculatorpb.Aggregation], error) {
+	var trailer metadata.MD
+	res, err := b.client.GetAggregation(outgoing(ctx, req.Header(), req.Peer().Addr), req.Msg, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, connectError(err, trailer)
+	}
+	return connect.NewResponse(res), nil
+}
@@ -0,0 +1,86 @@
+package web
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb"
+	"github.com/AlanKev117/go-grpc/calculator/calculatorv2pb/calculatorv2pbconnect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// CalculatorV2Service serves calculator.v2, and its FindMaximum over
+// WebSocket.
+var CalculatorV2Service = Service{
+	Connect: func(conn *grpc.ClientConn) (string, http.Handler) {
+		return calculatorv2pbconnect.NewCalculatorServiceHandler(&calculatorV2Bridge{client: calculatorv2pb.NewCalculatorServiceClient(conn)})
+	},
+	Sockets: []Socket{{
+		Method:      "/calculator.v2.CalculatorService/FindMaximum",
+		NewRequest:  func() proto.Message { return &calculatorv2pb.FindMaximumRequest{} },
+		NewResponse: func() proto.Message { return &calculatorv2pb.FindMaximumResponse{} },
+	}},
+}
+
+// calculatorV2Bridge implements the Connect calculator.v2 service by calling
+// the gRPC one.
+type calculatorV2Bridge struct {
+	client calculatorv2pb.CalculatorServiceClient
+}
+
+func (b *calculatorV2Bridge) Calculate(ctx context.Context, req *connect.Request[calculatorv2pb.OperationRequest]) (*connect.Response[calculatorv2pb.OperationResponse], error) {
+	var trailer metadata.MD
+	res, err := b.client.Calculate(outgoing(ctx, req.Header(), req.Peer().Addr), req.Msg, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, connectError(err, trailer)
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (b *calculatorV2Bridge) PrimeNumberDecomposition(ctx context.Context, req *connect.Request[calculatorv2pb.PrimeNumberDecompositionRequest], stream *connect.ServerStream[calculatorv2pb.PrimeNumberDecompositionResponse]) error {
+	grpcStream, err := b.client.PrimeNumberDecomposition(outgoing(ctx, req.Header(), req.Peer().Addr), req.Msg)
+	if err != nil {
+		return connectError(err, nil)
+	}
+	return forwardResponses[calculatorv2pb.PrimeNumberDecompositionResponse](grpcStream, stream.Send)
+}
+
+func (b *calculatorV2Bridge) ComputeAverage(ctx context.Context, stream *connect.ClientStream[calculatorv2pb.ComputeAverageRequest]) (*connect.Response[calculatorv2pb.ComputeAverageResponse], error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	grpcStream, err := b.client.ComputeAverage(outgoing(ctx, stream.RequestHeader(), stream.Peer().Addr))
+	if err != nil {
+		return nil, connectError(err, nil)
+	}
+	forwardRequests[calculatorv2pb.ComputeAverageRequest](clientReceiver(stream), grpcStream, cancel)
+	res, err := grpcStream.CloseAndRecv()
+	if err != nil {
+		return nil, connectError(err, grpcStream.Trailer())
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (b *calculatorV2Bridge) FindMaximum(ctx context.Context, stream *connect.BidiStream[calculatorv2pb.FindMaximumRequest, calculatorv2pb.FindMaximumResponse]) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	grpcStream, err := b.client.FindMaximum(outgoing(ctx, stream.RequestHeader(), stream.Peer().Addr))
+	if err != nil {
+		return connectError(err, nil)
+	}
+	go forwardRequests[calculatorv2pb.FindMaximumRequest](stream.Receive, grpcStream, cancel)
+	return forwardResponses[calculatorv2pb.FindMaximumResponse](grpcStream, stream.Send)
+}
+
+func (b *calculatorV2Bridge) GetAggregation(ctx context.Context, req *connect.Request[calculatorv2pb.GetAggregationRequest]) (*connect.Response[calculatorv2pb.Aggregation], error) {
+	var trailer metadata.MD
+	res, err := b.client.GetAggregation(outgoing(ctx, req.Header(), req.Peer().Addr), req.Msg, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, connectError(err, trailer)
+	}
+	return connect.NewResponse(res), nil
+}
@@ -0,0 +1,72 @@
+package web
+
+import (
+	"errors"
+	"io"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc/metadata"
+)
+
+// grpcReceiver is the receiving side of a gRPC client stream.
+type grpcReceiver[T any] interface {
+	Recv() (*T, error)
+	Trailer() metadata.MD
+}
+
+// grpcSender is the sending side of a gRPC client stream.
+type grpcSender[T any] interface {
+	Send(*T) error
+	CloseSend() error
+}
+
+// forwardResponses sends the responses received from a gRPC stream until it
+// ends.
+func forwardResponses[T any](from grpcReceiver[T], send func(*T) error) error {
+	for {
+		res, err := from.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return connectError(err, from.Trailer())
+		}
+		if err := send(res); err != nil {
+			return err
+		}
+	}
+}
+
+// forwardRequests sends the requests received from a web stream to a gRPC
+// stream, and half-closes it once the web client is done. When the web
+// stream breaks, cancel is called to abort the gRPC call.
+func forwardRequests[T any](receive func() (*T, error), to grpcSender[T], cancel func()) {
+	for {
+		req, err := receive()
+		if errors.Is(err, io.EOF) {
+			to.CloseSend()
+			return
+		}
+		if err != nil {
+			cancel()
+			return
+		}
+		if err := to.Send(req); err != nil {
+			// The gRPC side reports why when its response is received.
+			return
+		}
+	}
+}
+
+// clientReceiver adapts a Connect client stream to forwardRequests.
+func clientReceiver[T any](s *connect.ClientStream[T]) func() (*T, error) {
+	return func() (*T, error) {
+		if s.Receive() {
+			return s.Msg(), nil
+		}
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+}
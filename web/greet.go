@@ -0,0 +1,111 @@
+package web
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/AlanKev117/go-grpc/greet/greetpb/greetpbconnect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// GreetService serves GreetService, and GreetEveryone over WebSocket.
+var GreetService = Service{
+	Connect: func(conn *grpc.ClientConn) (string, http.Handler) {
+		return greetpbconnect.NewGreetServiceHandler(&greetBridge{client: greetpb.NewGreetServiceClient(conn)})
+	},
+	Sockets: []Socket{{
+		Method:      "/greet.v1.GreetService/GreetEveryone",
+		NewRequest:  func() proto.Message { return &greetpb.GreetEveryoneRequest{} },
+		NewResponse: func() proto.Message { return &greetpb.GreetEveryoneResponse{} },
+	}},
+}
+
+// greetBridge implements the Connect GreetService by calling the gRPC one.
+type greetBridge struct {
+	client greetpb.GreetServiceClient
+}
+
+func (b *greetBridge) Greet(ctx context.Context, req *connect.Request[greetpb.GreetRequest]) (*connect.Response[greetpb.GreetResponse], error) {
+	var trailer metadata.MD
+	res, err := b.client.Greet(outgoing(ctx, req.Header(), req.Peer().Addr), req.Msg, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, connectError(err, trailer)
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (b *greetBridge) GreetManyTimes(ctx context.Context, req *connect.Request[greetpb.GreetManyTimesRequest], stream *connect.ServerStream[greetpb.GreetManyTimesResponse]) error {
+	grpcStream, err := b.client.GreetManyTimes(outgoing(ctx, req.Header(), req.Peer().Addr), req.Msg)
+	if err != nil {
+		return connectError(err, nil)
+	}
+	return forwardResponses[greetpb.GreetManyTimesResponse](grpcStream, stream.Send)
+}
+
+func (b *greetBridge) LongGreet(ctx context.Context, stream *connect.ClientStream[greetpb.LongGreetRequest]) (*connect.Response[greetpb.LongGreetResponse], error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	grpcStream, err := b.client.LongGreet(outgoing(ctx, stream.RequestHeader(), stream.Peer().Addr))
+	if err != nil {
+		return nil, connectError(err, nil)
+	}
+	forwardRequests[greetpb.LongGreetRequest](clientReceiver(stream), grpcStream, cancel)
+	res, err := grpcStream.CloseAndRecv()
+	if err != nil {
+		return nil, connectError(err, grpcStream.Trailer())
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (b *greetBridge) GreetEveryone(ctx context.Context, stream *connect.BidiStream[greetpb.GreetEveryoneRequest, greetpb.GreetEveryoneResponse]) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	grpcStream, err := b.client.GreetEveryone(outgoing(ctx, stream.RequestHeader(), stream.Peer().Addr))
+	if err != nil {
+		return connectError(err, nil)
+	}
+	go forwardRequests[greetpb.GreetEveryoneRequest](stream.Receive, grpcStream, cancel)
+	return forwardResponses[greetpb.GreetEveryoneResponse](grpcStream, stream.Send)
+}
+
+func (b *greetBridge) ListTemplates(ctx context.Context, req *connect.Request[greetpb.ListTemplatesRequest]) (*connect.Response[greetpb.ListTemplatesResponse], error) {
+	var trailer metadata.MD
+	res, err := b.client.ListTemplates(outgoing(ctx, req.Header(), req.Peer().Addr), req.Msg, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, connectError(err, trailer)
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (b *greetBridge) CreateTemplate(ctx context.Context, req *connect.Request[greetpb.CreateTemplateRequest]) (*connect.Response[greetpb.Template], error) {
+	var trailer metadata.MD
+	res, err := b.client.CreateTemplate(outgoing(ctx, req.Header(), req.Peer().Addr), req.Msg, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, connectError(err, trailer)
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (b *greetBridge) UpdateTemplate(ctx context.Context, req *connect.Request[greetpb.UpdateTemplateRequest]) (*connect.Response[greetpb.Template], error) {
+	var trailer metadata.MD
+	res, err := b.client.UpdateTemplate(outgoing(ctx, req.Header(), req.Peer().Addr), req.Msg, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, connectError(err, trailer)
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (b *greetBridge) ListGreetings(ctx context.Context, req *connect.Request[greetpb.ListGreetingsRequest]) (*connect.Response[greetpb.ListGreetingsResponse], error) {
+	var trailer metadata.MD
+	res, err := b.client.ListGreetings(outgoing(ctx, req.Header(), req.Peer().Addr), req.Msg, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, connectError(err, trailer)
+	}
+	return connect.NewResponse(res), nil
+}
@@ -0,0 +1,222 @@
+package web
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// SocketPrefix is the path prefix of the WebSocket endpoints, followed by the
+// full method name, e.g. /ws/greet.v1.GreetService/GreetEveryone.
+const SocketPrefix = "/ws"
+
+// CloseStatusBase is added to the gRPC status code of a call to get the
+// WebSocket close code ending the connection, so 4005 means NOT_FOUND. Calls
+// ending successfully close with 1000.
+const CloseStatusBase = 4000
+
+const (
+	// maxFrameSize is the largest request frame accepted.
+	maxFrameSize = 64 << 10
+	// writeTimeout bounds the time to send a frame to a slow client.
+	writeTimeout = 10 * time.Second
+	// pingInterval is how often the connection is checked. Clients that do
+	// not answer within two intervals are disconnected.
+	pingInterval = 30 * time.Second
+)
+
+// Socket is a bidirectional method bridged over WebSocket. Every text frame
+// sent by the client is a request in JSON, and every response is sent back
+// as a text frame. An empty frame half-closes the call: the client sends no
+// more requests but still receives responses.
+type Socket struct {
+	// Method is the full gRPC method name.
+	Method string
+	// NewRequest and NewResponse return empty messages of the method types.
+	NewRequest  func() proto.Message
+	NewResponse func() proto.Message
+}
+
+type socketHandler struct {
+	conn     *grpc.ClientConn
+	socket   Socket
+	upgrader websocket.Upgrader
+}
+
+func newSocketHandler(conn *grpc.ClientConn, socket Socket, origins []string) *socketHandler {
+	h := &socketHandler{conn: conn, socket: socket}
+	h.upgrader.CheckOrigin = checkOrigin(origins)
+	return h
+}
+
+// checkOrigin accepts WebSocket connections from the CORS origins. With no
+// origins, only pages served from the same host can connect. Connections
+// without an Origin header are rejected, so clients other than browsers must
+// send one of the accepted origins.
+func checkOrigin(origins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return false
+		}
+		for _, allowed := range origins {
+			if allowed == "*" || allowed == origin {
+				return true
+			}
+		}
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+}
+
+// ServeHTTP bridges a WebSocket connection to a call of the method. Browsers
+// cannot set headers on WebSocket connections, so the bearer token can also
+// be given in the access_token query parameter. The bridge never logs URLs,
+// but proxies in front of it may.
+func (h *socketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	header := r.Header.Clone()
+	if token := r.URL.Query().Get("access_token"); token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+
+	ws, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already replied with an HTTP error.
+		return
+	}
+	s := &socket{ws: ws}
+	defer ws.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	desc := &grpc.StreamDesc{ClientStreams: true, ServerStreams: true}
+	stream, err := h.conn.NewStream(outgoing(ctx, header, r.RemoteAddr), desc, h.socket.Method)
+	if err != nil {
+		s.close(err)
+		return
+	}
+
+	go s.keepAlive(ctx)
+	go h.forwardFrames(s, stream, cancel)
+	s.close(h.forwardResponses(s, stream))
+}
+
+// forwardFrames sends the requests read from the WebSocket to the call.
+// Reading a frame only after the previous request was sent lets gRPC flow
+// control slow down clients sending faster than the server reads.
+func (h *socketHandler) forwardFrames(s *socket, stream grpc.ClientStream, cancel func()) {
+	s.ws.SetReadLimit(maxFrameSize)
+	s.ws.SetReadDeadline(time.Now().Add(2 * pingInterval))
+	s.ws.SetPongHandler(func(string) error {
+		return s.ws.SetReadDeadline(time.Now().Add(2 * pingInterval))
+	})
+
+	halfClosed := false
+	for {
+		_, data, err := s.ws.ReadMessage()
+		if err != nil {
+			// The client went away or closed the connection.
+			cancel()
+			return
+		}
+		if halfClosed {
+			continue
+		}
+		if len(data) == 0 {
+			halfClosed = true
+			stream.CloseSend()
+			continue
+		}
+
+		req := h.socket.NewRequest()
+		if err := protojson.Unmarshal(data, req); err != nil {
+			s.closeWith(websocket.CloseInvalidFramePayloadData, "invalid request: "+err.Error())
+			cancel()
+			return
+		}
+		if err := stream.SendMsg(req); err != nil {
+			// The call ended, forwardResponses reports how.
+			return
+		}
+	}
+}
+
+// forwardResponses sends the responses of the call to the WebSocket, until
+// the call ends.
+func (h *socketHandler) forwardResponses(s *socket, stream grpc.ClientStream) error {
+	for {
+		res := h.socket.NewResponse()
+		if err := stream.RecvMsg(res); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		data, err := protojson.Marshal(res)
+		if err != nil {
+			return status.Errorf(codes.Internal, "encoding response: %v", err)
+		}
+		s.ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if err := s.ws.WriteMessage(websocket.TextMessage, data); err != nil {
+			return status.Error(codes.Canceled, "client is gone")
+		}
+	}
+}
+
+// socket serializes the closing of a WebSocket connection.
+type socket struct {
+	ws   *websocket.Conn
+	once sync.Once
+}
+
+// close ends the connection with the close code of the call error err.
+func (s *socket) close(err error) {
+	if err == nil {
+		s.closeWith(websocket.CloseNormalClosure, "")
+		return
+	}
+	st := status.Convert(err)
+	s.closeWith(CloseStatusBase+int(st.Code()), st.Message())
+}
+
+func (s *socket) closeWith(code int, reason string) {
+	s.once.Do(func() {
+		// Close frames carry at most 123 bytes of reason.
+		if len(reason) > 123 {
+			reason = reason[:123]
+		}
+		deadline := time.Now().Add(writeTimeout)
+		if err := s.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), deadline); err != nil && err != websocket.ErrCloseSent {
+			log.Printf("Failed to close WebSocket: %v", err)
+		}
+	})
+}
+
+// keepAlive pings the client until ctx is done. Each pong extends the read
+// deadline set by forwardFrames.
+func (s *socket) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc"
+)
+
+// GreetEveryone answers every greeting with its first name and, once the
+// client half-closes, with the metadata of the call.
+func (echoServer) GreetEveryone(stream grpc.BidiStreamingServer[greetpb.GreetEveryoneRequest, greetpb.GreetEveryoneResponse]) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.Send(&greetpb.GreetEveryoneResponse{Result: echo(stream.Context())})
+		}
+		if err != nil {
+			return err
+		}
+		if err := errorFor(req.GetGreeting().GetFirstName()); err != nil {
+			return err
+		}
+		if err := stream.Send(&greetpb.GreetEveryoneResponse{Result: req.GetGreeting().GetFirstName()}); err != nil {
+			return err
+		}
+	}
+}
+
+func TestCheckOrigin(t *testing.T) {
+	tests := []struct {
+		origins []string
+		origin  string
+		want    bool
+	}{
+		{nil, "http://example.com:8082", true},
+		{nil, "http://EXAMPLE.com:8082", true},
+		{nil, "http://example.com:8083", false},
+		{nil, "https://evil.example", false},
+		{nil, "", false},
+		{[]string{"https://app.example"}, "https://app.example", true},
+		{[]string{"https://app.example"}, "https://evil.example", false},
+		{[]string{"https://app.example"}, "", false},
+		{[]string{"*"}, "https://evil.example", true},
+		{[]string{"*"}, "", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com:8082"+SocketPrefix, nil)
+		if tt.origin != "" {
+			r.Header.Set("Origin", tt.origin)
+		}
+		if got := checkOrigin(tt.origins)(r); got != tt.want {
+			t.Errorf("checkOrigin(%q) of %q = %v, want %v", tt.origins, tt.origin, got, tt.want)
+		}
+	}
+}
+
+// dialSocket opens a WebSocket to GreetEveryone on the handler at url, from
+// origin, with the query appended to the URL.
+func dialSocket(t *testing.T, url, origin, query string) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+	header := http.Header{}
+	if origin != "" {
+		header.Set("Origin", origin)
+	}
+	url = "ws" + strings.TrimPrefix(url, "http") + SocketPrefix + "/greet.v1.GreetService/GreetEveryone" + query
+	ws, res, err := websocket.DefaultDialer.Dial(url, header)
+	if err == nil {
+		t.Cleanup(func() { ws.Close() })
+		ws.SetReadDeadline(time.Now().Add(10 * time.Second))
+	}
+	return ws, res, err
+}
+
+// sendGreeting sends a GreetEveryone request greeting name.
+func sendGreeting(t *testing.T, ws *websocket.Conn, name string) {
+	t.Helper()
+	data, _ := json.Marshal(map[string]interface{}{"greeting": map[string]string{"firstName": name}})
+	if err := ws.WriteMessage(websocket.TextMessage, data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readResult returns the result of the next response.
+func readResult(t *testing.T, ws *websocket.Conn) string {
+	t.Helper()
+	_, data, err := ws.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res struct{ Result string }
+	if err := json.Unmarshal(data, &res); err != nil {
+		t.Fatal(err)
+	}
+	return res.Result
+}
+
+// readClose reads until the connection closes, and returns its close error.
+func readClose(t *testing.T, ws *websocket.Conn) *websocket.CloseError {
+	t.Helper()
+	for {
+		_, _, err := ws.ReadMessage()
+		if err == nil {
+			continue
+		}
+		var closeErr *websocket.CloseError
+		if !errors.As(err, &closeErr) {
+			t.Fatalf("read = %v, want a close frame", err)
+		}
+		return closeErr
+	}
+}
+
+func TestSocketOrigin(t *testing.T) {
+	url := startWeb(t)
+	for origin, want := range map[string]int{
+		"https://example.com":  http.StatusSwitchingProtocols,
+		url:                    http.StatusSwitchingProtocols,
+		"https://evil.example": http.StatusForbidden,
+		"":                     http.StatusForbidden,
+	} {
+		_, res, err := dialSocket(t, url, origin, "")
+		if res == nil {
+			t.Fatalf("Dial from %q = %v", origin, err)
+		}
+		if res.StatusCode != want {
+			t.Errorf("Dial from %q = %d, want %d", origin, res.StatusCode, want)
+		}
+	}
+}
+
+func TestSocketHalfClose(t *testing.T) {
+	url := startWeb(t)
+	ws, _, err := dialSocket(t, url, url, "?access_token=s3cr3t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sendGreeting(t, ws, "Alan")
+	if got := readResult(t, ws); got != "Alan" {
+		t.Errorf("result = %q, want Alan", got)
+	}
+	sendGreeting(t, ws, "Ana")
+	// Responses keep coming after the client half-closes.
+	if err := ws.WriteMessage(websocket.TextMessage, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := readResult(t, ws); got != "Ana" {
+		t.Errorf("result = %q, want Ana", got)
+	}
+	want := "authorization=Bearer s3cr3t accept-language= x-forwarded-for=127.0.0.1"
+	if got := readResult(t, ws); got != want {
+		t.Errorf("metadata = %q, want %q", got, want)
+	}
+	if got := readClose(t, ws); got.Code != websocket.CloseNormalClosure {
+		t.Errorf("close = %v, want %d", got, websocket.CloseNormalClosure)
+	}
+}
+
+func TestSocketClose(t *testing.T) {
+	url := startWeb(t)
+	tests := []struct {
+		name   string
+		frame  string
+		code   int
+		reason string
+	}{
+		{"not found", `{"greeting": {"firstName": "NOT_FOUND"}}`, 4005, "failed on purpose"},
+		{"unauthenticated", `{"greeting": {"firstName": "UNAUTHENTICATED"}}`, 4016, "failed on purpose"},
+		{"invalid request", `{"greeting": 1}`, websocket.CloseInvalidFramePayloadData, ""},
+	}
+	for _, tt := range tests {
+		ws, _, err := dialSocket(t, url, url, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ws.WriteMessage(websocket.TextMessage, []byte(tt.frame)); err != nil {
+			t.Fatal(err)
+		}
+		got := readClose(t, ws)
+		if got.Code != tt.code {
+			t.Errorf("%s: close = %v, want %d", tt.name, got, tt.code)
+		}
+		if tt.reason != "" && got.Text != tt.reason {
+			t.Errorf("%s: reason = %q, want %q", tt.name, got.Text, tt.reason)
+		}
+	}
+}
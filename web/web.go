@@ -0,0 +1,184 @@
+// Package web serves the gRPC services to browsers over gRPC-Web and the
+// Connect protocol, next to plain gRPC, from the server binaries. Calls are
+// bridged in process to the gRPC server, so they go through the same
+// authentication, authorization and rate limiting as native gRPC calls.
+package web
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/AlanKev117/go-grpc/ratelimit"
+	"github.com/rs/cors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Service describes the web handlers of a gRPC service.
+type Service struct {
+	// Connect builds the Connect handler of the service, calling the gRPC
+	// server through conn. It returns the path to mount the handler on.
+	Connect func(conn *grpc.ClientConn) (string, http.Handler)
+	// Sockets lists the bidirectional methods also served over WebSocket.
+	Sockets []Socket
+}
+
+// Flags configures the web listener of a server.
+type Flags struct {
+	// Address is where gRPC-Web and Connect are served. Empty disables them.
+	Address string
+	// CORSOrigins lists the origins browsers may call from, "*" for any.
+	CORSOrigins string
+}
+
+// RegisterFlags registers the web flags on fs, using the current values as
+// defaults.
+func (f *Flags) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&f.Address, "web-address", f.Address, "address serving gRPC-Web and Connect over HTTP/1.1 and HTTP/2; disabled when empty")
+	fs.StringVar(&f.CORSOrigins, "cors-origins", f.CORSOrigins, "comma separated origins allowed to make cross-origin calls, * for any")
+}
+
+// Serve starts serving services over Connect, gRPC-Web and gRPC on
+// f.Address, bridging the calls to s. The services must be registered on s
+// beforehand. It returns nil when no address is set.
+func (f *Flags) Serve(s *grpc.Server, services ...Service) (*http.Server, error) {
+	if f.Address == "" {
+		return nil, nil
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	go s.Serve(lis)
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := &http.Server{
+		Addr:              f.Address,
+		Handler:           Handler(conn, f.CORSOrigins, services...),
+		ReadHeaderTimeout: 10 * time.Second,
+		Protocols:         new(http.Protocols),
+	}
+	// gRPC and bidirectional Connect streams need HTTP/2, which browsers
+	// only speak over TLS, so both cleartext HTTP/1.1 and HTTP/2 are served.
+	srv.Protocols.SetHTTP1(true)
+	srv.Protocols.SetUnencryptedHTTP2(true)
+
+	webLis, err := net.Listen("tcp", f.Address)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	go func() {
+		if err := srv.Serve(webLis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Failed to serve gRPC-Web and Connect: %v", err)
+		}
+	}()
+	srv.RegisterOnShutdown(func() { conn.Close() })
+	log.Printf("Serving gRPC-Web and Connect on %s", f.Address)
+	return srv, nil
+}
+
+// Handler serves services over Connect, gRPC-Web and gRPC, and their
+// sockets over WebSocket, bridging the calls to conn. Browsers can call from
+// the comma separated origins, as with CORS.
+func Handler(conn *grpc.ClientConn, origins string, services ...Service) http.Handler {
+	socketOrigins := parseOrigins(origins)
+	mux := http.NewServeMux()
+	for _, service := range services {
+		mux.Handle(service.Connect(conn))
+		for _, socket := range service.Sockets {
+			mux.Handle(SocketPrefix+socket.Method, newSocketHandler(conn, socket, socketOrigins))
+		}
+	}
+	return CORS(origins, mux)
+}
+
+// CORS allows browsers from the comma separated origins to call h with the
+// gRPC-Web and Connect protocols. With no origins, h is returned as is.
+func CORS(origins string, h http.Handler) http.Handler {
+	allowed := parseOrigins(origins)
+	if len(allowed) == 0 {
+		return h
+	}
+	return cors.New(cors.Options{
+		AllowedOrigins: allowed,
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders: []string{
+			"Authorization",
+			"Content-Type",
+			"Accept",
+			"Connect-Protocol-Version",
+			"Connect-Timeout-Ms",
+			"Grpc-Timeout",
+			"X-Grpc-Web",
+			"X-User-Agent",
+		},
+		ExposedHeaders: []string{
+			"Grpc-Status",
+			"Grpc-Message",
+			"Grpc-Status-Details-Bin",
+			ratelimit.RetryAfterKey,
+		},
+		MaxAge: 2 * 60 * 60,
+	}).Handler(h)
+}
+
+func parseOrigins(origins string) []string {
+	var list []string
+	for _, origin := range strings.Split(origins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			list = append(list, origin)
+		}
+	}
+	return list
+}
+
+// outgoing returns the context of the gRPC call bridging a web call made
+// from peerAddr with header. It carries the credentials and languages of the
+// caller and, for rate limiting and the ownership of sessions, its address.
+func outgoing(ctx context.Context, header http.Header, peerAddr string) context.Context {
+	md := metadata.MD{}
+	if authorization := header.Get("Authorization"); authorization != "" {
+		md.Set("authorization", authorization)
+	}
+	if languages := header.Values("Accept-Language"); len(languages) > 0 {
+		md.Set("accept-language", languages...)
+	}
+	if host, _, err := net.SplitHostPort(peerAddr); err == nil {
+		md.Set(ratelimit.ForwardedForKey, host)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// connectError converts the error of a gRPC call, including its status
+// details and trailers, into a Connect error.
+func connectError(err error, trailer metadata.MD) error {
+	st := status.Convert(err)
+	cerr := connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+	for _, d := range st.Proto().GetDetails() {
+		if detail, err := connect.NewErrorDetail(d); err == nil {
+			cerr.AddDetail(detail)
+		}
+	}
+	for key, values := range trailer {
+		for _, v := range values {
+			cerr.Meta().Add(key, v)
+		}
+	}
+	return cerr
+}
@@ -0,0 +1,285 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/AlanKev117/go-grpc/auth"
+	"github.com/AlanKev117/go-grpc/greet/greetpb"
+	"github.com/AlanKev117/go-grpc/greet/greetpb/greetpbconnect"
+	"github.com/AlanKev117/go-grpc/grpctest"
+	"github.com/AlanKev117/go-grpc/ratelimit"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// echoServer greets with the metadata of the call, and fails the greetings
+// of the names of status codes with that code.
+type echoServer struct {
+	greetpb.UnimplementedGreetServiceServer
+}
+
+// errorFor returns the error of greeting name, if any.
+func errorFor(name string) error {
+	var code codes.Code
+	if err := code.UnmarshalJSON([]byte(`"` + name + `"`)); err != nil {
+		return nil
+	}
+	st := status.New(code, "failed on purpose")
+	if code == codes.ResourceExhausted {
+		st, _ = st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Second)})
+	}
+	return st.Err()
+}
+
+// echo describes the metadata of ctx.
+func echo(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var parts []string
+	for _, key := range []string{"authorization", "accept-language", ratelimit.ForwardedForKey} {
+		parts = append(parts, key+"="+strings.Join(md.Get(key), ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (echoServer) Greet(ctx context.Context, req *greetpb.GreetRequest) (*greetpb.GreetResponse, error) {
+	if err := errorFor(req.GetGreeting().GetFirstName()); err != nil {
+		grpc.SetTrailer(ctx, metadata.Pairs(ratelimit.RetryAfterKey, "1000"))
+		return nil, err
+	}
+	return &greetpb.GreetResponse{Result: echo(ctx)}, nil
+}
+
+func (echoServer) GreetManyTimes(req *greetpb.GreetManyTimesRequest, stream greetpb.GreetService_GreetManyTimesServer) error {
+	if err := stream.Send(&greetpb.GreetManyTimesResponse{Result: echo(stream.Context())}); err != nil {
+		return err
+	}
+	return errorFor(req.GetGreeting().GetFirstName())
+}
+
+func (echoServer) LongGreet(stream greetpb.GreetService_LongGreetServer) error {
+	var names []string
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&greetpb.LongGreetResponse{Result: strings.Join(names, ",")})
+		}
+		if err != nil {
+			return err
+		}
+		if err := errorFor(req.GetGreeting().GetFirstName()); err != nil {
+			return err
+		}
+		names = append(names, req.GetGreeting().GetFirstName())
+	}
+}
+
+// startWeb serves echoServer in memory, and its web handler over HTTP. It
+// returns the URL of the handler.
+func startWeb(t *testing.T) string {
+	t.Helper()
+	conn := grpctest.Serve(t, func(s *grpc.Server) {
+		greetpb.RegisterGreetServiceServer(s, echoServer{})
+	}, grpctest.Options{})
+	srv := httptest.NewServer(Handler(conn, "https://example.com", GreetService))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// testContext returns a context ending with the test.
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func greeting(name string) *greetpb.Greeting {
+	return &greetpb.Greeting{FirstName: name}
+}
+
+func TestForwardedMetadata(t *testing.T) {
+	url := startWeb(t)
+	for name, opts := range map[string][]connect.ClientOption{
+		"Connect":  nil,
+		"gRPC-Web": {connect.WithGRPCWeb()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := greetpbconnect.NewGreetServiceClient(http.DefaultClient, url, opts...)
+			req := connect.NewRequest(&greetpb.GreetRequest{Greeting: greeting("Alan")})
+			req.Header().Set("Authorization", "Bearer s3cr3t")
+			req.Header().Set("Accept-Language", "es-MX")
+			// Metadata the bridge does not forward is dropped.
+			req.Header().Set(ratelimit.ForwardedForKey, "203.0.113.7")
+			res, err := c.Greet(testContext(t), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := "authorization=Bearer s3cr3t accept-language=es-MX x-forwarded-for=127.0.0.1"
+			if got := res.Msg.GetResult(); got != want {
+				t.Errorf("metadata = %q, want %q", got, want)
+			}
+
+			stream, err := c.GreetManyTimes(testContext(t), connect.NewRequest(&greetpb.GreetManyTimesRequest{Greeting: greeting("Alan")}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !stream.Receive() || stream.Msg().GetResult() != "authorization= accept-language= x-forwarded-for=127.0.0.1" {
+				t.Errorf("stream metadata = %v, %v", stream.Msg(), stream.Err())
+			}
+			stream.Close()
+		})
+	}
+}
+
+// tokenVerifier accepts the token "s3cr3t".
+type tokenVerifier struct{}
+
+func (tokenVerifier) Verify(token string) (*auth.Principal, error) {
+	if token != "s3cr3t" {
+		return nil, auth.ErrInvalidToken
+	}
+	return &auth.Principal{Name: "alice", Source: "test"}, nil
+}
+
+func TestAuthentication(t *testing.T) {
+	a := auth.NewAuthenticator(tokenVerifier{})
+	conn := grpctest.Serve(t, func(s *grpc.Server) {
+		greetpb.RegisterGreetServiceServer(s, echoServer{})
+	}, grpctest.Options{ServerOptions: []grpc.ServerOption{grpc.UnaryInterceptor(a.UnaryServerInterceptor())}})
+	srv := httptest.NewServer(Handler(conn, "", GreetService))
+	t.Cleanup(srv.Close)
+	c := greetpbconnect.NewGreetServiceClient(http.DefaultClient, srv.URL)
+
+	greet := func(authorization string) error {
+		req := connect.NewRequest(&greetpb.GreetRequest{Greeting: greeting("Alan")})
+		if authorization != "" {
+			req.Header().Set("Authorization", authorization)
+		}
+		_, err := c.Greet(testContext(t), req)
+		return err
+	}
+	if err := greet("Bearer s3cr3t"); err != nil {
+		t.Errorf("Greet with a valid token = %v", err)
+	}
+	if err := greet(""); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("Greet without a token = %v, want Unauthenticated", err)
+	}
+	if err := greet("Bearer wrong"); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("Greet with an invalid token = %v, want Unauthenticated", err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	c := greetpbconnect.NewGreetServiceClient(http.DefaultClient, startWeb(t))
+	for name, want := range map[string]connect.Code{
+		"INVALID_ARGUMENT":    connect.CodeInvalidArgument,
+		"UNAUTHENTICATED":     connect.CodeUnauthenticated,
+		"PERMISSION_DENIED":   connect.CodePermissionDenied,
+		"NOT_FOUND":           connect.CodeNotFound,
+		"FAILED_PRECONDITION": connect.CodeFailedPrecondition,
+		"UNAVAILABLE":         connect.CodeUnavailable,
+	} {
+		_, err := c.Greet(testContext(t), connect.NewRequest(&greetpb.GreetRequest{Greeting: greeting(name)}))
+		if got := connect.CodeOf(err); got != want {
+			t.Errorf("Greet failing with %s = %v, want %v", name, err, want)
+		}
+	}
+
+	// Details and trailers reach the client.
+	_, err := c.Greet(testContext(t), connect.NewRequest(&greetpb.GreetRequest{Greeting: greeting("RESOURCE_EXHAUSTED")}))
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) || cerr.Code() != connect.CodeResourceExhausted {
+		t.Fatalf("Greet = %v, want ResourceExhausted", err)
+	}
+	if cerr.Message() != "failed on purpose" {
+		t.Errorf("message = %q", cerr.Message())
+	}
+	if len(cerr.Details()) != 1 {
+		t.Fatalf("details = %v, want a RetryInfo", cerr.Details())
+	}
+	if detail, err := cerr.Details()[0].Value(); err != nil {
+		t.Error(err)
+	} else if info, ok := detail.(*errdetails.RetryInfo); !ok || info.GetRetryDelay().AsDuration() != time.Second {
+		t.Errorf("detail = %v, want a RetryInfo of 1s", detail)
+	}
+	if got := cerr.Meta().Get(ratelimit.RetryAfterKey); got != "1000" {
+		t.Errorf("%s = %q, want 1000", ratelimit.RetryAfterKey, got)
+	}
+
+	// Streams end with the error of the gRPC call.
+	stream, err := c.GreetManyTimes(testContext(t), connect.NewRequest(&greetpb.GreetManyTimesRequest{Greeting: greeting("NOT_FOUND")}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for stream.Receive() {
+	}
+	if connect.CodeOf(stream.Err()) != connect.CodeNotFound {
+		t.Errorf("GreetManyTimes = %v, want NotFound", stream.Err())
+	}
+}
+
+func TestClientStream(t *testing.T) {
+	// Client streams need HTTP/2.
+	srv := httptest.NewUnstartedServer(nil)
+	conn := grpctest.Serve(t, func(s *grpc.Server) {
+		greetpb.RegisterGreetServiceServer(s, echoServer{})
+	}, grpctest.Options{})
+	srv.Config.Handler = Handler(conn, "", GreetService)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	c := greetpbconnect.NewGreetServiceClient(srv.Client(), srv.URL)
+
+	send := func(names ...string) (string, error) {
+		stream := c.LongGreet(testContext(t))
+		for _, name := range names {
+			if err := stream.Send(&greetpb.LongGreetRequest{Greeting: greeting(name)}); err != nil {
+				break
+			}
+		}
+		res, err := stream.CloseAndReceive()
+		if err != nil {
+			return "", err
+		}
+		return res.Msg.GetResult(), nil
+	}
+	if got, err := send("Alan", "Ana"); err != nil || got != "Alan,Ana" {
+		t.Errorf("LongGreet = %q, %v, want every name", got, err)
+	}
+	if _, err := send("Alan", "INVALID_ARGUMENT"); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("LongGreet = %v, want InvalidArgument", err)
+	}
+}
+
+func TestCORS(t *testing.T) {
+	url := startWeb(t)
+	preflight := func(origin string) string {
+		req, _ := http.NewRequest(http.MethodOptions, url+"/greet.v1.GreetService/Greet", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "authorization,content-type")
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res.Header.Get("Access-Control-Allow-Origin")
+	}
+	if got := preflight("https://example.com"); got != "https://example.com" {
+		t.Errorf("allowed origin = %q", got)
+	}
+	if got := preflight("https://evil.example"); got != "" {
+		t.Errorf("other origin allowed as %q", got)
+	}
+}